@@ -0,0 +1,72 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/amdw/gopoker/history"
+	"log"
+	"math/rand"
+	"os"
+)
+
+func main() {
+	var player string
+	var maxDeals int
+	var seed int64
+	flag.StringVar(&player, "player", "", "Player to report on (by default, the player whose hole cards were dealt in each hand)")
+	flag.IntVar(&maxDeals, "deals", 10000, "Maximum number of ways of completing the board to play out for each all-in")
+	flag.Int64Var(&seed, "seed", 1, "Random seed for all-ins with too many ways of completing the board to play out every one")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %v [flags] file...\n", os.Args[0])
+		fmt.Fprintln(flag.CommandLine.Output(), "Report all-in adjusted winnings over PokerStars-style text hand history files.")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var hands []*history.HandHistory
+	for _, filename := range flag.Args() {
+		f, err := os.Open(filename)
+		if err != nil {
+			log.Fatalf("Could not open %v: %v", filename, err)
+		}
+		imported, problems, err := history.ImportText(f)
+		f.Close()
+		if err != nil {
+			log.Fatalf("Could not read %v: %v", filename, err)
+		}
+		for _, p := range problems {
+			log.Printf("%v: skipped hand: %v", filename, p)
+		}
+		hands = append(hands, imported...)
+	}
+
+	report, err := history.NewEVReport(context.Background(), hands, player, maxDeals, 0, rand.New(rand.NewSource(seed)))
+	if err != nil {
+		log.Fatalf("Could not produce report: %v", err)
+	}
+	report.WriteText(os.Stdout)
+}
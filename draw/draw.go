@@ -0,0 +1,162 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package draw
+
+import (
+	"errors"
+	"fmt"
+	"github.com/amdw/gopoker/poker"
+)
+
+// Each player has five cards, all of which play
+const HandSize = 5
+
+// Each player may replace all five of their cards, so no more than five players can be sure of a full draw from one pack
+const MaxPlayers = 52 / (2 * HandSize)
+
+// Deal five cards to each player
+func Deal(pack *poker.Pack, players int) [][]poker.Card {
+	if players < 1 || players > MaxPlayers {
+		panic(fmt.Sprintf("Between 1 and %v players supported, found %v", MaxPlayers, players))
+	}
+	playerCards := make([][]poker.Card, players)
+	for i := 0; i < players; i++ {
+		playerCards[i] = pack.Cards[i*HandSize : (i+1)*HandSize]
+	}
+	return playerCards
+}
+
+// Replace the cards each player throws (discards[i] for player i, which must come from their hand) with the next cards
+// in the pack after those dealt by Deal, returning the new hands. Each player's kept cards stay where they were.
+func DrawRound(pack *poker.Pack, playerCards, discards [][]poker.Card) [][]poker.Card {
+	next := len(playerCards) * HandSize
+	result := make([][]poker.Card, len(playerCards))
+	for i, hand := range playerCards {
+		thrown := poker.NewCardSet(discards[i]...)
+		newHand := make([]poker.Card, len(hand))
+		for j, c := range hand {
+			if thrown.Contains(c) {
+				newHand[j] = pack.Cards[next]
+				next++
+			} else {
+				newHand[j] = c
+			}
+		}
+		result[i] = newHand
+	}
+	return result
+}
+
+type PlayerOutcome struct {
+	Player         int
+	Level          poker.HandLevel
+	Won            bool
+	PotFractionWon float64
+}
+
+// Classify each player's five cards and work out how the pot is divided.
+// Outcomes are in the same order as playerCards, with player numbers starting from 1.
+func PlayerOutcomes(playerCards [][]poker.Card) []PlayerOutcome {
+	result := make([]PlayerOutcome, len(playerCards))
+	bestLevel := poker.MinLevel()
+	for i, hand := range playerCards {
+		result[i] = PlayerOutcome{i + 1, classify(hand), false, 0}
+		if poker.Beats(result[i].Level, bestLevel) {
+			bestLevel = result[i].Level
+		}
+	}
+
+	winners := 0
+	for _, outcome := range result {
+		if !poker.Beats(bestLevel, outcome.Level) {
+			winners++
+		}
+	}
+	for i, outcome := range result {
+		if !poker.Beats(bestLevel, outcome.Level) {
+			result[i].Won = true
+			result[i].PotFractionWon = 1.0 / float64(winners)
+		}
+	}
+	return result
+}
+
+// Classify a hand without disturbing the order of its cards
+func classify(hand []poker.Card) poker.HandLevel {
+	var cardsBuf [HandSize]poker.Card
+	cards := cardsBuf[:len(hand)]
+	copy(cards, hand)
+	return poker.ClassifyHand(cards)
+}
+
+// Every choice of cards to throw from a hand: for five cards, the 32 choices from standing pat to drawing five
+func DiscardChoices(hand []poker.Card) [][]poker.Card {
+	result := make([][]poker.Card, 0, 1<<uint(len(hand)))
+	for k := 0; k <= len(hand); k++ {
+		result = append(result, poker.AllCardCombinations(hand, k)...)
+	}
+	return result
+}
+
+// A simple strategy for choosing discards: stand pat on a straight or better, keep any pairs, trips or quads and
+// throw the rest, or with nothing at all, keep the highest card and draw four
+func StandardDiscards(hand []poker.Card) []poker.Card {
+	level := classify(hand)
+	if level.Class >= poker.Straight {
+		return nil
+	}
+	countsByRank := make([]int, poker.Ace+1)
+	for _, c := range hand {
+		countsByRank[c.Rank]++
+	}
+	result := []poker.Card{}
+	keptHighCard := false
+	for _, c := range hand {
+		if level.Class == poker.HighCard && c.Rank == level.Tiebreaks[0] && !keptHighCard {
+			keptHighCard = true
+			continue
+		}
+		if countsByRank[c.Rank] < 2 {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// Check a hand and the cards to be thrown from it, along with the number of players
+func ValidateDiscards(yourCards, discards []poker.Card, players int) error {
+	if players < 2 || players > MaxPlayers {
+		return errors.New(fmt.Sprintf("Between 2 and %v players required, found %v", MaxPlayers, players))
+	}
+	if len(yourCards) != HandSize {
+		return errors.New(fmt.Sprintf("Exactly %v player cards required, found %v", HandSize, len(yourCards)))
+	}
+	if err := poker.ValidateStandardCards(yourCards); err != nil {
+		return err
+	}
+	hand := poker.NewCardSet(yourCards...)
+	if hand.Count() != len(yourCards) {
+		return errors.New("Duplicate cards found in player cards")
+	}
+	if discardSet := poker.NewCardSet(discards...); discardSet.Count() != len(discards) || !hand.ContainsAll(discardSet) {
+		return errors.New(fmt.Sprintf("Discards %v must be distinct cards from the player cards", discards))
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package draw
+
+import (
+	"github.com/amdw/gopoker/poker"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var h = poker.TestMakeHand
+var hl = poker.TestMakeHandLevel
+
+func TestDealAndDraw(t *testing.T) {
+	pack := poker.NewPack()
+	pack.Shuffle(rand.New(rand.NewSource(1234)))
+	playerCards := Deal(&pack, MaxPlayers)
+	discards := make([][]poker.Card, MaxPlayers)
+	for i, hand := range playerCards {
+		discards[i] = hand
+	}
+	discards[0] = playerCards[0][1:3]
+	final := DrawRound(&pack, playerCards, discards)
+
+	seen := poker.CardSet(0)
+	for i, hand := range final {
+		if len(hand) != HandSize {
+			t.Errorf("Expected %v cards for player %v, found %v", HandSize, i+1, len(hand))
+		}
+		seen = seen.Union(poker.NewCardSet(hand...))
+	}
+	if seen.Count() != HandSize*MaxPlayers {
+		t.Errorf("Expected %v distinct cards, found %v", HandSize*MaxPlayers, seen.Count())
+	}
+	if final[0][0] != playerCards[0][0] || final[0][3] != playerCards[0][3] || final[0][4] != playerCards[0][4] {
+		t.Errorf("Expected kept cards to stay in place, found %v from %v", final[0], playerCards[0])
+	}
+	if poker.NewCardSet(final[0]...).Intersects(poker.NewCardSet(discards[0]...)) {
+		t.Errorf("Expected %v to be replaced, found %v", discards[0], final[0])
+	}
+	for i := 1; i < MaxPlayers; i++ {
+		if poker.NewCardSet(final[i]...).Intersects(poker.NewCardSet(playerCards[i]...)) {
+			t.Errorf("Expected player %v to draw five new cards, found %v", i+1, final[i])
+		}
+	}
+}
+
+func TestPlayerOutcomes(t *testing.T) {
+	playerCards := [][]poker.Card{
+		h("AS", "AC", "KD", "KH", "2S"),
+		h("5S", "4C", "3D", "2H", "AH"),
+		h("QS", "QC", "QD", "7H", "7S"),
+	}
+	outcomes := PlayerOutcomes(playerCards)
+	expected := []poker.HandLevel{hl("TwoPair", "A", "K", "2"), hl("Straight", "5"), hl("FullHouse", "Q", "7")}
+	for i, outcome := range outcomes {
+		if outcome.Player != i+1 || !reflect.DeepEqual(expected[i], outcome.Level) || outcome.Won != (i == 2) {
+			t.Errorf("Unexpected outcome for player %v: %v", i+1, outcome)
+		}
+	}
+	if !reflect.DeepEqual(h("AS", "AC", "KD", "KH", "2S"), playerCards[0]) {
+		t.Errorf("Expected player cards to be left alone, found %v", playerCards[0])
+	}
+}
+
+func TestDiscardChoices(t *testing.T) {
+	hand := h("AS", "AC", "KD", "KH", "2S")
+	choices := DiscardChoices(hand)
+	if len(choices) != 32 || len(choices[0]) != 0 || len(choices[31]) != 5 {
+		t.Fatalf("Expected 32 choices from standing pat to drawing five, found %v", choices)
+	}
+	seen := make(map[poker.CardSet]bool)
+	for _, choice := range choices {
+		seen[poker.NewCardSet(choice...)] = true
+	}
+	if len(seen) != 32 {
+		t.Errorf("Expected 32 distinct choices, found %v", len(seen))
+	}
+}
+
+func TestStandardDiscards(t *testing.T) {
+	testCases := []struct {
+		hand, expected []poker.Card
+	}{
+		{h("5S", "4C", "3D", "2H", "AH"), nil},
+		{h("AS", "AC", "KD", "KH", "2S"), h("2S")},
+		{h("9S", "9C", "KD", "4H", "2S"), h("KD", "4H", "2S")},
+		{h("9S", "JC", "KD", "4H", "2S"), h("9S", "JC", "4H", "2S")},
+	}
+	for _, tc := range testCases {
+		if result := StandardDiscards(tc.hand); !poker.CardsEqual(tc.expected, result) {
+			t.Errorf("Expected to throw %v from %v, found %v", tc.expected, tc.hand, result)
+		}
+	}
+}
+
+func TestValidateDiscards(t *testing.T) {
+	if err := ValidateDiscards(h("AS", "AC", "KD", "KH", "2S"), h("2S"), 3); err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+	testCases := []struct {
+		yourCards, discards []poker.Card
+		players             int
+		expected            string
+	}{
+		{h("AS", "AC", "KD", "KH", "2S"), nil, 6, "Between 2 and 5 players"},
+		{h("AS", "AC", "KD", "KH"), nil, 2, "Exactly 5"},
+		{h("AS", "AC", "KD", "KH", "AS"), nil, 2, "Duplicate"},
+		{h("AS", "AC", "KD", "KH", "2S"), h("3S"), 2, "Discards"},
+		{h("AS", "AC", "KD", "KH", "JK1"), nil, 2, "not in a standard pack"},
+	}
+	for _, tc := range testCases {
+		err := ValidateDiscards(tc.yourCards, tc.discards, tc.players)
+		if err == nil || !strings.Contains(err.Error(), tc.expected) {
+			t.Errorf("Expected error containing %q, found %v", tc.expected, err)
+		}
+	}
+}
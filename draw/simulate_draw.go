@@ -0,0 +1,101 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package draw
+
+import (
+	"context"
+	"github.com/amdw/gopoker/poker"
+	"math/rand"
+	"sort"
+)
+
+// Simulate hands of five-card draw where we throw the given discards from our hand, and each opponent is dealt a
+// random hand and draws using StandardDiscards. The cards must pass ValidateDiscards.
+func SimulateDiscard(yourCards, discards []poker.Card, players, handsToPlay int, randGen *rand.Rand) *poker.Simulator {
+	sim, _ := SimulateDiscardContext(context.Background(), yourCards, discards, players, handsToPlay, randGen, nil)
+	return sim
+}
+
+// SimulateDiscard, stopped early with ctx.Err() (and the results so far) if ctx is cancelled, with progress reports
+// sent to progress if it is non-nil
+func SimulateDiscardContext(ctx context.Context, yourCards, discards []poker.Card, players, handsToPlay int, randGen *rand.Rand, progress poker.ProgressFunc) (*poker.Simulator, error) {
+	deal := discardDealer(yourCards, discards, players)()
+	return poker.Simulate(ctx, poker.HighRanking, players, handsToPlay, deal, randGen, progress)
+}
+
+// SimulateDiscardContext with the hands shared out between several goroutines, one per CPU unless workers is
+// positive. Only the seed of randGen affects the results.
+func SimulateDiscardParallelContext(ctx context.Context, yourCards, discards []poker.Card, players, handsToPlay, workers int, randGen *rand.Rand, progress poker.ProgressFunc) (*poker.Simulator, error) {
+	return poker.SimulateParallel(ctx, poker.HighRanking, players, handsToPlay, workers, discardDealer(yourCards, discards, players), randGen, progress)
+}
+
+// Dealers which deal us our known cards, throw our chosen discards and draw for the opponents with StandardDiscards
+func discardDealer(yourCards, discards []poker.Card, players int) func() poker.Dealer {
+	fixing := poker.Fixing{}
+	fixing.Place(0, yourCards...)
+	return func() poker.Dealer {
+		p := poker.NewPack()
+		playerDiscards := make([][]poker.Card, players)
+		return func(randGen *rand.Rand) ([]poker.GameOutcome, error) {
+			p.ShuffleFixed(randGen, &fixing)
+			playerCards := Deal(&p, players)
+			playerDiscards[0] = discards
+			for i := 1; i < players; i++ {
+				playerDiscards[i] = StandardDiscards(playerCards[i])
+			}
+			outcomes := PlayerOutcomes(DrawRound(&p, playerCards, playerDiscards))
+			result := make([]poker.GameOutcome, len(outcomes))
+			for i, o := range outcomes {
+				result[i] = poker.GameOutcome{Player: o.Player, Level: o.Level, PotFractionWon: o.PotFractionWon}
+			}
+			return result, nil
+		}
+	}
+}
+
+// The simulated results of one choice of discards
+type DiscardOutcome struct {
+	Discards  []poker.Card
+	Simulator *poker.Simulator
+}
+
+// Simulate every choice of discards from our hand (see DiscardChoices), ranked by win probability, best first, with
+// pot equity breaking ties. Every choice is played out against the same starting hands for the opponents, which
+// takes out the luck of the deal; the cards drawn still differ between choices.
+func AnalyseDiscards(ctx context.Context, yourCards []poker.Card, players, handsToPlay, workers int, randGen *rand.Rand) ([]DiscardOutcome, error) {
+	seed := randGen.Int63()
+	choices := DiscardChoices(yourCards)
+	result := make([]DiscardOutcome, len(choices))
+	for i, discards := range choices {
+		sim, err := SimulateDiscardParallelContext(ctx, yourCards, discards, players, handsToPlay, workers, rand.New(rand.NewSource(seed)), nil)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = DiscardOutcome{discards, sim}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		si, sj := result[i].Simulator, result[j].Simulator
+		if si.WinCount != sj.WinCount {
+			return si.WinCount > sj.WinCount
+		}
+		return si.PotsWon > sj.PotsWon
+	})
+	return result, nil
+}
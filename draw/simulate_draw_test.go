@@ -0,0 +1,79 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package draw
+
+import (
+	"context"
+	"github.com/amdw/gopoker/poker"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestSimulateDiscard(t *testing.T) {
+	hand := h("AS", "AC", "KD", "7H", "2S")
+	sim := SimulateDiscard(hand, h("KD", "7H", "2S"), 3, 5000, rand.New(rand.NewSource(1234)))
+	poker.TestAssertSimSanity(sim, 3, 5000, t)
+	// Throwing the aces can only make things worse
+	bad := SimulateDiscard(hand, h("AS", "AC", "7H"), 3, 5000, rand.New(rand.NewSource(1234)))
+	if sim.Equity().Mean <= bad.Equity().Mean+0.1 {
+		t.Errorf("Expected keeping aces to do much better: %v vs %v", sim.Equity(), bad.Equity())
+	}
+}
+
+func TestSimulateDiscardParallel(t *testing.T) {
+	hand, discards := h("9S", "10S", "JS", "QS", "2D"), h("2D")
+	sim, err := SimulateDiscardParallelContext(context.Background(), hand, discards, 2, 5000, 4, rand.New(rand.NewSource(1234)), nil)
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+	poker.TestAssertSimSanity(sim, 2, 5000, t)
+	other, _ := SimulateDiscardParallelContext(context.Background(), hand, discards, 2, 5000, 1, rand.New(rand.NewSource(1234)), nil)
+	if !reflect.DeepEqual(sim, other) {
+		t.Errorf("Expected identical results with different numbers of workers")
+	}
+}
+
+func TestAnalyseDiscards(t *testing.T) {
+	hand := h("KS", "KC", "KD", "7H", "2S")
+	outcomes, err := AnalyseDiscards(context.Background(), hand, 3, 2000, 0, rand.New(rand.NewSource(1234)))
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if len(outcomes) != 32 {
+		t.Fatalf("Expected 32 discard choices, found %v", len(outcomes))
+	}
+	for i, outcome := range outcomes {
+		poker.TestAssertSimSanity(outcome.Simulator, 3, 2000, t)
+		if i > 0 && outcome.Simulator.WinCount > outcomes[i-1].Simulator.WinCount {
+			t.Errorf("Expected choices in descending order of wins, found %v after %v", outcome.Simulator.WinCount, outcomes[i-1].Simulator.WinCount)
+		}
+	}
+	// The best play keeps the trips
+	if best := outcomes[0].Discards; poker.NewCardSet(best...).Intersects(poker.NewCardSet(h("KS", "KC", "KD")...)) {
+		t.Errorf("Expected to keep three kings, found best discard %v", best)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := AnalyseDiscards(ctx, hand, 3, 2000, 0, rand.New(rand.NewSource(1234))); err != context.Canceled {
+		t.Errorf("Expected cancellation, found %v", err)
+	}
+}
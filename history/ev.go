@@ -0,0 +1,348 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package history
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/amdw/gopoker/holdem"
+	"github.com/amdw/gopoker/poker"
+	"io"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// How the players in an all-in stood to do before the rest of the board was dealt, compared with how they did.
+// The slices are indexed by seat.
+type AllInEquity struct {
+	Point    AllInPoint
+	Pots     []AllInPot
+	Deals    int       // The number of ways of completing the board which were played out, over all the pots
+	Exact    bool      // Whether every way of completing the board was played out, rather than a random sample
+	Pot      int       // The chips in the pot, ignoring any rake
+	Equity   []float64 // The share of the pot each player could expect to win
+	Expected []float64 // The chips each player could expect to win
+	Actual   []int     // The chips each player won on the board which was dealt, ignoring any rake
+}
+
+// The main pot or a side pot of an all-in, as divided on the board which was dealt, and the street from which it was
+// decided by the cards
+type AllInPot struct {
+	Pot    poker.Pot
+	Street holdem.Street
+}
+
+// The earliest street from which a pot the player contested was decided by the cards, if they contested any
+func (e *AllInEquity) Street(seat int) (holdem.Street, bool) {
+	result, found := holdem.Showdown, false
+	for _, p := range e.Pots {
+		for _, eligible := range p.Pot.Eligible {
+			if eligible == seat && p.Street <= result {
+				result, found = p.Street, true
+			}
+		}
+	}
+	return result, found
+}
+
+// Work out each player's equity in an all-in. Each pot is assessed from the street on which it stopped growing, by
+// playing out every way of completing the board from there if there are at most maxDeals of them, or otherwise
+// maxDeals random ones. The pots are divided on each board as at the showdown, so split pots and hi-lo games are taken
+// into account. The deals are shared between workers goroutines as for the game simulators (one per CPU if workers
+// is not positive), so the results depend only on the seed of randGen. If ctx is cancelled, ctx.Err() is returned.
+func (hh *HandHistory) Equity(ctx context.Context, point AllInPoint, maxDeals, workers int, randGen *rand.Rand) (*AllInEquity, error) {
+	g, ok := poker.LookupGame(hh.Game)
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("Unknown game %q", hh.Game))
+	}
+	if maxDeals < 1 {
+		return nil, errors.New(fmt.Sprintf("Need to play out at least one deal, found %v", maxDeals))
+	}
+	actual, err := hh.Showdown()
+	if err != nil {
+		return nil, err
+	}
+	contributions := hh.Contributions()
+	result := AllInEquity{Point: point, Exact: true, Equity: make([]float64, len(hh.Seats)), Expected: make([]float64, len(hh.Seats)), Actual: actual.Winnings}
+	for _, c := range contributions {
+		result.Pot += c
+	}
+	for _, pot := range actual.Pots {
+		result.Pots = append(result.Pots, AllInPot{Pot: pot, Street: point.potStreet(pot, contributions)})
+	}
+	for street := holdem.Preflop; street <= holdem.Showdown; street++ {
+		var pots []int // The pots decided from this street
+		for i, p := range result.Pots {
+			if p.Street == street {
+				pots = append(pots, i)
+			}
+		}
+		if len(pots) == 0 {
+			continue
+		}
+		won, deals, exact, err := hh.playOut(ctx, g, hh.Board[:street.TableCards()], pots, maxDeals, workers, randGen)
+		if err != nil {
+			return nil, err
+		}
+		for seat, chips := range won {
+			result.Expected[seat] += float64(chips) / float64(deals)
+		}
+		result.Deals += deals
+		result.Exact = result.Exact && exact
+	}
+	for seat := range result.Expected {
+		result.Equity[seat] = result.Expected[seat] / float64(result.Pot)
+	}
+	return &result, nil
+}
+
+// Divide the pots at a showdown on every way of completing the board from the given table cards if there are at most
+// maxDeals of them, or otherwise on maxDeals random ones, as for Equity. Returns the chips each seat won from the pots
+// with the given indexes over all the boards, the number of boards played out, and whether they were all of them.
+func (hh *HandHistory) playOut(ctx context.Context, g poker.Game, board []poker.Card, pots []int, maxDeals, workers int, randGen *rand.Rand) ([]int, int, bool, error) {
+	playerCards, contributions, folded := hh.playerCards(), hh.Contributions(), hh.Folded()
+	layout := g.Layout()
+	fixing, err := hh.fixedCards(g, board)
+	if err != nil {
+		return nil, 0, false, err
+	}
+	settle := func(tableCards []poker.Card, won []int) {
+		_, settlement := showdown(g, tableCards, playerCards, contributions, folded, hh.Button)
+		for _, i := range pots {
+			for seat, chips := range settlement.Pots[i].Won {
+				won[seat] += chips
+			}
+		}
+	}
+
+	// As for poker.BoardCompletions, but from the cards in play in the game's pack, which may be short or have jokers
+	pack := g.NewPack()
+	dead := poker.NewCardSet(fixing.Cards...)
+	var available []poker.Card
+	for _, c := range pack.Cards[:pack.Size()] {
+		if !dead.Contains(c) {
+			available = append(available, c)
+		}
+	}
+	needed := layout.TableCards - len(board)
+	exact := poker.CombinationCount(len(available), needed) <= maxDeals
+	var completions [][]poker.Card
+	deals := maxDeals
+	if exact {
+		completions = poker.AllCardCombinations(available, needed)
+		deals = len(completions)
+		randGen = nil
+	}
+
+	chunks := poker.ChunkCount(deals)
+	won := make([][]int, chunks)
+	errs := make([]error, chunks)
+	poker.RunParallel(deals, workers, randGen, func(chunk, hands int, chunkRandGen *rand.Rand) {
+		won[chunk] = make([]int, len(hh.Seats))
+		if exact {
+			start, end := poker.ChunkRange(deals, chunk)
+			tableCards := append(make([]poker.Card, 0, layout.TableCards), board...)
+			_, errs[chunk] = poker.RunHands(ctx, end-start, func() {
+				settle(append(tableCards, completions[start]...), won[chunk])
+				start++
+			}, nil)
+			return
+		}
+		// Deal the rest of the board from a pack with the known cards held in place
+		p := g.NewPack()
+		_, errs[chunk] = poker.RunHands(ctx, hands, func() {
+			p.ShuffleFixed(chunkRandGen, &fixing)
+			tableCards, _ := layout.Deal(&p, len(hh.Seats))
+			settle(tableCards, won[chunk])
+		}, nil)
+	})
+
+	result := make([]int, len(hh.Seats))
+	for chunk := range won {
+		if errs[chunk] != nil {
+			return nil, 0, false, errs[chunk]
+		}
+		for seat, chips := range won[chunk] {
+			result[seat] += chips
+		}
+	}
+	return result, deals, exact, nil
+}
+
+// A gap between hands of a cash game of longer than this starts a new session
+const SessionBreak = 30 * time.Minute
+
+// A player's hands in one tournament, or in a spell of cash games played for the same currency
+type Session struct {
+	Start, End time.Time
+	Tournament string  // The site's number for the tournament, or blank for cash games
+	Currency   string  // The currency of cash games played for money, in which case amounts are in cents
+	Hands      int     // The hands the player was dealt into
+	AllIns     int     // The hands in which the player was all in, or called an all-in, before the river
+	Won        int     // The player's net winnings over all the hands, after any rake
+	Luck       float64 // The chips the player won in all-ins beyond what their equity entitled them to
+}
+
+func (s *Session) Name() string {
+	if s.Tournament != "" {
+		return "Tournament #" + s.Tournament
+	}
+	if s.Currency == "" {
+		return "Cash game"
+	}
+	return fmt.Sprintf("Cash game (%v)", s.Currency)
+}
+
+// The player's winnings with the luck of their all-ins taken out, as if each all-in had paid out their equity
+func (s *Session) Adjusted() float64 {
+	return float64(s.Won) - s.Luck
+}
+
+// Format an amount of chips, or of money (given in cents) in cash games
+func (s *Session) FormatAmount(amount float64) string {
+	if s.Tournament == "" && s.Currency != "" {
+		return fmt.Sprintf("%.2f", amount/100)
+	}
+	return fmt.Sprintf("%.0f", amount)
+}
+
+// One all-in on a player's luck curve. Luck is measured in big blinds, so that hands at different stakes add up.
+type LuckPoint struct {
+	Hand   *HandHistory
+	Seat   int
+	Street holdem.Street // The earliest street from which a pot the player contested was decided by the cards
+	Equity *AllInEquity
+	Luck   float64 // The big blinds the player won beyond their equity in this hand
+	Total  float64 // The player's luck over this and all the earlier all-ins
+}
+
+// A player's all-in adjusted results over a number of hands
+type EVReport struct {
+	Sessions []*Session  // In order of their start
+	Curve    []LuckPoint // In the order the hands were played
+}
+
+// When the hand was played, if it is known
+func (hh *HandHistory) PlayedAt() time.Time {
+	if hh.Source == nil {
+		return time.Time{}
+	}
+	return hh.Source.Time
+}
+
+// The seat of the player with the given name, or if it is blank the hero of an imported hand, or -1 if they did not play
+func (hh *HandHistory) seatOf(player string) int {
+	if player == "" && hh.Source != nil {
+		player = hh.Source.Hero
+	}
+	for seat, s := range hh.Seats {
+		if player != "" && s.Name == player {
+			return seat
+		}
+	}
+	return -1
+}
+
+// Work out a player's all-in adjusted results over the given hands with betting, in which the player's name is as
+// given (or if it is blank, they are the hero of each imported hand). Hands are taken in the order they were played,
+// and each all-in before the river is assessed with HandHistory.Equity. If ctx is cancelled, ctx.Err() is returned.
+func NewEVReport(ctx context.Context, hands []*HandHistory, player string, maxDeals, workers int, randGen *rand.Rand) (*EVReport, error) {
+	hands = append([]*HandHistory{}, hands...)
+	sort.SliceStable(hands, func(i, j int) bool {
+		return hands[i].PlayedAt().Before(hands[j].PlayedAt())
+	})
+	var result EVReport
+	latest := make(map[string]*Session) // The latest session for each tournament, and for cash games in each currency
+	total := 0.0
+	for _, hh := range hands {
+		seat := hh.seatOf(player)
+		if seat < 0 || hh.Rules == nil {
+			continue
+		}
+		var source Source
+		if hh.Source != nil {
+			source = *hh.Source
+		}
+		key := "Cash " + source.Currency
+		if source.Tournament != "" {
+			key = "Tournament " + source.Tournament
+		}
+		s, ok := latest[key]
+		if !ok || (source.Tournament == "" && source.Time.Sub(s.End) > SessionBreak) {
+			s = &Session{Start: source.Time, Tournament: source.Tournament, Currency: source.Currency}
+			latest[key] = s
+			result.Sessions = append(result.Sessions, s)
+		}
+		s.End = source.Time
+		s.Hands++
+		s.Won += hh.Results[seat].Won - hh.Contributions()[seat]
+
+		point, ok := hh.AllIn()
+		if !ok || hh.Folded()[seat] {
+			continue
+		}
+		equity, err := hh.Equity(ctx, point, maxDeals, workers, randGen)
+		if err != nil && ctx.Err() != nil {
+			return nil, err
+		}
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("Could not work out the equity in hand %v: %v", hh.HandName(), err))
+		}
+		street, ok := equity.Street(seat)
+		if !ok || street >= holdem.River {
+			continue
+		}
+		luck := float64(equity.Actual[seat]) - equity.Expected[seat]
+		s.AllIns++
+		s.Luck += luck
+		total += luck / float64(hh.Rules.BigBlind)
+		result.Curve = append(result.Curve, LuckPoint{Hand: hh, Seat: seat, Street: street, Equity: equity, Luck: luck / float64(hh.Rules.BigBlind), Total: total})
+	}
+	sort.SliceStable(result.Sessions, func(i, j int) bool {
+		return result.Sessions[i].Start.Before(result.Sessions[j].Start)
+	})
+	return &result, nil
+}
+
+// The site's number for the hand, if it was imported
+func (hh *HandHistory) HandName() string {
+	if hh.Source == nil || hh.Source.HandID == "" {
+		return "(not imported)"
+	}
+	return "#" + hh.Source.HandID
+}
+
+// Write the report as plain text: a table of the sessions, followed by the luck curve
+func (r *EVReport) WriteText(w io.Writer) {
+	fmt.Fprintf(w, "%-16v  %-25v  %6v  %7v  %12v  %12v  %12v\n", "Start", "Session", "Hands", "All-ins", "Won", "Luck", "Adjusted")
+	for _, s := range r.Sessions {
+		fmt.Fprintf(w, "%-16v  %-25v  %6v  %7v  %12v  %12v  %12v\n", s.Start.Format("2006-01-02 15:04"), s.Name(), s.Hands, s.AllIns,
+			s.FormatAmount(float64(s.Won)), s.FormatAmount(s.Luck), s.FormatAmount(s.Adjusted()))
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Luck curve (big blinds)")
+	fmt.Fprintf(w, "%-16v  %-16v  %-7v  %6v  %8v  %8v\n", "Time", "Hand", "Street", "Equity", "Luck", "Total")
+	for _, p := range r.Curve {
+		fmt.Fprintf(w, "%-16v  %-16v  %-7v  %5.1f%%  %+8.2f  %+8.2f\n", p.Hand.PlayedAt().Format("2006-01-02 15:04"), p.Hand.HandName(),
+			p.Street, 100*p.Equity.Equity[p.Seat], p.Luck, p.Total)
+	}
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package history
+
+import (
+	"bytes"
+	"context"
+	"github.com/amdw/gopoker/holdem"
+	"github.com/amdw/gopoker/poker"
+	"math"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Alice gets her aces all in on the flop against Bob's trips, and hits one of her two outs
+const flopAllInHand = `PokerStars Hand #200000000004:  Hold'em No Limit ($0.01/$0.02 USD) - 2020/03/14 12:10:00 ET
+Table 'Alpha II' 6-max Seat #3 is the button
+Seat 1: Alice ($2.00 in chips)
+Seat 3: Bob ($1.50 in chips)
+Bob: posts small blind $0.01
+Alice: posts big blind $0.02
+*** HOLE CARDS ***
+Dealt to Alice [Ah Ad]
+Bob: calls $0.01
+Alice: checks
+*** FLOP *** [Kc Ks 2d]
+Alice: bets $0.04
+Bob: raises $1.44 to $1.48 and is all-in
+Alice: calls $1.44
+*** TURN *** [Kc Ks 2d] [7h]
+*** RIVER *** [Kc Ks 2d 7h] [Ac]
+*** SHOW DOWN ***
+Alice: shows [Ah Ad] (a full house, Aces full of Kings)
+Bob: shows [Kh 9h] (three of a kind, Kings)
+Alice collected $2.97 from pot
+*** SUMMARY ***
+Total pot $3 | Rake $0.03
+Board [Kc Ks 2d 7h Ac]
+Seat 1: Alice (big blind) showed [Ah Ad] and won ($2.97) with a full house, Aces full of Kings
+Seat 3: Bob (button) (small blind) showed [Kh 9h] and lost with three of a kind, Kings
+`
+
+func TestEquity(t *testing.T) {
+	hh := importHands(t, flopAllInHand)[0]
+	point, ok := hh.AllIn()
+	if !ok || point.Street != holdem.Flop || len(point.Board) != 3 {
+		t.Fatalf("Expected an all-in on the flop, found %+v", point)
+	}
+	equity, err := hh.Equity(context.Background(), point, 1000, 0, rand.New(rand.NewSource(1234)))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// Alice wins with one of the two aces, unless the last king comes too
+	if !equity.Exact || equity.Deals != 990 || equity.Pot != 300 || math.Abs(equity.Equity[0]-85.0/990) > 1e-9 || math.Abs(equity.Equity[1]-905.0/990) > 1e-9 {
+		t.Errorf("Unexpected equity %+v", equity)
+	}
+	if equity.Actual[0] != 300 || equity.Actual[1] != 0 || math.Abs(equity.Expected[0]-300*85.0/990) > 1e-9 {
+		t.Errorf("Unexpected winnings %+v", equity)
+	}
+
+	sampled, err := hh.Equity(context.Background(), point, 500, 0, rand.New(rand.NewSource(1234)))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if sampled.Exact || sampled.Deals != 500 || math.Abs(sampled.Equity[0]+sampled.Equity[1]-1) > 1e-9 || math.Abs(sampled.Equity[0]-85.0/990) > 0.05 {
+		t.Errorf("Unexpected sampled equity %+v", sampled)
+	}
+	if _, err := hh.Equity(context.Background(), point, 0, 0, rand.New(rand.NewSource(1234))); err == nil {
+		t.Errorf("Expected error with no deals")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := hh.Equity(ctx, point, 500, 0, rand.New(rand.NewSource(1234))); err != context.Canceled {
+		t.Errorf("Expected cancellation, found %v", err)
+	}
+	if parallel, err := hh.Equity(context.Background(), point, 500, 1, rand.New(rand.NewSource(1234))); err != nil || !reflect.DeepEqual(sampled, parallel) {
+		t.Errorf("Expected identical results with different numbers of workers, found %+v and %+v (error %v)", sampled, parallel, err)
+	}
+
+	// With a three-way all-in, the side pot is only contested by two of the players
+	hh = importHands(t, tournamentHand)[0]
+	point, _ = hh.AllIn()
+	equity, err = hh.Equity(context.Background(), point, 2000, 0, rand.New(rand.NewSource(1234)))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if equity.Exact || equity.Pot != 7000 || math.Abs(equity.Expected[0]+equity.Expected[1]+equity.Expected[2]-7000) > 1e-6 || equity.Expected[0] > 3000 {
+		t.Errorf("Unexpected equity %+v", equity)
+	}
+
+	// A side pot bet on until the river is not down to luck, though the main pot was decided before the flop
+	pack := poker.NewPack()
+	pack.Shuffle(rand.New(rand.NewSource(1234)))
+	hand, err := holdem.NewHand(holdem.BettingRules{SmallBlind: 1, BigBlind: 2}, []int{20, 100, 100}, 0, &pack)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	playActions(t, hand, []testAction{{holdem.AllIn, 0}, {holdem.Call, 0}, {holdem.Call, 0}, {holdem.Bet, 10}, {holdem.Call, 0}})
+	if err := hand.Play(holdem.CheckOrCall); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	hh = RecordHand(hand)
+	point, _ = hh.AllIn()
+	equity, err = hh.Equity(context.Background(), point, 1000, 0, rand.New(rand.NewSource(1234)))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(equity.Pots) != 2 || equity.Pots[0].Street != holdem.Preflop || equity.Pots[1].Street != holdem.River || equity.Exact || equity.Deals != 1001 {
+		t.Errorf("Unexpected pots %+v", equity)
+	}
+	if street, ok := equity.Street(1); !ok || street != holdem.Preflop {
+		t.Errorf("Expected seat 1 to have contested a pot from before the flop, found %v", street)
+	}
+	mainPot, sidePot := equity.Pots[0].Pot, equity.Pots[1].Pot
+	if math.Abs(equity.Expected[0]+equity.Expected[1]+equity.Expected[2]-float64(equity.Pot)) > 1e-6 || equity.Expected[0] > float64(mainPot.Amount) {
+		t.Errorf("Unexpected equity %+v", equity)
+	}
+	for _, seat := range []int{1, 2} {
+		if equity.Expected[seat] < float64(sidePot.Won[seat]) || equity.Expected[seat] > float64(sidePot.Won[seat]+mainPot.Amount) {
+			t.Errorf("Expected seat %v to win %v from the side pot and a share of the main pot, found %v", seat, sidePot.Won[seat], equity.Expected[seat])
+		}
+	}
+}
+
+func TestEVReport(t *testing.T) {
+	hands := importHands(t, tournamentHand+"\n"+hiLoHand+"\n"+flopAllInHand+"\n"+cashHand)
+	report, err := NewEVReport(context.Background(), hands, "", 1000, 0, rand.New(rand.NewSource(1234)))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(report.Sessions) != 3 {
+		t.Fatalf("Expected three sessions, found %v", len(report.Sessions))
+	}
+	cash, tournament, hiLo := report.Sessions[0], report.Sessions[1], report.Sessions[2]
+	luck := 300 - 300*85.0/990
+	if cash.Name() != "Cash game (USD)" || cash.Hands != 2 || cash.AllIns != 1 || cash.Won != -76+147 || math.Abs(cash.Luck-luck) > 1e-9 || math.Abs(cash.Adjusted()-(71-luck)) > 1e-9 {
+		t.Errorf("Unexpected cash session %+v", cash)
+	}
+	if cash.FormatAmount(cash.Adjusted()) != "-2.03" {
+		t.Errorf("Expected adjusted winnings of -2.03, found %v", cash.FormatAmount(cash.Adjusted()))
+	}
+	if tournament.Name() != "Tournament #3000000001" || tournament.Hands != 1 || tournament.AllIns != 1 || tournament.Won != 4000 || tournament.Luck <= 0 {
+		t.Errorf("Unexpected tournament session %+v", tournament)
+	}
+	if hiLo.Hands != 1 || hiLo.AllIns != 0 || hiLo.Won != -1 {
+		t.Errorf("Unexpected hi-lo session %+v", hiLo)
+	}
+
+	if len(report.Curve) != 2 || report.Curve[0].Hand.Source.HandID != "200000000004" || report.Curve[1].Seat != 1 {
+		t.Fatalf("Unexpected luck curve %+v", report.Curve)
+	}
+	if math.Abs(report.Curve[0].Luck-luck/2) > 1e-9 || math.Abs(report.Curve[1].Total-report.Curve[0].Luck-tournament.Luck/100) > 1e-9 {
+		t.Errorf("Unexpected luck curve %+v", report.Curve)
+	}
+
+	var buf bytes.Buffer
+	report.WriteText(&buf)
+	for _, expected := range []string{"Cash game (USD)", "Tournament #3000000001", "#200000000004", "Flop", "+137.12"} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("Expected to find %q in %v", expected, buf.String())
+		}
+	}
+
+	// Bob played in every hand, losing the flop all-in against the odds
+	report, err = NewEVReport(context.Background(), hands, "Bob", 1000, 0, rand.New(rand.NewSource(1234)))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(report.Sessions) != 3 || len(report.Curve) != 2 || report.Curve[0].Luck >= 0 {
+		t.Errorf("Unexpected report for Bob: %+v", report)
+	}
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package history
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/amdw/gopoker/holdem"
+	"github.com/amdw/gopoker/poker"
+	"time"
+)
+
+// Cards which are written to JSON as strings, e.g. ["AS","10D"]
+type Cards []poker.Card
+
+func (c Cards) MarshalJSON() ([]byte, error) {
+	cardStrings := make([]string, len(c))
+	for i, card := range c {
+		cardStrings[i] = card.String()
+	}
+	return json.Marshal(cardStrings)
+}
+
+func (c *Cards) UnmarshalJSON(data []byte) error {
+	var cardStrings []string
+	if err := json.Unmarshal(data, &cardStrings); err != nil {
+		return err
+	}
+	result := make(Cards, len(cardStrings))
+	for i, s := range cardStrings {
+		card, err := poker.MakeCard(s)
+		if err != nil {
+			return err
+		}
+		result[i] = card
+	}
+	*c = result
+	return nil
+}
+
+// A player's seat at the table
+type Seat struct {
+	Name  string // May be blank, in which case the seat number is used
+	Stack int    // Chips at the start of the hand
+	Cards Cards  // Hole cards, if they are known
+}
+
+// What one player got out of the hand
+type Result struct {
+	Seat           int    // Index into the seats
+	Hand           string // The hand shown down, if any
+	Cards          Cards  // The cards making up the hand
+	Won            int    // Chips won from the pot, in hands with betting
+	PotFractionWon float64
+}
+
+// Where a hand imported from an online poker site was played
+type Source struct {
+	Site       string
+	HandID     string // The site's number for the hand
+	Tournament string // The site's number for the tournament, or blank for a cash game
+	Table      string
+	Time       time.Time // As written in the hand history, ignoring its time zone
+	Currency   string    // For cash games played for money, e.g. "USD", in which case all amounts are in cents
+	Hero       string    // The player whose hole cards were dealt to the owner of the hand history, if any
+}
+
+// A record of one hand, with everything needed to replay it
+type HandHistory struct {
+	Game    string               // The key the game is registered under (see poker.LookupGame)
+	Rules   *holdem.BettingRules // The betting structure, or nil if the hand was dealt without any betting
+	Button  int
+	Seats   []Seat
+	Board   Cards // The table cards which were dealt
+	Actions []holdem.Action
+	Results []Result // One for each seat, in seat order
+	Source  *Source  // Where the hand was imported from, or nil if it was not
+}
+
+// Record a hand of Texas Hold'em played with betting, once it is over
+func RecordHand(h *holdem.Hand) *HandHistory {
+	rules := h.Rules
+	result := HandHistory{Game: "holdem", Rules: &rules, Button: h.Button, Board: append(Cards{}, h.Board()...),
+		Actions: append([]holdem.Action{}, h.Actions...)}
+	for seat, stack := range h.StartingStacks {
+		result.Seats = append(result.Seats, Seat{Stack: stack, Cards: append(Cards{}, h.PlayerCards[seat]...)})
+		result.Results = append(result.Results, Result{Seat: seat, Won: h.Winnings[seat], PotFractionWon: float64(h.Winnings[seat]) / float64(h.Pot())})
+	}
+	for _, o := range h.Outcomes {
+		result.Results[o.Player-1].Hand = o.Level.PrettyPrint()
+		result.Results[o.Player-1].Cards = o.Cards
+	}
+	return &result
+}
+
+// Record a hand dealt without betting in the game registered under the given key, as on the play pages
+func RecordDeal(gameKey string, tableCards []poker.Card, playerCards [][]poker.Card) (*HandHistory, error) {
+	g, ok := poker.LookupGame(gameKey)
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("Unknown game %q", gameKey))
+	}
+	result := HandHistory{Game: gameKey, Board: append(Cards{}, tableCards...)}
+	for _, cards := range playerCards {
+		result.Seats = append(result.Seats, Seat{Cards: append(Cards{}, cards...)})
+	}
+	for _, o := range g.Outcomes(tableCards, playerCards) {
+		result.Results = append(result.Results, Result{Seat: o.Player - 1, Hand: o.Level.PrettyPrint(), Cards: o.Cards, PotFractionWon: o.PotFractionWon})
+	}
+	return &result, nil
+}
+
+func (hh *HandHistory) JSON() ([]byte, error) {
+	return json.MarshalIndent(hh, "", "  ")
+}
+
+// Read a hand history written by JSON
+func ParseJSON(data []byte) (*HandHistory, error) {
+	var result HandHistory
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, errors.New(fmt.Sprintf("Could not parse hand history: %v", err))
+	}
+	if len(result.Results) != len(result.Seats) {
+		return nil, errors.New(fmt.Sprintf("Expected one result for each of %v seats, found %v", len(result.Seats), len(result.Results)))
+	}
+	return &result, nil
+}
+
+// The name of the player in the given seat
+func (hh *HandHistory) SeatName(seat int) string {
+	if name := hh.Seats[seat].Name; name != "" {
+		return name
+	}
+	return fmt.Sprintf("Seat %v", seat+1)
+}
+
+// The actions chosen by the players, leaving out those which happen automatically
+func (hh *HandHistory) PlayerActions() []holdem.Action {
+	var result []holdem.Action
+	for _, a := range hh.Actions {
+		if !a.Type.Automatic() {
+			result = append(result, a)
+		}
+	}
+	return result
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package history
+
+import (
+	"bytes"
+	"github.com/amdw/gopoker/holdem"
+	_ "github.com/amdw/gopoker/omaha8"
+	"github.com/amdw/gopoker/poker"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var h = poker.TestMakeHand
+var hs = poker.TestMakeHands
+
+// Play a hand in which seat 0 raises and everyone calls down
+func playTestHand(t *testing.T) *holdem.Hand {
+	pack := poker.NewPack()
+	pack.Shuffle(rand.New(rand.NewSource(1234)))
+	hand, err := holdem.NewHand(holdem.BettingRules{SmallBlind: 1, BigBlind: 2, Ante: 1}, []int{100, 50, 200}, 0, &pack)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := hand.Act(holdem.Raise, 10); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := hand.Play(holdem.CheckOrCall); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return hand
+}
+
+func TestRecordHand(t *testing.T) {
+	hand := playTestHand(t)
+	hh := RecordHand(hand)
+	if hh.Game != "holdem" || len(hh.Seats) != 3 || hh.Seats[1].Stack != 50 || len(hh.Board) != 5 || len(hh.Results) != 3 {
+		t.Fatalf("Unexpected history %+v", hh)
+	}
+	total := 0
+	for seat, r := range hh.Results {
+		if r.Seat != seat || r.Won != hand.Winnings[seat] || r.Hand == "" || len(r.Cards) != 5 {
+			t.Errorf("Unexpected result %+v for seat %v", r, seat)
+		}
+		total += r.Won
+	}
+	if total != 3*(10+1) {
+		t.Errorf("Expected a pot of 33, found %v", total)
+	}
+	if actions := hh.PlayerActions(); len(actions) != 12 || actions[0].Type != holdem.Raise {
+		t.Errorf("Unexpected player actions %+v", actions)
+	}
+	if err := Verify(hh); err != nil {
+		t.Errorf("Unexpected error verifying: %v", err)
+	}
+
+	data, err := hh.JSON()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(string(data), `"Limit": "No-Limit"`) || !strings.Contains(string(data), `"Type": "raise"`) {
+		t.Errorf("Expected readable limits and actions in %v", string(data))
+	}
+	parsed, err := ParseJSON(data)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(hh, parsed) {
+		t.Errorf("Expected %+v after round trip, found %+v", hh, parsed)
+	}
+
+	var buf bytes.Buffer
+	hh.WriteText(&buf)
+	text := buf.String()
+	for _, expected := range []string{"Texas Hold'em No-Limit (blinds 1/2, ante 1)", "Seat 1 is the button", "Seat 2 (50 in chips)",
+		"*** PREFLOP ***", "Seat 1: raise 10", "*** RIVER *** " + formatCards(hh.Board), "*** SUMMARY ***"} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Expected to find %q in %v", expected, text)
+		}
+	}
+}
+
+func TestRecordDeal(t *testing.T) {
+	tableCards := h("AS", "2H", "7C", "KD", "JC")
+	playerCards := hs(h("AH", "AD", "KS", "QS"), h("3C", "4D", "9H", "9S"))
+	hh, err := RecordDeal("omaha8", tableCards, playerCards)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if hh.Rules != nil || len(hh.Actions) != 0 || hh.Results[0].PotFractionWon != 0.5 || hh.Results[1].PotFractionWon != 0.5 {
+		t.Errorf("Expected the pot to be split hi-lo, found %+v", hh.Results)
+	}
+	if err := Verify(hh); err != nil {
+		t.Errorf("Unexpected error verifying: %v", err)
+	}
+	if _, err := NewReplayer(hh); err == nil {
+		t.Errorf("Expected error replaying a hand without betting")
+	}
+	if _, err := RecordDeal("wibble", tableCards, playerCards); err == nil {
+		t.Errorf("Expected error for unknown game")
+	}
+
+	hh.Results[1].PotFractionWon = 0
+	if err := Verify(hh); err == nil {
+		t.Errorf("Expected error for wrong pot fraction")
+	}
+	hh.Seats[1].Cards = h("3C", "4D", "9H", "AS")
+	if err := Verify(hh); err == nil || !strings.Contains(err.Error(), "Found duplicate card AS") {
+		t.Errorf("Expected duplicate card error, found %v", err)
+	}
+	if _, err := ParseJSON([]byte(`{"Game": "holdem", "Board": ["XX"]}`)); err == nil {
+		t.Errorf("Expected error for bad card")
+	}
+}
+
+func TestVerifyTampering(t *testing.T) {
+	tamperings := map[string]func(hh *HandHistory){
+		"won":         func(hh *HandHistory) { hh.Results[0].Won++ },
+		"hand":        func(hh *HandHistory) { hh.Results[1].Hand = "Four As (plus K)" },
+		"amount":      func(hh *HandHistory) { hh.Actions[len(hh.Actions)-1].Amount++ },
+		"extra":       func(hh *HandHistory) { hh.Actions = append(hh.Actions, hh.Actions[len(hh.Actions)-1]) },
+		"missing":     func(hh *HandHistory) { hh.Actions = hh.Actions[:len(hh.Actions)-1] },
+		"seat":        func(hh *HandHistory) { hh.Actions[8].Seat = 0 },
+		"cards":       func(hh *HandHistory) { hh.Seats[2].Cards = hh.Seats[0].Cards },
+		"stack":       func(hh *HandHistory) { hh.Seats[1].Stack = 5 },
+		"unknownGame": func(hh *HandHistory) { hh.Game = "wibble" },
+	}
+	for name, tamper := range tamperings {
+		hh := RecordHand(playTestHand(t))
+		tamper(hh)
+		if err := Verify(hh); err == nil {
+			t.Errorf("Expected error verifying after tampering with %v", name)
+		}
+	}
+}
@@ -0,0 +1,610 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package history
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"github.com/amdw/gopoker/holdem"
+	_ "github.com/amdw/gopoker/omaha"  // Registers the Omaha games, so that their hands can be imported
+	_ "github.com/amdw/gopoker/omaha8" // Likewise the Omaha/8 games
+	"github.com/amdw/gopoker/poker"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A problem with one hand in a file of hand histories. The hand is skipped, but the rest of the file is still read.
+type ImportError struct {
+	Line   int    // The line of the file where the problem was found, starting from 1
+	HandID string // The site's number for the hand, if it could be read
+	Err    error
+}
+
+func (e ImportError) Error() string {
+	if e.HandID == "" {
+		return fmt.Sprintf("Line %v: %v", e.Line, e.Err)
+	}
+	return fmt.Sprintf("Line %v (hand #%v): %v", e.Line, e.HandID, e.Err)
+}
+
+// The keys of the games which can be imported, by the names the site gives them
+var importedGames = map[string]string{
+	"Hold'em":            "holdem",
+	"Omaha":              "omaha",
+	"Omaha Hi/Lo":        "omaha8",
+	"5 Card Omaha":       "omaha5",
+	"5 Card Omaha Hi/Lo": "bigo",
+	"6 Card Omaha":       "omaha6",
+}
+
+var importedLimits = map[string]holdem.BettingLimit{"No Limit": holdem.NoLimit, "Pot Limit": holdem.PotLimit, "Limit": holdem.FixedLimit}
+
+// Actions which put chips into the pot, by the words which come before the amount
+var amountActions = map[string]holdem.ActionType{"calls ": holdem.Call, "bets ": holdem.Bet,
+	"posts small blind ": holdem.PostSmallBlind, "posts big blind ": holdem.PostBigBlind, "posts the ante ": holdem.PostAnte,
+	"posts small & big blinds ": holdem.PostBigBlind}
+
+// Phrases in lines which do not affect the play of the hand, such as players coming and going or chatting
+var ignoredPhrases = []string{"mucks hand", "doesn't show hand", "sits out", "is sitting out", "is disconnected",
+	"is connected", "has timed out", "has returned", "joins the table", "leaves the table", "was removed from the table",
+	"will be allowed to play after the button", " said, ", "finished the tournament", "wins the tournament", "re-buys",
+	"cashed out"}
+
+var (
+	headerRegexp    = regexp.MustCompile(`^PokerStars (?:Zoom |Home Game )?(?:Hand|Game) #(\d+): +(.*)$`)
+	gameRegexp      = regexp.MustCompile(`(5 Card Omaha Hi/Lo|5 Card Omaha|6 Card Omaha|Omaha Hi/Lo|Omaha|Hold'em) (No Limit|Pot Limit|Limit)`)
+	tournamentRegex = regexp.MustCompile(`Tournament #(\d+)`)
+	stakesRegexp    = regexp.MustCompile(`\(([^()/ ]+)/([^()/ ]+)(?: ([A-Z]{3}))?\)`)
+	timeRegexp      = regexp.MustCompile(`\d{4}/\d{1,2}/\d{1,2} \d{1,2}:\d{2}:\d{2}`)
+	tableRegexp     = regexp.MustCompile(`^Table '(.*)' .*Seat #(\d+) is the button$`)
+	seatRegexp      = regexp.MustCompile(`^Seat (\d+): (.+) \((\S+) in chips[^)]*\)(.*)$`)
+	streetRegexp    = regexp.MustCompile(`^\*\*\* (.+?) \*\*\*(.*)$`)
+	cardsRegexp     = regexp.MustCompile(`\[([^\]]*)\]`)
+	amountRegexp    = regexp.MustCompile(`^(\d+)(?:\.(\d{1,2}))?$`)
+	dealtRegexp     = regexp.MustCompile(`^Dealt to (.+?)(?: \[(.*)\])?$`)
+	uncalledRegexp  = regexp.MustCompile(`^Uncalled bet \((\S+)\) returned to (.+)$`)
+	collectedRegexp = regexp.MustCompile(`^(.+) collected (\S+) from (?:main |side )?pot(?:-\d+)?$`)
+	raiseRegexp     = regexp.MustCompile(`^raises (\S+) to (\S+)$`)
+	summaryRegexp   = regexp.MustCompile(`^Seat (\d+): .* (?:showed|mucked) \[(.*?)\]`)
+)
+
+// Read a file of hand histories in the text format of PokerStars, of Hold'em or Omaha (high or hi-lo, with four to
+// six hole cards) in cash games or tournaments. Each hand which cannot be read is skipped, and reported with the line
+// where the problem was found. The error is only for failing to read the file at all.
+func ImportText(r io.Reader) ([]*HandHistory, []ImportError, error) {
+	var hands []*HandHistory
+	var problems []ImportError
+	var imp *textImporter // The hand being read, if any
+	skipping := false     // Whether to ignore lines up to the start of the next hand, after a problem
+	finish := func() {
+		if imp == nil {
+			return
+		}
+		if hh, err := imp.finish(); err != nil {
+			problems = append(problems, ImportError{imp.lastLine, imp.hh.Source.HandID, err})
+		} else {
+			hands = append(hands, hh)
+		}
+		imp = nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := scanner.Text()
+		if lineNum == 1 {
+			line = strings.TrimPrefix(line, "\ufeff") // Byte order mark
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if headerRegexp.MatchString(line) {
+			finish()
+			var err error
+			imp, err = newTextImporter(line)
+			if err != nil {
+				problems = append(problems, ImportError{lineNum, headerRegexp.FindStringSubmatch(line)[1], err})
+			}
+			if imp != nil {
+				imp.lastLine = lineNum
+			}
+			skipping = imp == nil
+			continue
+		}
+		if imp == nil {
+			if !skipping {
+				problems = append(problems, ImportError{Line: lineNum, Err: errors.New(fmt.Sprintf("Expected the start of a hand, found %q", line))})
+				skipping = true
+			}
+			continue
+		}
+		imp.lastLine = lineNum
+		if err := imp.readLine(line); err != nil {
+			problems = append(problems, ImportError{lineNum, imp.hh.Source.HandID, err})
+			imp, skipping = nil, true
+		}
+	}
+	finish()
+	return hands, problems, scanner.Err()
+}
+
+// Reads the lines of one hand
+type textImporter struct {
+	hh       *HandHistory
+	game     poker.Game
+	cents    bool           // Whether amounts are money, written with two decimal places
+	seatNums map[int]int    // From the site's seat numbers to indices into the seats
+	names    map[string]int // From players' names to indices into the seats
+	button   int            // The site's seat number for the button
+	section  string         // The last "*** ... ***" heading, or blank before the first
+	street   holdem.Street
+	stacks   []int // Chips each player has behind
+	bets     []int // Chips each player has put in on the current street, not counting antes
+	lastLine int
+}
+
+// Start reading a hand from its first line
+func newTextImporter(header string) (*textImporter, error) {
+	match := headerRegexp.FindStringSubmatch(header)
+	imp := textImporter{hh: &HandHistory{Rules: &holdem.BettingRules{}, Source: &Source{Site: "PokerStars", HandID: match[1]}},
+		seatNums: make(map[int]int), names: make(map[string]int)}
+	rest := match[2]
+
+	gameMatch := gameRegexp.FindStringSubmatch(rest)
+	if gameMatch == nil {
+		return nil, errors.New(fmt.Sprintf("Unsupported game in %q", rest))
+	}
+	imp.hh.Game = importedGames[gameMatch[1]]
+	imp.game, _ = poker.LookupGame(imp.hh.Game)
+	imp.hh.Rules.Limit = importedLimits[gameMatch[2]]
+
+	if tournamentMatch := tournamentRegex.FindStringSubmatch(rest); tournamentMatch != nil {
+		imp.hh.Source.Tournament = tournamentMatch[1]
+	}
+	stakesMatch := stakesRegexp.FindStringSubmatch(rest)
+	if stakesMatch == nil {
+		return nil, errors.New(fmt.Sprintf("Could not find the stakes in %q", rest))
+	}
+	imp.cents = strings.IndexAny(stakesMatch[1], "$€£") == 0
+	small, err := imp.parseAmount(stakesMatch[1])
+	if err != nil {
+		return nil, err
+	}
+	big, err := imp.parseAmount(stakesMatch[2])
+	if err != nil {
+		return nil, err
+	}
+	if imp.cents {
+		// The amounts parsed, so the currency symbol is followed by a digit
+		imp.hh.Source.Currency = stakesMatch[3]
+		if imp.hh.Source.Currency == "" {
+			imp.hh.Source.Currency = stakesMatch[1][:strings.IndexAny(stakesMatch[1], "0123456789")]
+		}
+	}
+	if imp.hh.Rules.Limit == holdem.FixedLimit {
+		// The stakes are the small and big bets, and the blinds are half of the small bet and the small bet itself
+		small, big = small/2, small
+	}
+	imp.hh.Rules.SmallBlind, imp.hh.Rules.BigBlind = small, big
+
+	if timeString := timeRegexp.FindString(rest); timeString != "" {
+		if imp.hh.Source.Time, err = time.Parse("2006/1/2 15:04:05", timeString); err != nil {
+			return nil, err
+		}
+	}
+	return &imp, nil
+}
+
+// Read an amount of chips, or of money in cents
+func (imp *textImporter) parseAmount(s string) (int, error) {
+	match := amountRegexp.FindStringSubmatch(strings.Replace(strings.TrimLeft(s, "$€£"), ",", "", -1))
+	if match == nil || (match[2] != "" && !imp.cents) {
+		return 0, errors.New(fmt.Sprintf("Illegally formatted amount %q", s))
+	}
+	result, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0, err
+	}
+	if imp.cents {
+		cents, _ := strconv.Atoi((match[2] + "00")[:2])
+		result = 100*result + cents
+	}
+	return result, nil
+}
+
+func parseCards(s string) ([]poker.Card, error) {
+	var result []poker.Card
+	for _, field := range strings.Fields(s) {
+		card, err := poker.MakeCard(field)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, card)
+	}
+	return result, nil
+}
+
+// Whether the cards are the same, in the same order
+func sameCards(c1, c2 []poker.Card) bool {
+	if len(c1) != len(c2) {
+		return false
+	}
+	for i := range c1 {
+		if c1[i] != c2[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Read all the cards in square brackets, e.g. "[Ah Kd 2c] [5s]"
+func parseBracketedCards(s string) ([]poker.Card, error) {
+	var cards []string
+	for _, match := range cardsRegexp.FindAllStringSubmatch(s, -1) {
+		cards = append(cards, match[1])
+	}
+	return parseCards(strings.Join(cards, " "))
+}
+
+// The seat of the player whose name begins the line, followed by the given separator, and the rest of the line
+func (imp *textImporter) findPlayer(line, separator string) (int, string, bool) {
+	best, bestName := -1, ""
+	for name, seat := range imp.names {
+		if len(name) > len(bestName) && strings.HasPrefix(line, name+separator) {
+			best, bestName = seat, name
+		}
+	}
+	if best < 0 {
+		return 0, "", false
+	}
+	return best, line[len(bestName)+len(separator):], true
+}
+
+func (imp *textImporter) lookupPlayer(name string) (int, error) {
+	seat, ok := imp.names[name]
+	if !ok {
+		return 0, errors.New(fmt.Sprintf("Unknown player %q", name))
+	}
+	return seat, nil
+}
+
+func (imp *textImporter) readLine(line string) error {
+	if imp.section == "" && imp.stacks == nil {
+		if match := tableRegexp.FindStringSubmatch(line); match != nil {
+			imp.hh.Source.Table = match[1]
+			imp.button, _ = strconv.Atoi(match[2])
+			return nil
+		}
+		if match := seatRegexp.FindStringSubmatch(line); match != nil {
+			return imp.readSeat(match)
+		}
+	}
+	if imp.stacks == nil {
+		if err := imp.startPlay(); err != nil {
+			return err
+		}
+	}
+	if match := streetRegexp.FindStringSubmatch(line); match != nil {
+		return imp.readStreet(match[1], match[2])
+	}
+	if imp.section == "SUMMARY" {
+		return imp.readSummary(line)
+	}
+	if match := dealtRegexp.FindStringSubmatch(line); match != nil {
+		if match[2] == "" {
+			return nil
+		}
+		seat, err := imp.lookupPlayer(match[1])
+		if err != nil {
+			return err
+		}
+		imp.hh.Source.Hero = match[1]
+		return imp.setCards(seat, match[2])
+	}
+	if match := uncalledRegexp.FindStringSubmatch(line); match != nil {
+		seat, err := imp.lookupPlayer(match[2])
+		if err != nil {
+			return err
+		}
+		amount, err := imp.parseAmount(match[1])
+		if err != nil {
+			return err
+		}
+		if amount > imp.bets[seat] {
+			return errors.New(fmt.Sprintf("%v only bet %v, but %v was returned", match[2], imp.bets[seat], amount))
+		}
+		imp.bets[seat] -= amount
+		imp.stacks[seat] += amount
+		imp.hh.Actions = append(imp.hh.Actions, holdem.Action{Seat: seat, Street: imp.street, Type: holdem.ReturnUncalled, Amount: amount})
+		return nil
+	}
+	if match := collectedRegexp.FindStringSubmatch(line); match != nil {
+		seat, err := imp.lookupPlayer(match[1])
+		if err != nil {
+			return err
+		}
+		amount, err := imp.parseAmount(match[2])
+		if err != nil {
+			return err
+		}
+		imp.hh.Results[seat].Won += amount
+		return nil
+	}
+	if seat, action, ok := imp.findPlayer(line, ": "); ok {
+		return imp.readAction(seat, action)
+	}
+	for _, phrase := range ignoredPhrases {
+		if strings.Contains(line, phrase) {
+			return nil
+		}
+	}
+	return errors.New(fmt.Sprintf("Unrecognised line %q", line))
+}
+
+func (imp *textImporter) readSeat(match []string) error {
+	if strings.Contains(match[4], "sitting out") || strings.Contains(match[4], "out of hand") {
+		return nil
+	}
+	seatNum, _ := strconv.Atoi(match[1])
+	if _, ok := imp.seatNums[seatNum]; ok {
+		return errors.New(fmt.Sprintf("Seat %v is listed twice", seatNum))
+	}
+	if _, ok := imp.names[match[2]]; ok {
+		return errors.New(fmt.Sprintf("Player %q is listed twice", match[2]))
+	}
+	stack, err := imp.parseAmount(match[3])
+	if err != nil {
+		return err
+	}
+	imp.seatNums[seatNum] = len(imp.hh.Seats)
+	imp.names[match[2]] = len(imp.hh.Seats)
+	imp.hh.Seats = append(imp.hh.Seats, Seat{Name: match[2], Stack: stack})
+	return nil
+}
+
+// Once all the seats have been read, find the button and get ready to read the action
+func (imp *textImporter) startPlay() error {
+	players := len(imp.hh.Seats)
+	if players < 2 {
+		return errors.New(fmt.Sprintf("Expected at least two players, found %v", players))
+	}
+	seatNums := make([]int, 0, players)
+	for seatNum := range imp.seatNums {
+		seatNums = append(seatNums, seatNum)
+	}
+	sort.Ints(seatNums)
+	// If nobody sits at the button's seat (a "dead button"), it counts as the seat of the player before it
+	imp.hh.Button = players - 1
+	for i, seatNum := range seatNums {
+		if seatNum <= imp.button {
+			imp.hh.Button = i
+		}
+	}
+	imp.stacks = make([]int, players)
+	for seat, s := range imp.hh.Seats {
+		imp.stacks[seat] = s.Stack
+	}
+	imp.bets = make([]int, players)
+	imp.hh.Results = make([]Result, players)
+	for seat := range imp.hh.Results {
+		imp.hh.Results[seat].Seat = seat
+	}
+	return nil
+}
+
+func (imp *textImporter) readStreet(name, cards string) error {
+	if imp.section == "SUMMARY" {
+		return errors.New(fmt.Sprintf("Unexpected heading %q after the summary", name))
+	}
+	imp.section = name
+	switch name {
+	case "HOLE CARDS", "SUMMARY":
+		return nil
+	case "SHOW DOWN":
+		imp.street = holdem.Showdown
+		return nil
+	case "FLOP":
+		imp.street = holdem.Flop
+	case "TURN":
+		imp.street = holdem.Turn
+	case "RIVER":
+		imp.street = holdem.River
+	default:
+		return errors.New(fmt.Sprintf("Unsupported heading %q", name))
+	}
+	board, err := parseBracketedCards(cards)
+	if err != nil {
+		return err
+	}
+	if len(board) != imp.street.TableCards() || len(board) < len(imp.hh.Board) || !sameCards(board[:len(imp.hh.Board)], imp.hh.Board) {
+		return errors.New(fmt.Sprintf("Unexpected board %v on the %v after %v", formatCards(board), imp.street, formatCards(imp.hh.Board)))
+	}
+	imp.hh.Board = board
+	for seat := range imp.bets {
+		imp.bets[seat] = 0
+	}
+	return nil
+}
+
+// Check the known cards against the summary, which may also reveal the cards of players who mucked at the showdown
+func (imp *textImporter) readSummary(line string) error {
+	if strings.HasPrefix(line, "Board ") {
+		board, err := parseBracketedCards(line)
+		if err != nil {
+			return err
+		}
+		if !sameCards(board, imp.hh.Board) {
+			return errors.New(fmt.Sprintf("Summary board %v does not match %v", formatCards(board), formatCards(imp.hh.Board)))
+		}
+		return nil
+	}
+	if match := summaryRegexp.FindStringSubmatch(line); match != nil {
+		seatNum, _ := strconv.Atoi(match[1])
+		seat, ok := imp.seatNums[seatNum]
+		if !ok {
+			return errors.New(fmt.Sprintf("Nobody is sitting at seat %v", seatNum))
+		}
+		return imp.setCards(seat, match[2])
+	}
+	return nil
+}
+
+func (imp *textImporter) setCards(seat int, cardString string) error {
+	cards, err := parseCards(strings.Trim(cardString, "[]"))
+	if err != nil {
+		return err
+	}
+	if holeCards := imp.game.Layout().HoleCards; len(cards) != holeCards {
+		return errors.New(fmt.Sprintf("Expected %v cards for %v, found %v", holeCards, imp.hh.SeatName(seat), formatCards(cards)))
+	}
+	if known := imp.hh.Seats[seat].Cards; known != nil && !sameCards(known, cards) {
+		return errors.New(fmt.Sprintf("Found cards %v for %v, who was dealt %v", formatCards(cards), imp.hh.SeatName(seat), formatCards(known)))
+	}
+	imp.hh.Seats[seat].Cards = cards
+	return nil
+}
+
+// Put chips from a player's stack into the pot. Live chips count towards the player's bet on this street.
+func (imp *textImporter) put(seat int, t holdem.ActionType, amount, live int) error {
+	if amount > imp.stacks[seat] {
+		return errors.New(fmt.Sprintf("%v put in %v with only %v behind", imp.hh.SeatName(seat), amount, imp.stacks[seat]))
+	}
+	imp.stacks[seat] -= amount
+	imp.bets[seat] += live
+	imp.hh.Actions = append(imp.hh.Actions, holdem.Action{Seat: seat, Street: imp.street, Type: t, Amount: amount, AllIn: imp.stacks[seat] == 0})
+	return nil
+}
+
+func (imp *textImporter) readAction(seat int, action string) error {
+	action = strings.TrimSuffix(action, " and is all-in")
+	if action == "folds" || strings.HasPrefix(action, "folds [") {
+		if cards := cardsRegexp.FindString(action); cards != "" {
+			if err := imp.setCards(seat, cards); err != nil {
+				return err
+			}
+		}
+		imp.hh.Actions = append(imp.hh.Actions, holdem.Action{Seat: seat, Street: imp.street, Type: holdem.Fold})
+		return nil
+	}
+	if action == "checks" {
+		imp.hh.Actions = append(imp.hh.Actions, holdem.Action{Seat: seat, Street: imp.street, Type: holdem.Check})
+		return nil
+	}
+	if strings.HasPrefix(action, "shows [") {
+		return imp.setCards(seat, cardsRegexp.FindString(action))
+	}
+	if match := raiseRegexp.FindStringSubmatch(action); match != nil {
+		total, err := imp.parseAmount(match[2])
+		if err != nil {
+			return err
+		}
+		if total <= imp.bets[seat] {
+			return errors.New(fmt.Sprintf("%v raised to %v, having already bet %v", imp.hh.SeatName(seat), total, imp.bets[seat]))
+		}
+		return imp.put(seat, holdem.Raise, total-imp.bets[seat], total-imp.bets[seat])
+	}
+
+	for prefix, t := range amountActions {
+		if !strings.HasPrefix(action, prefix) {
+			continue
+		}
+		amount, err := imp.parseAmount(strings.TrimPrefix(action, prefix))
+		if err != nil {
+			return err
+		}
+		live := amount
+		switch prefix {
+		case "posts the ante ":
+			live = 0
+			if amount > imp.hh.Rules.Ante {
+				imp.hh.Rules.Ante = amount
+			}
+		case "posts small & big blinds ":
+			live = min(amount, imp.hh.Rules.BigBlind) // The small blind is dead money
+		}
+		return imp.put(seat, t, amount, live)
+	}
+	for _, phrase := range ignoredPhrases {
+		if strings.Contains(action, phrase) {
+			return nil
+		}
+	}
+	return errors.New(fmt.Sprintf("Unrecognised action %q by %v", action, imp.hh.SeatName(seat)))
+}
+
+// Check the hand is complete, and re-evaluate the hands which were shown down
+func (imp *textImporter) finish() (*HandHistory, error) {
+	hh := imp.hh
+	if imp.section != "SUMMARY" {
+		return nil, errors.New("The hand ends before its summary")
+	}
+	if _, err := hh.pack(); err != nil {
+		return nil, err
+	}
+	// Antes are listed in seat order, but the betting engine posts them starting from the player after the button
+	players, antes := len(hh.Seats), 0
+	for antes < len(hh.Actions) && hh.Actions[antes].Type == holdem.PostAnte {
+		antes++
+	}
+	sort.SliceStable(hh.Actions[:antes], func(i, j int) bool {
+		return (hh.Actions[i].Seat+players-hh.Button-1)%players < (hh.Actions[j].Seat+players-hh.Button-1)%players
+	})
+
+	total := 0
+	for _, r := range hh.Results {
+		total += r.Won
+	}
+	if total == 0 {
+		return nil, errors.New("Nobody collected the pot")
+	}
+	for seat := range hh.Results {
+		hh.Results[seat].PotFractionWon = float64(hh.Results[seat].Won) / float64(total)
+	}
+
+	if len(hh.Board) == imp.game.Layout().TableCards {
+		var shown []int
+		var shownCards [][]poker.Card
+		for seat, folded := range hh.Folded() {
+			if !folded && len(hh.Seats[seat].Cards) > 0 {
+				shown = append(shown, seat)
+				shownCards = append(shownCards, hh.Seats[seat].Cards)
+			}
+		}
+		if len(shown) > 1 {
+			for i, o := range imp.game.Outcomes(hh.Board, shownCards) {
+				hh.Results[shown[i]].Hand = o.Level.PrettyPrint()
+				hh.Results[shown[i]].Cards = o.Cards
+			}
+		}
+	}
+	return hh, nil
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
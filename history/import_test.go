@@ -0,0 +1,278 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package history
+
+import (
+	"github.com/amdw/gopoker/holdem"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const cashHand = `PokerStars Hand #200000000001:  Hold'em No Limit ($0.01/$0.02 USD) - 2020/03/14 12:00:00 ET
+Table 'Alpha II' 6-max Seat #1 is the button
+Seat 1: Alice ($2.00 in chips)
+Seat 3: Bob ($1.50 in chips)
+Seat 4: Carol ($3 in chips)
+Seat 6: Dave ($2.00 in chips) is sitting out
+Bob: posts small blind $0.01
+Carol: posts big blind $0.02
+*** HOLE CARDS ***
+Dealt to Alice [Ah Kd]
+Alice: raises $0.04 to $0.06
+Bob: folds
+Dave said, "gl"
+Carol: calls $0.04
+*** FLOP *** [As 7c 2d]
+Carol: checks
+Alice: bets $0.10
+Carol: calls $0.10
+*** TURN *** [As 7c 2d] [9h]
+Carol: checks
+Alice: checks
+*** RIVER *** [As 7c 2d 9h] [Kc]
+Carol: bets $0.20
+Alice: raises $0.40 to $0.60
+Carol: calls $0.40
+*** SHOW DOWN ***
+Alice: shows [Ah Kd] (two pair, Aces and Kings)
+Carol: shows [7d 7s] (three of a kind, Sevens)
+Carol collected $1.48 from pot
+*** SUMMARY ***
+Total pot $1.53 | Rake $0.05
+Board [As 7c 2d 9h Kc]
+Seat 1: Alice (button) showed [Ah Kd] and lost with two pair, Aces and Kings
+Seat 3: Bob (small blind) folded before Flop
+Seat 4: Carol (big blind) showed [7d 7s] and won ($1.48) with three of a kind, Sevens
+`
+
+const tournamentHand = `PokerStars Hand #200000000002: Tournament #3000000001, $1.00+$0.10 USD Hold'em No Limit - Level V (50/100) - 2020/03/14 13:05:00 ET
+Table '3000000001 1' 9-max Seat #2 is the button
+Seat 1: Alice (1000 in chips)
+Seat 2: Bob (3000 in chips)
+Seat 5: Carol (5000 in chips, $0.50 bounty)
+Alice: posts the ante 10
+Bob: posts the ante 10
+Carol: posts the ante 10
+Carol: posts small blind 50
+Alice: posts big blind 100
+*** HOLE CARDS ***
+Dealt to Bob [Qs Qh]
+Bob: raises 200 to 300
+Carol: raises 4690 to 4990 and is all-in
+Alice: calls 890 and is all-in
+Bob: calls 2690 and is all-in
+Uncalled bet (2000) returned to Carol
+*** FLOP *** [2c 5d 9s]
+*** TURN *** [2c 5d 9s] [Jh]
+*** RIVER *** [2c 5d 9s Jh] [3c]
+*** SHOW DOWN ***
+Carol: shows [Ac Kc] (high card Ace)
+Bob: shows [Qs Qh] (a pair of Queens)
+Alice: shows [8h 8d] (a pair of Eights)
+Bob collected 4000 from side pot
+Bob collected 3000 from main pot
+Alice finished the tournament in 3rd place
+*** SUMMARY ***
+Total pot 7000 Main pot 3000. Side pot 4000. | Rake 0
+Board [2c 5d 9s Jh 3c]
+Seat 1: Alice (big blind) showed [8h 8d] and lost with a pair of Eights
+Seat 2: Bob (button) showed [Qs Qh] and won (7000) with a pair of Queens
+Seat 5: Carol (small blind) showed [Ac Kc] and won (2000) with high card Ace
+`
+
+const hiLoHand = `PokerStars Zoom Hand #200000000003:  Omaha Hi/Lo Pot Limit ($0.05/$0.10) - 2020/03/14 14:00:00 ET
+Table 'Beta' 6-max Seat #3 is the button
+Seat 2: Alice ($10 in chips)
+Seat 3: Bob ($10.25 in chips)
+Bob: posts small blind $0.05
+Alice: posts big blind $0.10
+*** HOLE CARDS ***
+Dealt to Alice [Ah 2h Kc Qd]
+Bob: calls $0.05
+Alice: checks
+*** FLOP *** [3s 4d Kd]
+Alice: bets $0.20
+Bob: calls $0.20
+*** TURN *** [3s 4d Kd] [9c]
+Alice: checks
+Bob: checks
+*** RIVER *** [3s 4d Kd 9c] [6h]
+Alice: checks
+Bob: checks
+*** SHOW DOWN ***
+Alice: shows [Ah 2h Kc Qd] (HI: a pair of Kings; LO: 6,4,3,2,A)
+Bob: shows [Ks 9s 8c 7c] (HI: two pair, Kings and Nines; LO: 8,7,6,4,3)
+Bob collected $0.29 from pot
+Alice collected $0.29 from pot
+*** SUMMARY ***
+Total pot $0.60 | Rake $0.02
+Board [3s 4d Kd 9c 6h]
+Seat 2: Alice (big blind) showed [Ah 2h Kc Qd] and won ($0.29) with HI: a pair of Kings; LO: 6,4,3,2,A
+Seat 3: Bob (button) (small blind) showed [Ks 9s 8c 7c] and won ($0.29) with HI: two pair, Kings and Nines; LO: 8,7,6,4,3
+`
+
+func importHands(t *testing.T, text string) []*HandHistory {
+	hands, problems, err := ImportText(strings.NewReader(text))
+	if err != nil || len(problems) > 0 {
+		t.Fatalf("Unexpected problems importing: %v, %v", problems, err)
+	}
+	return hands
+}
+
+func TestImportCashHand(t *testing.T) {
+	hands := importHands(t, cashHand)
+	if len(hands) != 1 {
+		t.Fatalf("Expected one hand, found %v", len(hands))
+	}
+	hh := hands[0]
+	expectedSource := Source{Site: "PokerStars", HandID: "200000000001", Table: "Alpha II", Time: time.Date(2020, 3, 14, 12, 0, 0, 0, time.UTC), Currency: "USD", Hero: "Alice"}
+	if !reflect.DeepEqual(expectedSource, *hh.Source) {
+		t.Errorf("Expected source %+v, found %+v", expectedSource, *hh.Source)
+	}
+	if hh.Game != "holdem" || *hh.Rules != (holdem.BettingRules{Limit: holdem.NoLimit, SmallBlind: 1, BigBlind: 2}) || hh.Button != 0 {
+		t.Errorf("Unexpected game %v, rules %+v and button %v", hh.Game, *hh.Rules, hh.Button)
+	}
+	expectedSeats := []Seat{{"Alice", 200, h("AH", "KD")}, {"Bob", 150, nil}, {"Carol", 300, h("7D", "7S")}}
+	if !reflect.DeepEqual(expectedSeats, hh.Seats) {
+		t.Errorf("Expected seats %+v, found %+v", expectedSeats, hh.Seats)
+	}
+	if !sameCards(h("AS", "7C", "2D", "9H", "KC"), hh.Board) {
+		t.Errorf("Unexpected board %v", hh.Board)
+	}
+	expectedActions := []holdem.Action{{Seat: 0, Street: holdem.Preflop, Type: holdem.Raise, Amount: 6}, {Seat: 1, Street: holdem.Preflop, Type: holdem.Fold}, {Seat: 2, Street: holdem.Preflop, Type: holdem.Call, Amount: 4}}
+	if !reflect.DeepEqual(expectedActions, hh.Actions[2:5]) {
+		t.Errorf("Expected actions %+v, found %+v", expectedActions, hh.Actions[2:5])
+	}
+	if last := hh.Actions[len(hh.Actions)-1]; last != (holdem.Action{Seat: 2, Street: holdem.River, Type: holdem.Call, Amount: 40}) {
+		t.Errorf("Unexpected last action %+v", last)
+	}
+	if !reflect.DeepEqual([]int{76, 1, 76}, hh.Contributions()) {
+		t.Errorf("Unexpected contributions %v", hh.Contributions())
+	}
+	if hh.Results[2].Won != 148 || hh.Results[2].PotFractionWon != 1 || hh.Results[2].Hand != "Three 7s (plus A, K)" || hh.Results[0].Hand != "Two pair: As and Ks (plus 9)" {
+		t.Errorf("Unexpected results %+v", hh.Results)
+	}
+	settlement, err := hh.Showdown()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual([]int{0, 0, 153}, settlement.Winnings) {
+		t.Errorf("Expected Carol to win the whole pot before rake, found %v", settlement.Winnings)
+	}
+	if _, ok := hh.AllIn(); ok {
+		t.Errorf("Did not expect anyone to be all in")
+	}
+}
+
+func TestImportTournamentHand(t *testing.T) {
+	hh := importHands(t, tournamentHand)[0]
+	if hh.Source.Tournament != "3000000001" || hh.Source.Currency != "" || hh.Source.Hero != "Bob" {
+		t.Errorf("Unexpected source %+v", *hh.Source)
+	}
+	if *hh.Rules != (holdem.BettingRules{Limit: holdem.NoLimit, SmallBlind: 50, BigBlind: 100, Ante: 10}) || hh.Button != 1 {
+		t.Errorf("Unexpected rules %+v and button %v", *hh.Rules, hh.Button)
+	}
+	// With no rake, the hand replays exactly in the betting engine
+	if err := Verify(hh); err != nil {
+		t.Errorf("Unexpected error verifying: %v", err)
+	}
+	allIn, ok := hh.AllIn()
+	if !ok || allIn.Street != holdem.Preflop || len(allIn.Board) != 0 || !reflect.DeepEqual([]int{0, 1, 2}, allIn.Seats) {
+		t.Errorf("Expected all three players all in before the flop, found %+v", allIn)
+	}
+	if won := []int{hh.Results[0].Won, hh.Results[1].Won, hh.Results[2].Won}; !reflect.DeepEqual([]int{0, 7000, 0}, won) {
+		t.Errorf("Unexpected winnings %v", won)
+	}
+}
+
+func TestImportHiLoHand(t *testing.T) {
+	hh := importHands(t, hiLoHand)[0]
+	if hh.Game != "omaha8" || hh.Rules.Limit != holdem.PotLimit || hh.Source.Currency != "$" || hh.Seats[0].Stack != 1000 || hh.Seats[1].Stack != 1025 {
+		t.Errorf("Unexpected hand %+v", hh)
+	}
+	if hh.Results[0].PotFractionWon != 0.5 || hh.Results[1].Hand != "Two pair: Ks and 9s (plus 6)" {
+		t.Errorf("Unexpected results %+v", hh.Results)
+	}
+	settlement, err := hh.Showdown()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual([]int{30, 30}, settlement.Winnings) {
+		t.Errorf("Expected the pot to be split between high and low, found %v", settlement.Winnings)
+	}
+}
+
+func lineOf(text, line string) int {
+	for i, l := range strings.Split(text, "\n") {
+		if l == line {
+			return i + 1
+		}
+	}
+	panic("Line not found: " + line)
+}
+
+func TestImportProblems(t *testing.T) {
+	badCard := strings.Replace(tournamentHand, "[Qs Qh]", "[Qs Xx]", 1)
+	badAction := strings.Replace(cashHand, "Alice: checks", "Alice: dances", 1)
+	truncated := hiLoHand[:strings.Index(hiLoHand, "*** SUMMARY ***")]
+	badGame := strings.Replace(cashHand, "Hold'em No Limit", "Razz Limit", 1)
+	badStakes := strings.Replace(cashHand, "($0.01/$0.02 USD)", "($/$)", 1)
+	badStreet := strings.Replace(cashHand, "*** SHOW DOWN ***", "*** TURN *** [As 7c 2d] [9h]\n*** SHOW DOWN ***", 1)
+	text := "Some junk\nMore junk\n\n" + badCard + "\n\n" + cashHand + "\n\n" + badAction + "\n\n" + tournamentHand + "\n" + badGame + "\n" +
+		badStakes + "\n" + badStreet + "\n" + truncated
+
+	hands, problems, err := ImportText(strings.NewReader(text))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(hands) != 2 || hands[0].Source.HandID != "200000000001" || hands[1].Source.HandID != "200000000002" {
+		t.Errorf("Expected two good hands, found %v", len(hands))
+	}
+	offset := func(part string) int {
+		return strings.Count(text[:strings.Index(text, part)], "\n")
+	}
+	expected := []struct {
+		line   int
+		handID string
+		msg    string
+	}{
+		{1, "", `Expected the start of a hand, found "Some junk"`},
+		{offset(badCard) + lineOf(badCard, "Dealt to Bob [Qs Xx]"), "200000000002", `Illegally formatted card "Xx"`},
+		{offset(badAction) + lineOf(badAction, "Alice: dances"), "200000000001", `Unrecognised action "dances" by Alice`},
+		{offset(badGame) + 1, "200000000001", "Unsupported game"},
+		{offset(badStakes) + 1, "200000000001", `Illegally formatted amount "$"`},
+		{offset(badStreet) + lineOf(badStreet, "*** SHOW DOWN ***") - 1, "200000000001", "on the Turn after [AS 7C 2D 9H KC]"},
+		{strings.Count(text, "\n"), "200000000003", "The hand ends before its summary"},
+	}
+	if len(problems) != len(expected) {
+		t.Fatalf("Expected %v problems, found %v: %v", len(expected), len(problems), problems)
+	}
+	for i, e := range expected {
+		p := problems[i]
+		if p.Line != e.line || p.HandID != e.handID || !strings.Contains(p.Err.Error(), e.msg) {
+			t.Errorf("Expected problem %q at line %v in hand %q, found %v", e.msg, e.line, e.handID, p)
+		}
+	}
+	if msg := problems[2].Error(); !strings.HasPrefix(msg, "Line ") || !strings.Contains(msg, "(hand #200000000001)") {
+		t.Errorf("Unexpected message %q", msg)
+	}
+}
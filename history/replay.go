@@ -0,0 +1,255 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package history
+
+import (
+	"errors"
+	"fmt"
+	"github.com/amdw/gopoker/holdem"
+	"github.com/amdw/gopoker/poker"
+	"math"
+	"math/rand"
+	"reflect"
+)
+
+// A pack which deals the recorded cards, with any cards which were never seen filled in at random
+func (hh *HandHistory) pack() (*poker.Pack, error) {
+	g, ok := poker.LookupGame(hh.Game)
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("Unknown game %q", hh.Game))
+	}
+	fixing, err := hh.fixedCards(g, hh.Board)
+	if err != nil {
+		return nil, err
+	}
+	pack := g.NewPack()
+	pack.ShuffleFixed(rand.New(rand.NewSource(0)), &fixing)
+	return &pack, nil
+}
+
+// Where the given table cards and the recorded hole cards are dealt from in a pack for the game, checking that they
+// could all have been dealt
+func (hh *HandHistory) fixedCards(g poker.Game, board []poker.Card) (poker.Fixing, error) {
+	pack := g.NewPack()
+	layout := g.Layout()
+	result := poker.Fixing{}
+	if len(hh.Seats) < 1 || len(hh.Seats) > layout.MaxPlayers(&pack) {
+		return result, errors.New(fmt.Sprintf("Between 1 and %v seats can be dealt in %v, found %v", layout.MaxPlayers(&pack), g.Name(), len(hh.Seats)))
+	}
+	if len(board) > layout.TableCards {
+		return result, errors.New(fmt.Sprintf("Maximum of %v table cards allowed, found %v", layout.TableCards, len(board)))
+	}
+	result.Place(0, board...)
+	for seat, s := range hh.Seats {
+		if len(s.Cards) > layout.HoleCards {
+			return result, errors.New(fmt.Sprintf("Maximum of %v cards allowed for %v, found %v", layout.HoleCards, hh.SeatName(seat), len(s.Cards)))
+		}
+		result.Place(layout.HoleCardsStart(seat), s.Cards...)
+	}
+	inPlay := poker.NewCardSet(pack.Cards[:pack.Size()]...)
+	seen := poker.CardSet(0)
+	for _, c := range result.Cards {
+		if !inPlay.Contains(c) {
+			return result, errors.New(fmt.Sprintf("Card %v is not in the pack for %v", c, g.Name()))
+		}
+		if seen.Contains(c) {
+			return result, errors.New(fmt.Sprintf("Found duplicate card %v", c))
+		}
+		seen = seen.Add(c)
+	}
+	return result, nil
+}
+
+// Steps through a recorded hand of Hold'em with betting, rebuilding the state of the hand as each action is replayed
+type Replayer struct {
+	History *HandHistory
+	Hand    *holdem.Hand // The state of the hand after the actions replayed so far
+	actions []holdem.Action
+	pack    *poker.Pack
+	step    int
+}
+
+func NewReplayer(hh *HandHistory) (*Replayer, error) {
+	if hh.Rules == nil || hh.Game != "holdem" {
+		return nil, errors.New(fmt.Sprintf("Only hands of Hold'em with betting can be replayed, found %v", hh.Game))
+	}
+	pack, err := hh.pack()
+	if err != nil {
+		return nil, err
+	}
+	r := Replayer{History: hh, actions: hh.PlayerActions(), pack: pack}
+	if err := r.Seek(0); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// The number of actions chosen by players, which can be replayed one at a time
+func (r *Replayer) Steps() int {
+	return len(r.actions)
+}
+
+// The number of player actions replayed so far
+func (r *Replayer) Position() int {
+	return r.step
+}
+
+// Replay the next player action
+func (r *Replayer) Step() error {
+	if r.step >= len(r.actions) {
+		return errors.New("No more actions to replay")
+	}
+	a := r.actions[r.step]
+	if r.Hand.Done() {
+		return errors.New(fmt.Sprintf("Recorded action %v by %v after the hand was over", a.Type, r.History.SeatName(a.Seat)))
+	}
+	if a.Seat != r.Hand.NextToAct() || a.Street != r.Hand.Street {
+		return errors.New(fmt.Sprintf("Recorded action %v by %v on the %v, but %v is next to act on the %v", a.Type, r.History.SeatName(a.Seat), a.Street, r.History.SeatName(r.Hand.NextToAct()), r.Hand.Street))
+	}
+	amount := a.Amount
+	if a.Type == holdem.Bet || a.Type == holdem.Raise {
+		amount += r.Hand.Bets[a.Seat]
+	}
+	if err := r.Hand.Act(a.Type, amount); err != nil {
+		return errors.New(fmt.Sprintf("Could not replay action %v: %v", r.step+1, err))
+	}
+	r.step++
+	return nil
+}
+
+// Rebuild the state of the hand after the given number of player actions
+func (r *Replayer) Seek(step int) error {
+	if step < 0 || step > len(r.actions) {
+		return errors.New(fmt.Sprintf("Step must be between 0 and %v, found %v", len(r.actions), step))
+	}
+	if step < r.step || r.Hand == nil {
+		stacks := make([]int, len(r.History.Seats))
+		for i, s := range r.History.Seats {
+			stacks[i] = s.Stack
+		}
+		hand, err := holdem.NewHand(*r.History.Rules, stacks, r.History.Button, r.pack)
+		if err != nil {
+			return err
+		}
+		r.Hand, r.step = hand, 0
+	}
+	for r.step < step {
+		if err := r.Step(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Check a hand history against a replay of the hand. Hands with betting are replayed action by action, and must
+// produce exactly the recorded actions and winnings. In all hands, the hands shown down are re-evaluated (with
+// holdem.DealOutcomes for Hold'em) and must match the recorded results.
+func Verify(hh *HandHistory) error {
+	if len(hh.Results) != len(hh.Seats) {
+		return errors.New(fmt.Sprintf("Expected one result for each of %v seats, found %v", len(hh.Seats), len(hh.Results)))
+	}
+	if hh.Rules == nil {
+		return verifyDeal(hh)
+	}
+	r, err := NewReplayer(hh)
+	if err != nil {
+		return err
+	}
+	if err := r.Seek(r.Steps()); err != nil {
+		return err
+	}
+	h := r.Hand
+	if !h.Done() {
+		return errors.New(fmt.Sprintf("The hand is not over after the recorded actions: %v is next to act", hh.SeatName(h.NextToAct())))
+	}
+	if !reflect.DeepEqual(hh.Actions, h.Actions) {
+		for i := range h.Actions {
+			if i >= len(hh.Actions) || hh.Actions[i] != h.Actions[i] {
+				return errors.New(fmt.Sprintf("Replay differs from the record at action %v: found %+v", i+1, h.Actions[i]))
+			}
+		}
+		return errors.New(fmt.Sprintf("Replay has %v actions, but %v were recorded", len(h.Actions), len(hh.Actions)))
+	}
+	for seat, won := range h.Winnings {
+		if hh.Results[seat].Won != won {
+			return errors.New(fmt.Sprintf("%v won %v, but %v was recorded", hh.SeatName(seat), won, hh.Results[seat].Won))
+		}
+	}
+
+	var showdownSeats []int
+	var playerCards [][]poker.Card
+	for seat, folded := range h.Folded {
+		if !folded && h.Outcomes != nil {
+			if len(hh.Seats[seat].Cards) != 2 {
+				return errors.New(fmt.Sprintf("%v reached the showdown, but their cards were not recorded", hh.SeatName(seat)))
+			}
+			showdownSeats = append(showdownSeats, seat)
+			playerCards = append(playerCards, hh.Seats[seat].Cards)
+		}
+	}
+	if len(showdownSeats) > 0 {
+		for i, o := range holdem.DealOutcomes(hh.Board, playerCards) {
+			if err := checkShownHand(hh, showdownSeats[i], o.Level); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func checkShownHand(hh *HandHistory, seat int, level poker.HandLevel) error {
+	if recorded := hh.Results[seat].Hand; recorded != level.PrettyPrint() {
+		return errors.New(fmt.Sprintf("%v showed %v, but %v was recorded", hh.SeatName(seat), level.PrettyPrint(), recorded))
+	}
+	return nil
+}
+
+// Check the results of a hand dealt without betting against the outcomes of its game
+func verifyDeal(hh *HandHistory) error {
+	g, ok := poker.LookupGame(hh.Game)
+	if !ok {
+		return errors.New(fmt.Sprintf("Unknown game %q", hh.Game))
+	}
+	if _, err := hh.pack(); err != nil {
+		return err
+	}
+	layout := g.Layout()
+	if len(hh.Board) != layout.TableCards {
+		return errors.New(fmt.Sprintf("Expected %v table cards, found %v", layout.TableCards, len(hh.Board)))
+	}
+	for seat, s := range hh.Seats {
+		if len(s.Cards) != layout.HoleCards {
+			return errors.New(fmt.Sprintf("Expected %v cards for %v, found %v", layout.HoleCards, hh.SeatName(seat), len(s.Cards)))
+		}
+	}
+	playerCards := make([][]poker.Card, len(hh.Seats))
+	for i, s := range hh.Seats {
+		playerCards[i] = s.Cards
+	}
+	for i, o := range g.Outcomes(hh.Board, playerCards) {
+		if err := checkShownHand(hh, i, o.Level); err != nil {
+			return err
+		}
+		if recorded := hh.Results[i].PotFractionWon; math.Abs(recorded-o.PotFractionWon) > 1e-9 {
+			return errors.New(fmt.Sprintf("%v won %v of the pot, but %v was recorded", hh.SeatName(i), o.PotFractionWon, recorded))
+		}
+	}
+	return nil
+}
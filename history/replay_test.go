@@ -0,0 +1,82 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package history
+
+import (
+	"github.com/amdw/gopoker/holdem"
+	"reflect"
+	"testing"
+)
+
+func TestReplayer(t *testing.T) {
+	hand := playTestHand(t)
+	hh := RecordHand(hand)
+	r, err := NewReplayer(hh)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if r.Steps() != len(hh.PlayerActions()) || r.Position() != 0 {
+		t.Errorf("Expected %v steps from the start, found %v from %v", len(hh.PlayerActions()), r.Steps(), r.Position())
+	}
+	if !reflect.DeepEqual([]int{99, 48, 197}, r.Hand.Stacks) || r.Hand.NextToAct() != 0 {
+		t.Errorf("Expected the state after the forced bets, found stacks %v with %v to act", r.Hand.Stacks, r.Hand.NextToAct())
+	}
+	if err := r.Step(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if r.Hand.Bets[0] != 10 || r.Hand.NextToAct() != 1 {
+		t.Errorf("Expected the raise to 10, found bets %v", r.Hand.Bets)
+	}
+	if err := r.Seek(r.Steps()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !r.Hand.Done() || !reflect.DeepEqual(hand.Stacks, r.Hand.Stacks) || !reflect.DeepEqual(hand.Actions, r.Hand.Actions) {
+		t.Errorf("Expected the replay to end like the original, found stacks %v", r.Hand.Stacks)
+	}
+	if err := r.Step(); err == nil {
+		t.Errorf("Expected error stepping past the end")
+	}
+	if err := r.Seek(3); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if r.Position() != 3 || r.Hand.Street != holdem.Flop || r.Hand.Pot() != 33 {
+		t.Errorf("Expected to be on the flop with 33 in the pot, found %v with %v", r.Hand.Street, r.Hand.Pot())
+	}
+	if err := r.Seek(-1); err == nil {
+		t.Errorf("Expected error seeking before the start")
+	}
+}
+
+func TestReplayUnknownCards(t *testing.T) {
+	// A hand where everyone folds to the big blind can be verified without knowing anyone's cards
+	hh := RecordHand(playTestHand(t))
+	hh.Actions = hh.Actions[:5]
+	hh.Actions = append(hh.Actions, holdem.Action{Seat: 0, Street: holdem.Preflop, Type: holdem.Fold}, holdem.Action{Seat: 1, Street: holdem.Preflop, Type: holdem.Fold},
+		holdem.Action{Seat: 2, Street: holdem.Preflop, Type: holdem.ReturnUncalled, Amount: 1})
+	hh.Board = nil
+	for i := range hh.Seats {
+		hh.Seats[i].Cards = nil
+		hh.Results[i] = Result{Seat: i}
+	}
+	hh.Results[2].Won = 5
+	if err := Verify(hh); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package history
+
+import (
+	"errors"
+	"fmt"
+	"github.com/amdw/gopoker/holdem"
+	"github.com/amdw/gopoker/poker"
+)
+
+// Chips each player put into the pot over the hand, after any uncalled bets were returned
+func (hh *HandHistory) Contributions() []int {
+	result := make([]int, len(hh.Seats))
+	for _, a := range hh.Actions {
+		if a.Type == holdem.ReturnUncalled {
+			result[a.Seat] -= a.Amount
+		} else {
+			result[a.Seat] += a.Amount
+		}
+	}
+	return result
+}
+
+// Which players folded during the hand
+func (hh *HandHistory) Folded() []bool {
+	result := make([]bool, len(hh.Seats))
+	for _, a := range hh.Actions {
+		if a.Type == holdem.Fold {
+			result[a.Seat] = true
+		}
+	}
+	return result
+}
+
+func (hh *HandHistory) playerCards() [][]poker.Card {
+	result := make([][]poker.Card, len(hh.Seats))
+	for seat, s := range hh.Seats {
+		result[seat] = s.Cards
+	}
+	return result
+}
+
+// Evaluate the hands of the players who did not fold on the given board, and divide the pot between them by the rules
+// of the game. The outcomes are indexed by seat, leaving those of players who folded empty.
+func showdown(g poker.Game, board []poker.Card, playerCards [][]poker.Card, contributions []int, folded []bool, button int) ([]poker.GameOutcome, poker.Settlement) {
+	outcomes := make([]poker.GameOutcome, len(playerCards))
+	var live []int
+	var liveCards [][]poker.Card
+	for seat, cards := range playerCards {
+		if !folded[seat] {
+			live = append(live, seat)
+			liveCards = append(liveCards, cards)
+		}
+	}
+	for i, o := range g.Outcomes(board, liveCards) {
+		o.Player = live[i] + 1
+		outcomes[live[i]] = o
+	}
+	stakes := make([]poker.Stake, len(playerCards))
+	for seat, cards := range playerCards {
+		o := outcomes[seat]
+		stakes[seat] = poker.Stake{Contribution: contributions[seat], Folded: folded[seat], High: o.Level, Low: o.Low, HasLow: o.HasLow, Cards: cards}
+	}
+	return outcomes, poker.Settle(g.SettlementRules(), stakes, button)
+}
+
+// Check that every player who did not fold had their cards recorded, so that their hands can be evaluated
+func (hh *HandHistory) checkLiveCards(g poker.Game, folded []bool) error {
+	live := 0
+	for seat, s := range hh.Seats {
+		if folded[seat] {
+			continue
+		}
+		live++
+		if len(s.Cards) != g.Layout().HoleCards {
+			return errors.New(fmt.Sprintf("Expected %v cards for %v, found %v", g.Layout().HoleCards, hh.SeatName(seat), len(s.Cards)))
+		}
+	}
+	if live < 2 {
+		return errors.New(fmt.Sprintf("Expected at least two players at the showdown, found %v", live))
+	}
+	return nil
+}
+
+// Re-run the showdown of the hand: evaluate the hands of the players who did not fold on the recorded board, and divide
+// the pot between them by the rules of the game, ignoring any rake
+func (hh *HandHistory) Showdown() (poker.Settlement, error) {
+	g, ok := poker.LookupGame(hh.Game)
+	if !ok {
+		return poker.Settlement{}, errors.New(fmt.Sprintf("Unknown game %q", hh.Game))
+	}
+	if len(hh.Board) != g.Layout().TableCards {
+		return poker.Settlement{}, errors.New(fmt.Sprintf("Expected %v table cards, found %v", g.Layout().TableCards, len(hh.Board)))
+	}
+	folded := hh.Folded()
+	if err := hh.checkLiveCards(g, folded); err != nil {
+		return poker.Settlement{}, err
+	}
+	_, settlement := showdown(g, hh.Board, hh.playerCards(), hh.Contributions(), folded, hh.Button)
+	return settlement, nil
+}
+
+// The point in a hand which went to a showdown after one or more of the players still in were all in. No more chips
+// could go into a pot once everyone contesting it was all in or had matched the all-in, so from then on the pot was
+// decided by the cards dealt.
+type AllInPoint struct {
+	Street    holdem.Street         // The street on which the betting ended
+	Board     []poker.Card          // The table cards dealt by the end of the betting
+	Seats     []int                 // The players still in
+	WentAllIn map[int]holdem.Street // The street on which each of them who was all in went all in, by seat
+}
+
+// Find the point at which players still in were all in, if the hand went to a showdown that way and the cards of
+// everyone still in were recorded
+func (hh *HandHistory) AllIn() (AllInPoint, bool) {
+	g, ok := poker.LookupGame(hh.Game)
+	if !ok || hh.Rules == nil {
+		return AllInPoint{}, false
+	}
+	folded := make([]bool, len(hh.Seats))
+	wentAllIn := make(map[int]holdem.Street)
+	street := holdem.Preflop
+	for _, a := range hh.Actions {
+		switch a.Type {
+		case holdem.Fold:
+			folded[a.Seat] = true
+		case holdem.ReturnUncalled:
+			delete(wentAllIn, a.Seat)
+		default:
+			if _, ok := wentAllIn[a.Seat]; a.AllIn && !ok {
+				wentAllIn[a.Seat] = a.Street
+			}
+		}
+		if !a.Type.Automatic() {
+			street = a.Street
+		}
+	}
+	result := AllInPoint{Street: street, WentAllIn: make(map[int]holdem.Street)}
+	for seat := range hh.Seats {
+		if folded[seat] {
+			continue
+		}
+		result.Seats = append(result.Seats, seat)
+		if allInStreet, ok := wentAllIn[seat]; ok {
+			result.WentAllIn[seat] = allInStreet
+		}
+	}
+	if len(result.Seats) < 2 || len(result.WentAllIn) == 0 || street.TableCards() > len(hh.Board) || hh.checkLiveCards(g, folded) != nil {
+		return AllInPoint{}, false
+	}
+	result.Board = hh.Board[:street.TableCards()]
+	return result, true
+}
+
+// The street from which the given pot was decided by the cards dealt: the street on which the last of the players all
+// in for exactly the pot's share of their chips went all in. Pots which nobody contesting them was all in for were
+// still being bet on when the betting ended.
+func (p AllInPoint) potStreet(pot poker.Pot, contributions []int) holdem.Street {
+	level := -1
+	for _, seat := range pot.Eligible {
+		if level < 0 || contributions[seat] < level {
+			level = contributions[seat]
+		}
+	}
+	result, found := holdem.Preflop, false
+	for _, seat := range pot.Eligible {
+		if street, ok := p.WentAllIn[seat]; ok && contributions[seat] == level && (!found || street > result) {
+			result, found = street, true
+		}
+	}
+	if !found {
+		return p.Street
+	}
+	return result
+}
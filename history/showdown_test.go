@@ -0,0 +1,139 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package history
+
+import (
+	"github.com/amdw/gopoker/holdem"
+	"github.com/amdw/gopoker/poker"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestShowdown(t *testing.T) {
+	hand := playTestHand(t)
+	hh := RecordHand(hand)
+	if !reflect.DeepEqual(hand.Contributions, hh.Contributions()) {
+		t.Errorf("Expected contributions %v, found %v", hand.Contributions, hh.Contributions())
+	}
+	settlement, err := hh.Showdown()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(hand.Winnings, settlement.Winnings) {
+		t.Errorf("Expected winnings %v, found %v", hand.Winnings, settlement.Winnings)
+	}
+	if _, ok := hh.AllIn(); ok {
+		t.Errorf("Did not expect anyone to be all in")
+	}
+
+	hh.Board = hh.Board[:4]
+	if _, err := hh.Showdown(); err == nil {
+		t.Errorf("Expected error without the river")
+	}
+	hh = RecordHand(hand)
+	hh.Seats[1].Cards = nil
+	if _, err := hh.Showdown(); err == nil {
+		t.Errorf("Expected error without a player's cards")
+	}
+	if _, ok := hh.AllIn(); ok {
+		t.Errorf("Did not expect an all-in point without a player's cards")
+	}
+}
+
+func TestAllIn(t *testing.T) {
+	pack := poker.NewPack()
+	pack.Shuffle(rand.New(rand.NewSource(1234)))
+	hand, err := holdem.NewHand(holdem.BettingRules{SmallBlind: 1, BigBlind: 2}, []int{20, 100, 100}, 0, &pack)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, action := range []holdem.ActionType{holdem.AllIn, holdem.Fold, holdem.Call} {
+		if err := hand.Act(action, 0); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	hh := RecordHand(hand)
+	allIn, ok := hh.AllIn()
+	if !ok || allIn.Street != holdem.Preflop || len(allIn.Board) != 0 || !reflect.DeepEqual([]int{0, 2}, allIn.Seats) {
+		t.Errorf("Expected seats 0 and 2 all in before the flop, found %+v", allIn)
+	}
+	if !reflect.DeepEqual([]bool{false, true, false}, hh.Folded()) {
+		t.Errorf("Unexpected folds %v", hh.Folded())
+	}
+
+	// If the players with chips behind carry on betting, the main pot is still decided from the street of the all-in
+	pack.Shuffle(rand.New(rand.NewSource(1234)))
+	hand, err = holdem.NewHand(holdem.BettingRules{SmallBlind: 1, BigBlind: 2}, []int{20, 100, 100}, 0, &pack)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	playActions(t, hand, []testAction{{holdem.AllIn, 0}, {holdem.Call, 0}, {holdem.Call, 0}, {holdem.Bet, 10}, {holdem.Call, 0}})
+	if err := hand.Play(holdem.CheckOrCall); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	hh = RecordHand(hand)
+	allIn, ok = hh.AllIn()
+	if !ok || allIn.Street != holdem.River || !reflect.DeepEqual([]int{0, 1, 2}, allIn.Seats) || !reflect.DeepEqual(map[int]holdem.Street{0: holdem.Preflop}, allIn.WentAllIn) {
+		t.Errorf("Expected seat 0 all in before the flop and the betting to end on the river, found %+v", allIn)
+	}
+	checkPotStreets(t, hh, allIn, []holdem.Street{holdem.Preflop, holdem.River})
+
+	// A player who folds after calling an all-in leaves the main pot decided from the street of the all-in
+	pack.Shuffle(rand.New(rand.NewSource(1234)))
+	hand, err = holdem.NewHand(holdem.BettingRules{SmallBlind: 1, BigBlind: 2}, []int{20, 100, 100}, 0, &pack)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	playActions(t, hand, []testAction{{holdem.AllIn, 0}, {holdem.Call, 0}, {holdem.Call, 0}, {holdem.Bet, 10}, {holdem.Fold, 0}})
+	hh = RecordHand(hand)
+	allIn, ok = hh.AllIn()
+	if !ok || allIn.Street != holdem.Flop || !reflect.DeepEqual([]int{0, 1}, allIn.Seats) || !reflect.DeepEqual(map[int]holdem.Street{0: holdem.Preflop}, allIn.WentAllIn) {
+		t.Errorf("Expected seat 0 all in before the flop and the betting to end on the flop, found %+v", allIn)
+	}
+	checkPotStreets(t, hh, allIn, []holdem.Street{holdem.Preflop})
+}
+
+type testAction struct {
+	action holdem.ActionType
+	amount int
+}
+
+func playActions(t *testing.T, hand *holdem.Hand, actions []testAction) {
+	for _, a := range actions {
+		if err := hand.Act(a.action, a.amount); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+}
+
+func checkPotStreets(t *testing.T, hh *HandHistory, allIn AllInPoint, expected []holdem.Street) {
+	settlement, err := hh.Showdown()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var streets []holdem.Street
+	for _, pot := range settlement.Pots {
+		streets = append(streets, allIn.potStreet(pot, hh.Contributions()))
+	}
+	if !reflect.DeepEqual(expected, streets) {
+		t.Errorf("Expected pots decided from %v, found %v", expected, streets)
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package history
+
+import (
+	"fmt"
+	"github.com/amdw/gopoker/holdem"
+	"github.com/amdw/gopoker/poker"
+	"io"
+	"strings"
+)
+
+func formatCards(cards []poker.Card) string {
+	cardStrings := make([]string, len(cards))
+	for i, c := range cards {
+		cardStrings[i] = c.String()
+	}
+	return "[" + strings.Join(cardStrings, " ") + "]"
+}
+
+// Write the hand history in plain text, in the style of online poker sites
+func (hh *HandHistory) WriteText(w io.Writer) {
+	gameName := hh.Game
+	if g, ok := poker.LookupGame(hh.Game); ok {
+		gameName = g.Name()
+	}
+	if hh.Rules == nil {
+		fmt.Fprintln(w, gameName)
+	} else {
+		fmt.Fprintf(w, "%v %v (blinds %v/%v", gameName, hh.Rules.Limit, hh.Rules.SmallBlind, hh.Rules.BigBlind)
+		if hh.Rules.Ante > 0 {
+			fmt.Fprintf(w, ", ante %v", hh.Rules.Ante)
+		}
+		fmt.Fprintln(w, ")")
+		fmt.Fprintf(w, "%v is the button\n", hh.SeatName(hh.Button))
+	}
+	for seat, s := range hh.Seats {
+		fmt.Fprintf(w, "%v", hh.SeatName(seat))
+		if hh.Rules != nil {
+			fmt.Fprintf(w, " (%v in chips)", s.Stack)
+		}
+		if len(s.Cards) > 0 {
+			fmt.Fprintf(w, " %v", formatCards(s.Cards))
+		}
+		fmt.Fprintln(w)
+	}
+
+	street := holdem.Street(-1)
+	for _, a := range hh.Actions {
+		if a.Street != street {
+			street = a.Street
+			fmt.Fprintf(w, "*** %v ***", strings.ToUpper(street.String()))
+			if cards := street.TableCards(); cards > 0 && cards <= len(hh.Board) {
+				fmt.Fprintf(w, " %v", formatCards(hh.Board[:cards]))
+			}
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "%v: %v", hh.SeatName(a.Seat), a.Type)
+		if a.Amount > 0 {
+			fmt.Fprintf(w, " %v", a.Amount)
+		}
+		if a.AllIn {
+			fmt.Fprint(w, " and is all in")
+		}
+		fmt.Fprintln(w)
+	}
+
+	fmt.Fprintln(w, "*** SUMMARY ***")
+	fmt.Fprintf(w, "Board %v\n", formatCards(hh.Board))
+	for _, r := range hh.Results {
+		fmt.Fprintf(w, "%v:", hh.SeatName(r.Seat))
+		if hh.Rules != nil {
+			fmt.Fprintf(w, " won %v", r.Won)
+		}
+		fmt.Fprintf(w, " (%.1f%% of the pot)", 100*r.PotFractionWon)
+		if r.Hand != "" {
+			fmt.Fprintf(w, " with %v %v", r.Hand, formatCards(r.Cards))
+		}
+		fmt.Fprintln(w)
+	}
+}
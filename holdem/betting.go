@@ -0,0 +1,635 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package holdem
+
+import (
+	"errors"
+	"fmt"
+	"github.com/amdw/gopoker/poker"
+)
+
+// How much may be bet at a time
+type BettingLimit int8
+
+const (
+	NoLimit BettingLimit = iota
+	PotLimit
+	FixedLimit
+)
+
+func (l BettingLimit) String() string {
+	switch l {
+	case NoLimit:
+		return "No-Limit"
+	case PotLimit:
+		return "Pot-Limit"
+	case FixedLimit:
+		return "Fixed-Limit"
+	default:
+		return fmt.Sprintf("Unknown[%v]", int(l))
+	}
+}
+
+func (l BettingLimit) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+func (l *BettingLimit) UnmarshalText(text []byte) error {
+	for candidate := NoLimit; candidate <= FixedLimit; candidate++ {
+		if candidate.String() == string(text) {
+			*l = candidate
+			return nil
+		}
+	}
+	return errors.New(fmt.Sprintf("Unknown betting limit %q", text))
+}
+
+// The rounds of betting in a hand, and the showdown at the end
+type Street int8
+
+const (
+	Preflop Street = iota
+	Flop
+	Turn
+	River
+	Showdown
+)
+
+func (s Street) String() string {
+	switch s {
+	case Preflop:
+		return "Preflop"
+	case Flop:
+		return "Flop"
+	case Turn:
+		return "Turn"
+	case River:
+		return "River"
+	case Showdown:
+		return "Showdown"
+	default:
+		return fmt.Sprintf("Unknown[%v]", int(s))
+	}
+}
+
+func (s Street) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+func (s *Street) UnmarshalText(text []byte) error {
+	for candidate := Preflop; candidate <= Showdown; candidate++ {
+		if candidate.String() == string(text) {
+			*s = candidate
+			return nil
+		}
+	}
+	return errors.New(fmt.Sprintf("Unknown street %q", text))
+}
+
+// The number of table cards which are face up during this street
+func (s Street) TableCards() int {
+	switch s {
+	case Preflop:
+		return 0
+	case Flop:
+		return 3
+	case Turn:
+		return 4
+	default:
+		return 5
+	}
+}
+
+type ActionType int8
+
+const (
+	Fold ActionType = iota
+	Check
+	Call
+	Bet
+	Raise
+	AllIn // Put in every remaining chip. This is logged as the call, bet or raise it amounts to.
+
+	// Actions which happen automatically, and cannot be chosen by a player
+	PostAnte
+	PostSmallBlind
+	PostBigBlind
+	ReturnUncalled // The part of a bet which nobody called is given back at the end of the betting round
+)
+
+func (t ActionType) String() string {
+	switch t {
+	case Fold:
+		return "fold"
+	case Check:
+		return "check"
+	case Call:
+		return "call"
+	case Bet:
+		return "bet"
+	case Raise:
+		return "raise"
+	case AllIn:
+		return "all-in"
+	case PostAnte:
+		return "ante"
+	case PostSmallBlind:
+		return "small blind"
+	case PostBigBlind:
+		return "big blind"
+	case ReturnUncalled:
+		return "uncalled bet returned"
+	default:
+		return fmt.Sprintf("Unknown[%v]", int(t))
+	}
+}
+
+// Whether this action happens automatically, rather than being chosen by a player
+func (t ActionType) Automatic() bool {
+	return t >= PostAnte
+}
+
+func (t ActionType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+func (t *ActionType) UnmarshalText(text []byte) error {
+	for candidate := Fold; candidate <= ReturnUncalled; candidate++ {
+		if candidate.String() == string(text) {
+			*t = candidate
+			return nil
+		}
+	}
+	return errors.New(fmt.Sprintf("Unknown action %q", text))
+}
+
+// The stakes and betting structure of a game. In fixed-limit games, bets and raises are one big blind before the
+// turn and two big blinds after it.
+type BettingRules struct {
+	Limit      BettingLimit
+	SmallBlind int
+	BigBlind   int
+	Ante       int // Paid by every player before the blinds are posted
+}
+
+// The most bets (including the big blind) allowed on one street in a fixed-limit game
+const FixedLimitBetCap = 4
+
+func (r BettingRules) validate() error {
+	if r.BigBlind <= 0 {
+		return errors.New(fmt.Sprintf("Big blind must be positive, found %v", r.BigBlind))
+	}
+	if r.SmallBlind < 0 || r.SmallBlind > r.BigBlind {
+		return errors.New(fmt.Sprintf("Small blind must be between 0 and the big blind %v, found %v", r.BigBlind, r.SmallBlind))
+	}
+	if r.Ante < 0 {
+		return errors.New(fmt.Sprintf("Ante must not be negative, found %v", r.Ante))
+	}
+	return nil
+}
+
+// An entry in the log of a hand
+type Action struct {
+	Seat   int
+	Street Street
+	Type   ActionType
+	Amount int  // The chips put into the pot by this action (or for ReturnUncalled, taken back out)
+	AllIn  bool // Whether this left the player with no chips behind
+}
+
+// The state of a hand of Texas Hold'em with betting, from the forced bets to the division of the pot.
+// Seats are numbered from zero, in the order the action goes round the table.
+// The exported fields describe the hand so far, and should only be changed by calling Act.
+type Hand struct {
+	Rules          BettingRules
+	Button         int
+	Street         Street
+	StartingStacks []int // Chips each player had at the start of the hand
+	Stacks         []int // Chips each player has behind
+	Bets           []int // Chips each player has put in on the current street, not counting antes
+	Contributions  []int // Chips each player has put into the pot over the whole hand
+	Folded         []bool
+	TableCards     []poker.Card // All five table cards, of which only Board are face up
+	PlayerCards    [][]poker.Card
+	Actions        []Action
+	Outcomes       []PlayerOutcome // The hands shown down, if any, with Player the seat plus one and PotFractionWon the share of the whole pot
+	Pots           []poker.Pot     // The main pot and any side pots, once the hand is over
+	Winnings       []int           // Chips each player won from the pot, once the hand is over
+
+	toAct      int    // The seat whose turn it is, or -1 once the hand is over
+	currentBet int    // The bet to match to stay in on this street
+	lastRaise  int    // The size of the last full bet or raise on this street, which is the minimum raise
+	betCount   int    // Full bets and raises on this street, including the big blind
+	pending    []bool // Players who still have to act on this street
+	mayRaise   []bool // Players who may raise when they next act, as the betting has been reopened since they last did
+}
+
+// Start a hand, dealing from the pack (which should already be shuffled) and posting the antes and blinds.
+// The stacks are not modified.
+func NewHand(rules BettingRules, stacks []int, button int, pack *poker.Pack) (*Hand, error) {
+	if err := rules.validate(); err != nil {
+		return nil, err
+	}
+	players := len(stacks)
+	if maxPlayers := layout.MaxPlayers(pack); players < 2 || players > maxPlayers {
+		return nil, errors.New(fmt.Sprintf("Between 2 and %v players can play, found %v", maxPlayers, players))
+	}
+	if button < 0 || button >= players {
+		return nil, errors.New(fmt.Sprintf("Button must be at a seat between 0 and %v, found %v", players-1, button))
+	}
+	for seat, stack := range stacks {
+		if stack <= 0 {
+			return nil, errors.New(fmt.Sprintf("Seat %v has no chips", seat))
+		}
+	}
+
+	h := Hand{Rules: rules, Button: button, StartingStacks: append([]int{}, stacks...), Stacks: append([]int{}, stacks...), Bets: make([]int, players),
+		Contributions: make([]int, players), Folded: make([]bool, players), pending: make([]bool, players),
+		mayRaise: make([]bool, players)}
+	tableCards, playerCards := Deal(pack, players)
+	h.TableCards = append([]poker.Card{}, tableCards...)
+	h.PlayerCards = make([][]poker.Card, players)
+	for i, cards := range playerCards {
+		h.PlayerCards[i] = append([]poker.Card{}, cards...)
+	}
+
+	if rules.Ante > 0 {
+		for i := 1; i <= players; i++ {
+			h.put((button+i)%players, PostAnte, rules.Ante, false)
+		}
+	}
+	smallBlind := (button + 1) % players
+	if players == 2 {
+		smallBlind = button // Heads up, the button posts the small blind and acts first before the flop
+	}
+	bigBlind := (smallBlind + 1) % players
+	h.put(smallBlind, PostSmallBlind, rules.SmallBlind, true)
+	h.put(bigBlind, PostBigBlind, rules.BigBlind, true)
+	h.currentBet = rules.BigBlind
+	h.startBetting(bigBlind + 1)
+	return &h, nil
+}
+
+// Put up to the given number of chips from a player's stack into the pot, logging it as the given action.
+// Live chips count towards the player's bet on this street.
+func (h *Hand) put(seat int, t ActionType, chips int, live bool) {
+	if chips > h.Stacks[seat] {
+		chips = h.Stacks[seat]
+	}
+	if chips == 0 && (t == PostAnte || t == PostSmallBlind || t == PostBigBlind) {
+		return // Already all in
+	}
+	h.Stacks[seat] -= chips
+	h.Contributions[seat] += chips
+	if live {
+		h.Bets[seat] += chips
+	}
+	h.Actions = append(h.Actions, Action{seat, h.Street, t, chips, h.Stacks[seat] == 0})
+}
+
+// Open the betting on the current street, with the first player to act being the first one still in from the given seat
+func (h *Hand) startBetting(from int) {
+	h.lastRaise = h.Rules.BigBlind
+	if h.Rules.Limit == FixedLimit && h.Street >= Turn {
+		h.lastRaise = 2 * h.Rules.BigBlind
+	}
+	h.betCount = 0
+	if h.Street == Preflop {
+		h.betCount = 1
+	}
+	for seat := range h.pending {
+		h.pending[seat] = h.canAct(seat)
+		h.mayRaise[seat] = true
+	}
+	h.moveOn(from)
+}
+
+// Whether the player is still in the hand with chips to bet
+func (h *Hand) canAct(seat int) bool {
+	return !h.Folded[seat] && h.Stacks[seat] > 0
+}
+
+func (h *Hand) countInHand() int {
+	result := 0
+	for _, folded := range h.Folded {
+		if !folded {
+			result++
+		}
+	}
+	return result
+}
+
+// Pass the action to the first player from the given seat who still has to act on this street. If there is no such
+// player, the betting round is over, so move on to the next street, or settle the hand if there are no more.
+func (h *Hand) moveOn(from int) {
+	players := len(h.Stacks)
+	if h.countInHand() == 1 {
+		h.returnUncalled()
+		h.settle()
+		return
+	}
+	canAct := 0
+	for seat := range h.Stacks {
+		if h.canAct(seat) {
+			canAct++
+		}
+	}
+	for i := 0; i < players; i++ {
+		seat := (from + i) % players
+		if !h.pending[seat] || !h.canAct(seat) {
+			continue
+		}
+		if canAct == 1 && h.Bets[seat] >= h.currentBet {
+			break // Everyone else is all in, so there is nobody left to bet against
+		}
+		h.toAct = seat
+		return
+	}
+
+	h.returnUncalled()
+	if canAct <= 1 {
+		h.Street = Showdown // No more betting is possible, so the rest of the board is dealt out
+	} else {
+		h.Street++
+	}
+	if h.Street == Showdown {
+		h.settle()
+		return
+	}
+	for seat := range h.Bets {
+		h.Bets[seat] = 0
+	}
+	h.currentBet = 0
+	h.startBetting(h.Button + 1)
+}
+
+// Give back the part of the biggest bet on this street which nobody else matched
+func (h *Hand) returnUncalled() {
+	top, second := 0, 0
+	for seat, bet := range h.Bets {
+		if bet > h.Bets[top] {
+			top = seat
+		}
+	}
+	for seat, bet := range h.Bets {
+		if seat != top && bet > second {
+			second = bet
+		}
+	}
+	if excess := h.Bets[top] - second; excess > 0 {
+		h.Stacks[top] += excess
+		h.Contributions[top] -= excess
+		h.Bets[top] -= excess
+		h.Actions = append(h.Actions, Action{top, h.Street, ReturnUncalled, excess, false})
+	}
+}
+
+// Work out who wins the pot, and pay them
+func (h *Hand) settle() {
+	h.toAct = -1
+	stakes := make([]poker.Stake, len(h.Stacks))
+	var live []int
+	for seat := range stakes {
+		stakes[seat] = poker.Stake{Contribution: h.Contributions[seat], Folded: h.Folded[seat], Cards: h.PlayerCards[seat]}
+		if !h.Folded[seat] {
+			live = append(live, seat)
+		}
+	}
+	if len(live) > 1 {
+		playerCards := make([][]poker.Card, len(live))
+		for i, seat := range live {
+			playerCards[i] = h.PlayerCards[seat]
+		}
+		h.Outcomes = DealOutcomes(h.TableCards, playerCards)
+		for i, seat := range live {
+			h.Outcomes[i].Player = seat + 1
+			stakes[seat].High = h.Outcomes[i].Level
+		}
+	}
+	settlement := poker.Settle(poker.SettlementRules{Ranking: poker.HighRanking}, stakes, h.Button)
+	h.Pots = settlement.Pots
+	h.Winnings = settlement.Winnings
+	for seat, won := range h.Winnings {
+		h.Stacks[seat] += won
+	}
+	for i, o := range h.Outcomes {
+		h.Outcomes[i].PotFractionWon = float64(h.Winnings[o.Player-1]) / float64(h.Pot())
+		h.Outcomes[i].Won = h.Winnings[o.Player-1] > 0
+	}
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// The table cards which are face up
+func (h *Hand) Board() []poker.Card {
+	return h.TableCards[:h.Street.TableCards()]
+}
+
+// The total chips in the pot, including bets on the current street
+func (h *Hand) Pot() int {
+	result := 0
+	for _, c := range h.Contributions {
+		result += c
+	}
+	return result
+}
+
+func (h *Hand) Done() bool {
+	return h.toAct < 0
+}
+
+// The seat whose turn it is to act, or -1 if the hand is over
+func (h *Hand) NextToAct() int {
+	return h.toAct
+}
+
+// How much the player to act has to put in to call, which is less than the bet they face if that would put them all in
+func (h *Hand) ToCall() int {
+	if h.Done() {
+		return 0
+	}
+	return min(h.currentBet-h.Bets[h.toAct], h.Stacks[h.toAct])
+}
+
+// The least and most the player to act may bet or raise to (counting what they have already bet on this street), if
+// they may bet or raise at all. A player who cannot afford a full raise may only raise all in.
+func (h *Hand) RaiseLimits() (least, most int, ok bool) {
+	if h.Done() {
+		return 0, 0, false
+	}
+	seat := h.toAct
+	allIn := h.Bets[seat] + h.Stacks[seat]
+	if !h.mayRaise[seat] || allIn <= h.currentBet || (h.Rules.Limit == FixedLimit && h.betCount >= FixedLimitBetCap) {
+		return 0, 0, false
+	}
+	others := 0
+	for other := range h.Stacks {
+		if other != seat && h.canAct(other) {
+			others++
+		}
+	}
+	if others == 0 {
+		return 0, 0, false // Nobody could call a raise
+	}
+	least = h.currentBet + h.lastRaise
+	switch h.Rules.Limit {
+	case PotLimit:
+		most = h.currentBet + h.Pot() + h.currentBet - h.Bets[seat]
+	case FixedLimit:
+		most = least
+	default:
+		most = allIn
+	}
+	return min(least, allIn), min(most, allIn), true
+}
+
+// The actions open to the player to act
+func (h *Hand) LegalActions() []ActionType {
+	if h.Done() {
+		return nil
+	}
+	result := []ActionType{Fold}
+	if h.ToCall() == 0 {
+		result = append(result, Check)
+	} else {
+		result = append(result, Call)
+	}
+	allIn := h.Bets[h.toAct] + h.Stacks[h.toAct]
+	if _, most, ok := h.RaiseLimits(); ok {
+		if h.currentBet == 0 {
+			result = append(result, Bet)
+		} else {
+			result = append(result, Raise)
+		}
+		if allIn == most {
+			result = append(result, AllIn)
+		}
+	} else if allIn <= h.currentBet {
+		result = append(result, AllIn)
+	}
+	return result
+}
+
+// Take an action for the player whose turn it is. For Bet and Raise, amount is the total the player bets on this
+// street; it is ignored for other actions.
+func (h *Hand) Act(t ActionType, amount int) error {
+	if h.Done() {
+		return errors.New("The hand is over")
+	}
+	seat := h.toAct
+	switch t {
+	case Fold:
+		h.Folded[seat] = true
+		h.Actions = append(h.Actions, Action{seat, h.Street, Fold, 0, false})
+	case Check:
+		if h.ToCall() > 0 {
+			return errors.New(fmt.Sprintf("Cannot check facing a bet of %v", h.currentBet))
+		}
+		h.Actions = append(h.Actions, Action{seat, h.Street, Check, 0, false})
+	case Call:
+		if h.ToCall() == 0 {
+			return errors.New("Nothing to call")
+		}
+		h.put(seat, Call, h.ToCall(), true)
+	case Bet, Raise:
+		if t == Bet && h.currentBet > 0 {
+			return errors.New(fmt.Sprintf("Cannot bet facing a bet of %v", h.currentBet))
+		}
+		if t == Raise && h.currentBet == 0 {
+			return errors.New("Cannot raise when nobody has bet")
+		}
+		least, most, ok := h.RaiseLimits()
+		if !ok {
+			return errors.New(fmt.Sprintf("Seat %v may not %v", seat, t))
+		}
+		if amount < least || amount > most {
+			return errors.New(fmt.Sprintf("Can only %v to between %v and %v, found %v", t, least, most, amount))
+		}
+		h.raiseTo(seat, t, amount)
+	case AllIn:
+		allIn := h.Bets[seat] + h.Stacks[seat]
+		if allIn <= h.currentBet {
+			h.put(seat, Call, h.Stacks[seat], true)
+			break
+		}
+		if _, most, ok := h.RaiseLimits(); !ok || allIn > most {
+			return errors.New(fmt.Sprintf("Seat %v may not go all in for %v", seat, allIn))
+		}
+		if h.currentBet == 0 {
+			h.raiseTo(seat, Bet, allIn)
+		} else {
+			h.raiseTo(seat, Raise, allIn)
+		}
+	default:
+		return errors.New(fmt.Sprintf("Cannot choose to %v", t))
+	}
+	h.pending[seat] = false
+	h.mayRaise[seat] = false
+	h.moveOn(seat + 1)
+	return nil
+}
+
+// Bet or raise to the given total. A raise smaller than the last one (only possible all in) does not reopen the
+// betting to players who have already acted.
+func (h *Hand) raiseTo(seat int, t ActionType, total int) {
+	fullRaise := total-h.currentBet >= h.lastRaise
+	if fullRaise {
+		h.lastRaise = total - h.currentBet
+		h.betCount++
+	}
+	h.currentBet = total
+	h.put(seat, t, total-h.Bets[seat], true)
+	for other := range h.Stacks {
+		if other == seat || !h.canAct(other) {
+			continue
+		}
+		h.pending[other] = true
+		if fullRaise {
+			h.mayRaise[other] = true
+		}
+	}
+}
+
+// A way of choosing the next action in a hand which is not over, e.g. for simulations
+type BettingStrategy func(h *Hand) (t ActionType, amount int)
+
+// A strategy which never bets, and calls any bet
+func CheckOrCall(h *Hand) (ActionType, int) {
+	if h.ToCall() == 0 {
+		return Check, 0
+	}
+	return Call, 0
+}
+
+// Play the hand to the end, taking every action chosen by the strategy
+func (h *Hand) Play(strategy BettingStrategy) error {
+	for !h.Done() {
+		t, amount := strategy(h)
+		if err := h.Act(t, amount); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,288 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package holdem
+
+import (
+	"github.com/amdw/gopoker/poker"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// A pack which deals the given table cards and hole cards, with the rest shuffled
+func packDealing(tableCards []poker.Card, playerCards ...[]poker.Card) *poker.Pack {
+	var positions []int
+	var fixed []poker.Card
+	for i, c := range tableCards {
+		positions = append(positions, i)
+		fixed = append(fixed, c)
+	}
+	for player, cards := range playerCards {
+		for i, c := range cards {
+			positions = append(positions, layout.TableCards+player*layout.HoleCards+i)
+			fixed = append(fixed, c)
+		}
+	}
+	pack := poker.NewPack()
+	pack.ShuffleFixing(rand.New(rand.NewSource(1234)), positions, fixed)
+	return &pack
+}
+
+func newTestHand(rules BettingRules, stacks []int, button int, t *testing.T) *Hand {
+	pack := poker.NewPack()
+	pack.Shuffle(rand.New(rand.NewSource(1234)))
+	h, err := NewHand(rules, stacks, button, &pack)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return h
+}
+
+func mustAct(h *Hand, t ActionType, amount int, test *testing.T) {
+	if err := h.Act(t, amount); err != nil {
+		test.Fatalf("Unexpected error for %v %v: %v", t, amount, err)
+	}
+}
+
+func TestNewHand(t *testing.T) {
+	h := newTestHand(BettingRules{SmallBlind: 1, BigBlind: 2}, []int{100, 100, 100}, 0, t)
+	if !reflect.DeepEqual([]int{100, 99, 98}, h.Stacks) || h.Pot() != 3 || h.NextToAct() != 0 || h.ToCall() != 2 {
+		t.Errorf("Unexpected state after blinds: stacks %v, pot %v, %v to act, %v to call", h.Stacks, h.Pot(), h.NextToAct(), h.ToCall())
+	}
+	if expected := []ActionType{Fold, Call, Raise, AllIn}; !reflect.DeepEqual(expected, h.LegalActions()) {
+		t.Errorf("Expected %v, found %v", expected, h.LegalActions())
+	}
+	if least, most, ok := h.RaiseLimits(); least != 4 || most != 100 || !ok {
+		t.Errorf("Expected raises from 4 to 100, found %v to %v (%v)", least, most, ok)
+	}
+	if len(h.Board()) != 0 || len(h.TableCards) != 5 || len(h.PlayerCards) != 3 {
+		t.Errorf("Unexpected deal: board %v, table %v, players %v", h.Board(), h.TableCards, h.PlayerCards)
+	}
+
+	h = newTestHand(BettingRules{SmallBlind: 1, BigBlind: 2, Ante: 1}, []int{100, 100, 2}, 1, t)
+	if !reflect.DeepEqual([]int{97, 99, 0}, h.Stacks) || !reflect.DeepEqual([]int{2, 0, 1}, h.Bets) || h.Pot() != 6 {
+		t.Errorf("Unexpected state after antes and blinds: stacks %v, bets %v, pot %v", h.Stacks, h.Bets, h.Pot())
+	}
+	if sb := h.Actions[len(h.Actions)-2]; sb.Seat != 2 || sb.Type != PostSmallBlind || sb.Amount != 1 || !sb.AllIn {
+		t.Errorf("Expected the small blind to be all in, found %+v", sb)
+	}
+
+	errorCases := []struct {
+		rules  BettingRules
+		stacks []int
+		button int
+	}{
+		{BettingRules{SmallBlind: 1}, []int{100, 100}, 0},
+		{BettingRules{SmallBlind: 3, BigBlind: 2}, []int{100, 100}, 0},
+		{BettingRules{SmallBlind: 1, BigBlind: 2, Ante: -1}, []int{100, 100}, 0},
+		{BettingRules{SmallBlind: 1, BigBlind: 2}, []int{100}, 0},
+		{BettingRules{SmallBlind: 1, BigBlind: 2}, make([]int, 24), 0},
+		{BettingRules{SmallBlind: 1, BigBlind: 2}, []int{100, 100}, 2},
+		{BettingRules{SmallBlind: 1, BigBlind: 2}, []int{100, 0}, 0},
+	}
+	for _, ec := range errorCases {
+		pack := poker.NewPack()
+		if _, err := NewHand(ec.rules, ec.stacks, ec.button, &pack); err == nil {
+			t.Errorf("Expected error for %+v with stacks %v and button %v", ec.rules, ec.stacks, ec.button)
+		}
+	}
+}
+
+func TestHeadsUp(t *testing.T) {
+	h := newTestHand(BettingRules{SmallBlind: 1, BigBlind: 2}, []int{100, 100}, 1, t)
+	if h.NextToAct() != 1 || h.Bets[1] != 1 || h.Bets[0] != 2 {
+		t.Errorf("Expected the button to post the small blind and act first, found bets %v and %v to act", h.Bets, h.NextToAct())
+	}
+	mustAct(h, Call, 0, t)
+	if h.NextToAct() != 0 || h.Street != Preflop {
+		t.Errorf("Expected the big blind to have the option, found %v to act on %v", h.NextToAct(), h.Street)
+	}
+	mustAct(h, Check, 0, t)
+	if h.NextToAct() != 0 || h.Street != Flop || len(h.Board()) != 3 || h.ToCall() != 0 {
+		t.Errorf("Expected the big blind to act first on the flop, found %v to act on %v", h.NextToAct(), h.Street)
+	}
+}
+
+func TestNoLimitRaises(t *testing.T) {
+	h := newTestHand(BettingRules{SmallBlind: 10, BigBlind: 20}, []int{1000, 1000, 70}, 0, t)
+	for _, bad := range []struct {
+		t      ActionType
+		amount int
+	}{{Check, 0}, {Bet, 40}, {Raise, 30}, {Raise, 1001}, {PostBigBlind, 20}} {
+		if err := h.Act(bad.t, bad.amount); err == nil {
+			t.Errorf("Expected error for %v %v", bad.t, bad.amount)
+		}
+	}
+	mustAct(h, Raise, 60, t)
+	if least, most, _ := h.RaiseLimits(); least != 100 || most != 1000 {
+		t.Errorf("Expected raises from 100 to 1000, found %v to %v", least, most)
+	}
+	mustAct(h, Call, 0, t)
+	// The big blind's all in is less than a full raise, so does not reopen the betting
+	if expected := []ActionType{Fold, Call, Raise, AllIn}; !reflect.DeepEqual(expected, h.LegalActions()) {
+		t.Errorf("Expected %v, found %v", expected, h.LegalActions())
+	}
+	mustAct(h, AllIn, 0, t)
+	if last := h.Actions[len(h.Actions)-1]; last.Type != Raise || last.Amount != 50 || !last.AllIn {
+		t.Errorf("Expected all-in raise of 50, found %+v", last)
+	}
+	for _, seat := range []int{0, 1} {
+		if h.NextToAct() != seat || h.ToCall() != 10 {
+			t.Errorf("Expected %v to call 10, found %v to call %v", seat, h.NextToAct(), h.ToCall())
+		}
+		if expected := []ActionType{Fold, Call}; !reflect.DeepEqual(expected, h.LegalActions()) {
+			t.Errorf("Expected %v, found %v", expected, h.LegalActions())
+		}
+		mustAct(h, Call, 0, t)
+	}
+	if h.Street != Flop || h.NextToAct() != 1 || h.Pot() != 210 {
+		t.Errorf("Expected seat 1 to act on the flop with 210 in the pot, found %v to act on %v with %v", h.NextToAct(), h.Street, h.Pot())
+	}
+}
+
+func TestPotLimit(t *testing.T) {
+	h := newTestHand(BettingRules{Limit: PotLimit, SmallBlind: 1, BigBlind: 2}, []int{1000, 1000, 1000}, 0, t)
+	if least, most, _ := h.RaiseLimits(); least != 4 || most != 7 {
+		t.Errorf("Expected raises from 4 to 7, found %v to %v", least, most)
+	}
+	if err := h.Act(AllIn, 0); err == nil {
+		t.Errorf("Expected error for all in over the pot")
+	}
+	mustAct(h, Raise, 7, t)
+	if least, most, _ := h.RaiseLimits(); least != 12 || most != 23 {
+		t.Errorf("Expected raises from 12 to 23, found %v to %v", least, most)
+	}
+}
+
+func TestFixedLimit(t *testing.T) {
+	h := newTestHand(BettingRules{Limit: FixedLimit, SmallBlind: 1, BigBlind: 2}, []int{1000, 1000, 1000}, 0, t)
+	for _, to := range []int{4, 6, 8} {
+		if least, most, ok := h.RaiseLimits(); least != to || most != to || !ok {
+			t.Errorf("Expected a raise to exactly %v, found %v to %v", to, least, most)
+		}
+		mustAct(h, Raise, to, t)
+	}
+	if _, _, ok := h.RaiseLimits(); ok {
+		t.Errorf("Expected betting to be capped")
+	}
+	mustAct(h, Call, 0, t)
+	mustAct(h, Call, 0, t)
+	if least, most, _ := h.RaiseLimits(); h.Street != Flop || least != 2 || most != 2 {
+		t.Errorf("Expected a bet of exactly 2 on the flop, found %v to %v on %v", least, most, h.Street)
+	}
+	for i := 0; i < 3; i++ {
+		mustAct(h, Check, 0, t)
+	}
+	if least, most, _ := h.RaiseLimits(); h.Street != Turn || least != 4 || most != 4 {
+		t.Errorf("Expected a bet of exactly 4 on the turn, found %v to %v on %v", least, most, h.Street)
+	}
+}
+
+func TestFoldToWin(t *testing.T) {
+	h := newTestHand(BettingRules{SmallBlind: 1, BigBlind: 2}, []int{100, 100, 100}, 0, t)
+	mustAct(h, Raise, 6, t)
+	mustAct(h, Fold, 0, t)
+	mustAct(h, Fold, 0, t)
+	if !h.Done() || !reflect.DeepEqual([]int{5, 0, 0}, h.Winnings) || !reflect.DeepEqual([]int{103, 99, 98}, h.Stacks) || h.Outcomes != nil {
+		t.Errorf("Expected seat 0 to win the blinds, found winnings %v, stacks %v, outcomes %v", h.Winnings, h.Stacks, h.Outcomes)
+	}
+	if last := h.Actions[len(h.Actions)-1]; last.Type != ReturnUncalled || last.Seat != 0 || last.Amount != 4 {
+		t.Errorf("Expected uncalled 4 returned to seat 0, found %+v", last)
+	}
+	if err := h.Act(Check, 0); err == nil {
+		t.Errorf("Expected error acting after the hand is over")
+	}
+}
+
+func TestSidePots(t *testing.T) {
+	pack := packDealing(h("2C", "7D", "9S", "3H", "4C"), h("AS", "AD"), h("KS", "KD"), h("QS", "QD"))
+	hand, err := NewHand(BettingRules{SmallBlind: 1, BigBlind: 2}, []int{50, 100, 100}, 0, pack)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	mustAct(hand, AllIn, 0, t)
+	mustAct(hand, AllIn, 0, t)
+	mustAct(hand, Call, 0, t)
+	if !hand.Done() || hand.Street != Showdown || len(hand.Board()) != 5 {
+		t.Errorf("Expected the board to be run out to a showdown, found %v with board %v", hand.Street, hand.Board())
+	}
+	if !reflect.DeepEqual([]int{150, 100, 0}, hand.Winnings) || !reflect.DeepEqual(hand.Winnings, hand.Stacks) {
+		t.Errorf("Expected aces to win the main pot and kings the side pot, found winnings %v, stacks %v", hand.Winnings, hand.Stacks)
+	}
+	for i, o := range hand.Outcomes {
+		if o.Player != i+1 {
+			t.Errorf("Expected player %v, found %+v", i+1, o)
+		}
+	}
+
+	// The small blind folds, and the other two split the pot with the odd chip going to the first after the button
+	pack = packDealing(h("AH", "KH", "QH", "JH", "10H"))
+	hand, err = NewHand(BettingRules{SmallBlind: 1, BigBlind: 2}, []int{100, 100, 100}, 0, pack)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	mustAct(hand, Call, 0, t)
+	mustAct(hand, Fold, 0, t)
+	if err := hand.Play(CheckOrCall); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual([]int{2, 0, 3}, hand.Winnings) || len(hand.Outcomes) != 2 {
+		t.Errorf("Expected split pot with the odd chip to seat 2, found %v", hand.Winnings)
+	}
+}
+
+func TestRandomPlay(t *testing.T) {
+	randGen := rand.New(rand.NewSource(1234))
+	randomStrategy := func(h *Hand) (ActionType, int) {
+		actions := h.LegalActions()
+		least, most, _ := h.RaiseLimits()
+		return actions[randGen.Intn(len(actions))], least + randGen.Intn(most-least+1)
+	}
+	for i := 0; i < 1000; i++ {
+		players := 2 + randGen.Intn(8)
+		stacks := make([]int, players)
+		total := 0
+		for seat := range stacks {
+			stacks[seat] = 1 + randGen.Intn(200)
+			total += stacks[seat]
+		}
+		rules := BettingRules{Limit: BettingLimit(randGen.Intn(3)), SmallBlind: 1, BigBlind: 2, Ante: randGen.Intn(2)}
+		pack := poker.NewPack()
+		pack.Shuffle(randGen)
+		h, err := NewHand(rules, stacks, randGen.Intn(players), &pack)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if err := h.Play(randomStrategy); err != nil {
+			t.Fatalf("Unexpected error playing %+v: %v", rules, err)
+		}
+		finalTotal, won := 0, 0
+		for seat, stack := range h.Stacks {
+			if stack < 0 {
+				t.Errorf("Negative stack %v for seat %v", stack, seat)
+			}
+			finalTotal += stack
+			won += h.Winnings[seat]
+		}
+		if finalTotal != total || won != h.Pot() {
+			t.Fatalf("Expected %v chips and winnings equal to pot %v, found %v chips and winnings %v", total, h.Pot(), finalTotal, h.Winnings)
+		}
+	}
+}
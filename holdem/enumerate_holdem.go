@@ -0,0 +1,77 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package holdem
+
+import (
+	"context"
+	"github.com/amdw/gopoker/poker"
+	"math/rand"
+)
+
+// The number of distinct deals exhaustive enumeration would have to evaluate, given the known cards and number of players
+func EnumerationCost(tableCards, yourCards []poker.Card, players int) float64 {
+	return poker.EnumerationCost(tableCards, 5, knownPlayerCards(yourCards, players), 2)
+}
+
+// Exhaustive enumeration gives exact results, so use it whenever it involves no more work than the requested simulation
+func shouldEnumerate(tableCards, yourCards []poker.Card, players, handsToPlay int) bool {
+	return EnumerationCost(tableCards, yourCards, players) <= float64(handsToPlay)
+}
+
+func knownPlayerCards(yourCards []poker.Card, players int) [][]poker.Card {
+	result := make([][]poker.Card, players)
+	result[0] = yourCards
+	return result
+}
+
+// Work out exact results by visiting every possible deal consistent with the known cards, dividing the work between
+// several goroutines as for SimulateHoldemParallel. The results take the same form as those of SimulateHoldem,
+// with one "hand" per deal and Exhaustive set. Statistics for the random opponent are those of the first opponent,
+// which is equivalent as every deal is visited. Check EnumerationCost first: this can take a very long time.
+// If ctx is cancelled, the partial results are returned along with ctx.Err(), and are not marked exhaustive.
+func EnumerateHoldem(ctx context.Context, tableCards, yourCards []poker.Card, players, workers int, progress poker.ProgressFunc) (*poker.Simulator, error) {
+	dead := poker.NewCardSet(yourCards...)
+	boards := poker.BoardCompletions(tableCards, 5, dead)
+	total := int(EnumerationCost(tableCards, yourCards, players))
+
+	chunks := poker.ChunkCount(len(boards))
+	results := make([]*poker.Simulator, chunks)
+	errs := make([]error, chunks)
+	aggregator := poker.NewProgressAggregator(chunks, total, progress)
+	poker.RunParallel(len(boards), workers, nil, func(chunk, _ int, _ *rand.Rand) {
+		start, end := poker.ChunkRange(len(boards), chunk)
+		results[chunk], errs[chunk] = enumerateBoards(ctx, tableCards, boards[start:end], yourCards, players, aggregator.Part(chunk))
+	})
+	return poker.MergeResults(results, errs)
+}
+
+func enumerateBoards(ctx context.Context, tableCards []poker.Card, boards [][]poker.Card, yourCards []poker.Card, players int, progress poker.ProgressFunc) (*poker.Simulator, error) {
+	e := poker.NewDealEnumerator(tableCards, boards, knownPlayerCards(yourCards, players), 2)
+	s := poker.Simulator{}
+	s.Reset(players, e.Size())
+	played, err := poker.RunHands(ctx, e.Size(), func() {
+		e.Next()
+		outcomes := gameOutcomes(dealOutcomes(e.TableCards(), e.PlayerCards(), false))
+		s.ProcessHand(poker.NewHandOutcome(outcomes, 1, poker.HighRanking))
+	}, s.Reporter(progress))
+	s.HandCount = played
+	s.Exhaustive = err == nil
+	return &s, err
+}
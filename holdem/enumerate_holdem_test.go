@@ -0,0 +1,130 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package holdem
+
+import (
+	"context"
+	"github.com/amdw/gopoker/poker"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestEnumerateHoldem(t *testing.T) {
+	tableCards := h("KS", "7D", "AH", "8C")
+	yourCards := h("9D", "10C")
+	expectedHands := 46 * 990
+	if cost := EnumerationCost(tableCards, yourCards, 2); cost != float64(expectedHands) {
+		t.Errorf("Expected cost %v, found %v", expectedHands, cost)
+	}
+
+	sim, err := EnumerateHoldem(context.Background(), tableCards, yourCards, 2, 0, nil)
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+	poker.TestAssertSimSanity(sim, 2, expectedHands, t)
+	if !sim.Exhaustive || sim.Equity().StdErr != 0 {
+		t.Errorf("Expected exact results")
+	}
+	// Heads-up, the random opponent is the best opponent
+	if sim.RandomOpponentWinCount != sim.BestOpponentWinCount || sim.RandomOpponentPotsWon != sim.BestOpponentPotsWon {
+		t.Errorf("Expected random opponent results to match best opponent: %v vs %v", sim.RandomOpponentWinCount, sim.BestOpponentWinCount)
+	}
+
+	// The results should not depend on the number of workers
+	for _, workers := range []int{1, 3} {
+		other, _ := EnumerateHoldem(context.Background(), tableCards, yourCards, 2, workers, nil)
+		if !reflect.DeepEqual(sim, other) {
+			t.Errorf("Expected identical results with %v workers", workers)
+		}
+	}
+
+	// Simulation should give similar results
+	mc := SimulateHoldemParallel(tableCards, yourCards, 2, 40000, 0, rand.New(rand.NewSource(1234)))
+	if mc.Exhaustive {
+		t.Errorf("Expected simulation when fewer hands are requested than enumeration requires")
+	}
+	if math.Abs(mc.Equity().Mean-sim.Equity().Mean) > 4*mc.Equity().StdErr {
+		t.Errorf("Simulated equity %+v inconsistent with exact equity %v", mc.Equity(), sim.Equity().Mean)
+	}
+}
+
+func TestAutomaticEnumeration(t *testing.T) {
+	tableCards := h("KS", "7D", "AH", "8C")
+	yourCards := h("9D", "10C")
+	randGen := rand.New(rand.NewSource(1234))
+	exact, _ := EnumerateHoldem(context.Background(), tableCards, yourCards, 2, 0, nil)
+	for _, sim := range []*poker.Simulator{
+		SimulateHoldem(tableCards, yourCards, 2, 50000, randGen),
+		SimulateHoldemParallel(tableCards, yourCards, 2, 50000, 2, randGen),
+	} {
+		if !reflect.DeepEqual(exact, sim) {
+			t.Errorf("Expected enumeration when it is cheaper than simulation")
+		}
+	}
+	sim, _ := SimulateHoldemAdaptive(context.Background(), tableCards, yourCards, 2, 0.001, 50000, 0, randGen, nil)
+	if !reflect.DeepEqual(exact, sim) {
+		t.Errorf("Expected adaptive simulation to enumerate when it is cheaper than the maximum hand count")
+	}
+}
+
+func TestEnumerateHoldemUnknownCards(t *testing.T) {
+	// With our hole cards unknown, we and our opponent are in identical positions
+	tableCards := h("KS", "7D", "AH", "8C", "8D")
+	sim, err := EnumerateHoldem(context.Background(), tableCards, []poker.Card{}, 2, 0, nil)
+	expectedHands := 1081 * 990
+	if err != nil || !sim.Exhaustive {
+		t.Errorf("Expected complete enumeration, found error %v", err)
+	}
+	poker.TestAssertSimSanity(sim, 2, expectedHands, t)
+	if sim.WinCount != sim.BestOpponentWinCount || math.Abs(2*sim.PotsWon-float64(expectedHands)) > 1e-6 {
+		t.Errorf("Expected equal shares for us and our opponent, found %v and %v", sim.PotsWon, sim.BestOpponentPotsWon)
+	}
+}
+
+func TestEnumerateHoldemMultiway(t *testing.T) {
+	tableCards := h("KS", "7D", "AH", "8C", "8D")
+	yourCards := h("9D", "7C")
+	sim, err := EnumerateHoldem(context.Background(), tableCards, yourCards, 3, 0, nil)
+	expectedHands := 990 * 903
+	if err != nil || !sim.Exhaustive {
+		t.Errorf("Expected complete enumeration, found error %v", err)
+	}
+	poker.TestAssertSimSanity(sim, 3, expectedHands, t)
+	// Both opponents are in identical positions, so the first opponent should have exactly half of the remaining equity
+	if math.Abs(2*sim.RandomOpponentPotsWon-(float64(expectedHands)-sim.PotsWon)) > 1e-6 {
+		t.Errorf("Expected random opponent to have half the remaining equity: %v vs %v", sim.RandomOpponentPotsWon, sim.PotsWon)
+	}
+}
+
+func TestEnumerateHoldemCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	progress := func(p poker.Progress) {
+		if p.HandsPlayed >= 5000 {
+			cancel()
+		}
+	}
+	sim, err := EnumerateHoldem(ctx, h("KS", "7D", "AH"), h("9D", "10C"), 2, 2, progress)
+	if err != context.Canceled || sim.Exhaustive {
+		t.Errorf("Expected cancellation, found error %v, exhaustive %v", err, sim.Exhaustive)
+	}
+	poker.TestAssertSimSanity(sim, 2, sim.HandCount, t)
+}
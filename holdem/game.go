@@ -0,0 +1,149 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package holdem
+
+import (
+	"fmt"
+	"github.com/amdw/gopoker/poker"
+	"strings"
+)
+
+// Where the cards are dealt from the pack: five on the table, then two for each player
+var layout = poker.DealLayout{TableCards: 5, HoleCards: 2}
+
+// Texas Hold'em as a poker.Game
+type Game struct{}
+
+// Short-deck Hold'em under the given rules as a poker.Game
+type ShortDeckGame struct {
+	Rules poker.ShortDeckRules
+}
+
+// Texas Hold'em with wild cards, played with a standard pack plus the given number of jokers
+type WildGame struct {
+	Wild   poker.WildCards
+	Jokers int
+}
+
+func init() {
+	poker.RegisterGame("holdem", Game{})
+	poker.RegisterGame("shortdeck", ShortDeckGame{})
+	poker.RegisterGame("shortdeck-trips", ShortDeckGame{poker.ShortDeckRules{TripsBeatStraight: true}})
+	poker.RegisterGame("holdem-deuces", WildGame{Wild: poker.WildCards{Ranks: []poker.Rank{poker.Two}}})
+	poker.RegisterGame("holdem-jokers", WildGame{Jokers: 2})
+	poker.RegisterGame("holdem-bug", WildGame{Wild: poker.WildCards{Bug: true}, Jokers: 1})
+}
+
+func (g Game) Name() string {
+	return "Texas Hold'em"
+}
+
+func (g Game) NewPack() poker.Pack {
+	return poker.NewPack()
+}
+
+func (g Game) Layout() poker.DealLayout {
+	return layout
+}
+
+func (g Game) SettlementRules() poker.SettlementRules {
+	return poker.SettlementRules{Ranking: poker.HighRanking}
+}
+
+func (g Game) ValidateKnownCards(tableCards, yourCards []poker.Card, players int) error {
+	return poker.ValidateGameCards(g, tableCards, yourCards, players)
+}
+
+func (g Game) Outcomes(tableCards []poker.Card, playerCards [][]poker.Card) []poker.GameOutcome {
+	return gameOutcomes(DealOutcomes(tableCards, playerCards))
+}
+
+func (g ShortDeckGame) Name() string {
+	if g.Rules.TripsBeatStraight {
+		return "Short-deck Hold'em (trips beat a straight)"
+	}
+	return "Short-deck Hold'em"
+}
+
+func (g ShortDeckGame) NewPack() poker.Pack {
+	return poker.NewShortPack()
+}
+
+func (g ShortDeckGame) Layout() poker.DealLayout {
+	return layout
+}
+
+func (g ShortDeckGame) SettlementRules() poker.SettlementRules {
+	return poker.SettlementRules{Ranking: g.Rules.Ranking()}
+}
+
+func (g ShortDeckGame) ValidateKnownCards(tableCards, yourCards []poker.Card, players int) error {
+	return poker.ValidateGameCards(g, tableCards, yourCards, players)
+}
+
+func (g ShortDeckGame) Outcomes(tableCards []poker.Card, playerCards [][]poker.Card) []poker.GameOutcome {
+	return gameOutcomes(ShortDeckOutcomes(g.Rules, tableCards, playerCards))
+}
+
+func (g WildGame) Name() string {
+	var wilds []string
+	for _, r := range g.Wild.Ranks {
+		wilds = append(wilds, fmt.Sprintf("%vs", r))
+	}
+	switch {
+	case g.Jokers == 1 && g.Wild.Bug:
+		wilds = append(wilds, "joker as bug")
+	case g.Jokers == 1:
+		wilds = append(wilds, "joker")
+	case g.Jokers > 1 && g.Wild.Bug:
+		wilds = append(wilds, "jokers as bugs")
+	case g.Jokers > 1:
+		wilds = append(wilds, "jokers")
+	}
+	return fmt.Sprintf("Texas Hold'em (%v wild)", strings.Join(wilds, " and "))
+}
+
+func (g WildGame) NewPack() poker.Pack {
+	return poker.NewPackWithJokers(g.Jokers)
+}
+
+func (g WildGame) Layout() poker.DealLayout {
+	return layout
+}
+
+func (g WildGame) SettlementRules() poker.SettlementRules {
+	return poker.SettlementRules{Ranking: poker.HighRanking}
+}
+
+func (g WildGame) ValidateKnownCards(tableCards, yourCards []poker.Card, players int) error {
+	return poker.ValidateGameCards(g, tableCards, yourCards, players)
+}
+
+func (g WildGame) Outcomes(tableCards []poker.Card, playerCards [][]poker.Card) []poker.GameOutcome {
+	return gameOutcomes(WildOutcomes(g.Wild, tableCards, playerCards))
+}
+
+func gameOutcomes(outcomes []PlayerOutcome) []poker.GameOutcome {
+	result := make([]poker.GameOutcome, len(outcomes))
+	for i, o := range outcomes {
+		result[i] = poker.GameOutcome{Player: o.Player, Level: o.Level, Cards: o.Cards, PotFractionWon: o.PotFractionWon}
+	}
+	return result
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package holdem
+
+import (
+	"github.com/amdw/gopoker/poker"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestGameRegistration(t *testing.T) {
+	expected := map[string]poker.Game{
+		"holdem":          Game{},
+		"shortdeck":       ShortDeckGame{},
+		"shortdeck-trips": ShortDeckGame{poker.ShortDeckRules{TripsBeatStraight: true}},
+		"holdem-deuces":   WildGame{Wild: poker.WildCards{Ranks: []poker.Rank{poker.Two}}},
+		"holdem-jokers":   WildGame{Jokers: 2},
+		"holdem-bug":      WildGame{Wild: poker.WildCards{Bug: true}, Jokers: 1},
+	}
+	for key, game := range expected {
+		if g, ok := poker.LookupGame(key); !ok || !reflect.DeepEqual(g, game) {
+			t.Errorf("Expected %v to be registered as %q, found %v", game.Name(), key, g)
+		}
+	}
+}
+
+func TestGameOutcomes(t *testing.T) {
+	for _, test := range gameOutcomeTests {
+		outcomes := Game{}.Outcomes(test.tableCards, test.playerCards)
+		for i, outcome := range outcomes {
+			expected := test.expectedOutcomes[i]
+			if outcome.Player != expected.Player || !levelsEqual(outcome.Level, expected.Level) || outcome.PotFractionWon != expected.PotFractionWon {
+				t.Errorf("Expected %+v, found %+v", expected, outcome)
+			}
+		}
+	}
+
+	// The flush beats the full house in short-deck
+	outcomes := ShortDeckGame{}.Outcomes(h("KH", "KD", "9H", "7H", "AS"), hs(h("AH", "6H"), h("AD", "AC")))
+	if outcomes[0].PotFractionWon != 1 || outcomes[1].PotFractionWon != 0 {
+		t.Errorf("Expected the flush to win, found %+v", outcomes)
+	}
+}
+
+func TestGameSimulation(t *testing.T) {
+	// The generic simulator should give the same results as the Hold'em simulator given the same seed
+	players := 4
+	simulations := 2000
+	for _, rules := range []poker.ShortDeckRules{{}, {TripsBeatStraight: true}} {
+		expected := SimulateShortDeck(rules, h("6C"), h("AS", "KD"), players, simulations, rand.New(rand.NewSource(1234)))
+		sim := poker.SimulateGame(ShortDeckGame{rules}, h("6C"), h("AS", "KD"), players, simulations, rand.New(rand.NewSource(1234)))
+		if !reflect.DeepEqual(expected, sim) {
+			t.Errorf("Expected identical results from the generic simulator for %v", ShortDeckGame{rules}.Name())
+		}
+	}
+	sim := poker.SimulateGame(Game{}, h("2C", "7D", "JS"), h("AS", "KD"), players, simulations, rand.New(rand.NewSource(1234)))
+	poker.TestAssertSimSanity(sim, players, simulations, t)
+
+	if err := (ShortDeckGame{}).ValidateKnownCards(h("5C"), nil, 2); err == nil {
+		t.Errorf("Expected error for a five in short-deck")
+	}
+	if err := (ShortDeckGame{}).ValidateKnownCards(nil, nil, 16); err == nil {
+		t.Errorf("Expected error for 16 short-deck players")
+	}
+}
@@ -20,31 +20,23 @@ along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
 package holdem
 
 import (
-	"fmt"
 	"github.com/amdw/gopoker/poker"
 )
 
 func classify(tableCards, holeCards []poker.Card) (poker.HandLevel, []poker.Card) {
-	allCards := make([]poker.Card, 7)
-	copy(allCards, holeCards)
-	copy(allCards[2:], tableCards)
-
-	// Construct all possible hands and find the best one
-	allPossibleHands := poker.AllCardCombinations(allCards, 5)
-
-	bestHand := allPossibleHands[0]
-	bestRank := poker.ClassifyHand(bestHand)
+	strength, bestHand := evaluate(tableCards, holeCards, true)
+	return strength.Level(), bestHand
+}
 
-	for i := 1; i < len(allPossibleHands); i++ {
-		hand := allPossibleHands[i]
-		rank := poker.ClassifyHand(hand)
-		if poker.Beats(rank, bestRank) {
-			bestHand = hand
-			bestRank = rank
-		}
+// Evaluate the best hand a player can make, optionally also returning the five cards making it up
+func evaluate(tableCards, holeCards []poker.Card, wantCards bool) (poker.HandStrength, []poker.Card) {
+	var allCardsBuf [7]poker.Card
+	allCards := append(append(allCardsBuf[:0], holeCards...), tableCards...)
+	if !wantCards {
+		return poker.Evaluate7(allCards), nil
 	}
-
-	return bestRank, bestHand
+	bestHand := make([]poker.Card, 5)
+	return poker.Evaluate7Best(allCards, bestHand), bestHand
 }
 
 type PlayerOutcome struct {
@@ -56,43 +48,74 @@ type PlayerOutcome struct {
 }
 
 func Deal(p *poker.Pack, players int) (onTable []poker.Card, playerCards [][]poker.Card) {
-	if players < 1 {
-		panic(fmt.Sprintf("At least one player required, found %v", players))
-	}
-
-	onTable = p.Cards[0:5]
-
-	playerCards = make([][]poker.Card, players)
-	for player := 0; player < players; player++ {
-		playerCards[player] = p.Cards[5+2*player : 7+2*player]
-	}
-
-	return onTable, playerCards
+	return layout.Deal(p, players)
 }
 
 // Assess the hand each player holds and return a sorted list of outcomes
 // by hand strength (descending) then player number (ascending).
 // Player numbers are in ascending order of playerCards entries, starting with 1.
 func DealOutcomes(onTable []poker.Card, playerCards [][]poker.Card) []PlayerOutcome {
+	return dealOutcomes(onTable, playerCards, true)
+}
+
+// As DealOutcomes, but optionally skip working out which cards make up each player's hand.
+func dealOutcomes(onTable []poker.Card, playerCards [][]poker.Card, wantCards bool) []PlayerOutcome {
+	outcomes := make([]PlayerOutcome, len(playerCards))
+	strengths := make([]poker.HandStrength, len(playerCards))
+	var bestStrength poker.HandStrength
+	for playerIdx, hand := range playerCards {
+		strength, cards := evaluate(onTable, hand, wantCards)
+		outcomes[playerIdx] = PlayerOutcome{playerIdx + 1, strength.Level(), cards, false, 0}
+		strengths[playerIdx] = strength
+		if strength > bestStrength {
+			bestStrength = strength
+		}
+	}
+
+	winners := 0
+	for _, strength := range strengths {
+		if strength == bestStrength {
+			winners++
+		}
+	}
+	for i, strength := range strengths {
+		if strength == bestStrength {
+			outcomes[i].Won = true
+			outcomes[i].PotFractionWon = 1.0 / float64(winners)
+		}
+	}
+	return outcomes
+}
+
+// Outcomes for each player, where the best hand of each is found by bestHand and hands are compared using beats
+func rankedOutcomes(bestHand func(cards, best []poker.Card) poker.HandLevel, beats func(l1, l2 poker.HandLevel) bool, onTable []poker.Card, playerCards [][]poker.Card, wantCards bool) []PlayerOutcome {
 	outcomes := make([]PlayerOutcome, len(playerCards))
-	var bestLevel poker.HandLevel
+	var allCardsBuf [7]poker.Card
+	bestIdx := 0
 	for playerIdx, hand := range playerCards {
-		level, cards := classify(onTable, hand)
-		outcomes[playerIdx] = PlayerOutcome{playerIdx + 1, level, cards, false, 0}
-		if playerIdx == 0 || poker.Beats(level, bestLevel) {
-			bestLevel = level
+		allCards := append(append(allCardsBuf[:0], hand...), onTable...)
+		var cards []poker.Card
+		if wantCards {
+			cards = make([]poker.Card, 5)
+		}
+		outcomes[playerIdx] = PlayerOutcome{Player: playerIdx + 1, Level: bestHand(allCards, cards), Cards: cards}
+		if beats(outcomes[playerIdx].Level, outcomes[bestIdx].Level) {
+			bestIdx = playerIdx
 		}
 	}
 
-	winners := make([]int, 0, len(outcomes))
-	for i, outcome := range outcomes {
-		if !poker.Beats(bestLevel, outcome.Level) {
-			winners = append(winners, i)
+	bestLevel := outcomes[bestIdx].Level
+	winners := 0
+	for i := range outcomes {
+		if !beats(bestLevel, outcomes[i].Level) {
+			outcomes[i].Won = true
+			winners++
 		}
 	}
-	for _, i := range winners {
-		outcomes[i].Won = true
-		outcomes[i].PotFractionWon = 1.0 / float64(len(winners))
+	for i := range outcomes {
+		if outcomes[i].Won {
+			outcomes[i].PotFractionWon = 1.0 / float64(winners)
+		}
 	}
 	return outcomes
 }
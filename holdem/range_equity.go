@@ -0,0 +1,310 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package holdem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/amdw/gopoker/poker"
+	"math/rand"
+)
+
+// The equity of one combo from the first range, against the other ranges
+type ComboEquity struct {
+	Combo     Combo
+	Frequency float64 // How often the first player holds this combo, given the other ranges and the table
+	Equity    float64 // The average fraction of the pot won with this combo
+}
+
+// The results of a range-versus-range equity calculation
+type RangeEquityResult struct {
+	Deals       int              // The number of deals visited or simulated
+	Exact       bool             // Whether every possible deal was visited, in which case the equities are exact
+	Partial     bool             // Whether the calculation was cut short, in which case the equities cover only the deals visited
+	Equity      []poker.Estimate // The average fraction of the pot won by each range in turn
+	ComboEquity []ComboEquity    // A breakdown of the first range's equity by combo
+}
+
+// All 1326 two-card combos, each with weight 1
+func AnyTwoCards() Range {
+	result := Range{}
+	p := poker.NewPack()
+	poker.ForEachCombination(p.Cards[:], 2, func(cards []poker.Card) bool {
+		result[NewCombo(cards[0], cards[1])] = 1
+		return true
+	})
+	return result
+}
+
+// An upper bound on the number of deals RangeEquity would have to visit to work out exact results.
+// A nil range stands for any two cards.
+func RangeEquityCost(tableCards []poker.Card, ranges []Range) float64 {
+	dead := poker.NewCardSet(tableCards...)
+	result := float64(poker.CombinationCount(52-len(tableCards)-2*len(ranges), 5-len(tableCards)))
+	for _, r := range ranges {
+		if r == nil {
+			result *= float64(poker.CombinationCount(52-len(tableCards), 2))
+		} else {
+			result *= float64(r.RemoveBlocked(dead).ComboCount())
+		}
+	}
+	return result
+}
+
+// Work out the equity of each of several ranges against the others, given the cards known to be on the table
+// (between zero and five). Each player's hole cards are drawn from their range in proportion to the combos' weights,
+// consistently with the table and with each other; a nil range stands for any two cards.
+// If RangeEquityCost is at most maxDeals, every possible deal is visited and the results are exact; otherwise
+// maxDeals deals are simulated, dividing the work between several goroutines as for SimulateHoldemParallel.
+// If ctx is cancelled, the partial results are returned, marked as such, along with ctx.Err().
+func RangeEquity(ctx context.Context, tableCards []poker.Card, ranges []Range, maxDeals, workers int, randGen *rand.Rand) (*RangeEquityResult, error) {
+	if len(ranges) < 2 {
+		return nil, errors.New(fmt.Sprintf("At least two ranges required, found %v", len(ranges)))
+	}
+	if len(tableCards) > 5 {
+		return nil, errors.New(fmt.Sprintf("At most 5 table cards allowed, found %v", len(tableCards)))
+	}
+	dead := poker.NewCardSet(tableCards...)
+	if dead.Count() != len(tableCards) {
+		return nil, errors.New(fmt.Sprintf("Duplicate cards found on table: %v", tableCards))
+	}
+	weights := make([]Range, len(ranges))
+	samplers := make([]*rangeSampler, len(ranges))
+	for i, r := range ranges {
+		if r == nil {
+			r = AnyTwoCards()
+		}
+		weights[i] = r
+		samplers[i] = newRangeSampler(r, dead)
+		if len(samplers[i].combos) == 0 {
+			return nil, errors.New(fmt.Sprintf("Range %v has no combos left once the table cards are removed", i+1))
+		}
+	}
+
+	var results []*rangeEquityTotals
+	var errs []error
+	exact := RangeEquityCost(tableCards, ranges) <= float64(maxDeals)
+	if exact {
+		// Split the work up by the first player's combo
+		firstCombos := samplers[0].combos
+		chunks := poker.ChunkCount(len(firstCombos))
+		results, errs = make([]*rangeEquityTotals, chunks), make([]error, chunks)
+		poker.RunParallel(len(firstCombos), workers, nil, func(chunk, _ int, _ *rand.Rand) {
+			start, end := poker.ChunkRange(len(firstCombos), chunk)
+			results[chunk], errs[chunk] = enumerateRangeEquity(ctx, tableCards, weights, samplers, firstCombos[start:end])
+		})
+	} else {
+		chunks := poker.ChunkCount(maxDeals)
+		results, errs = make([]*rangeEquityTotals, chunks), make([]error, chunks)
+		poker.RunParallel(maxDeals, workers, randGen, func(chunk, deals int, chunkRandGen *rand.Rand) {
+			results[chunk], errs[chunk] = simulateRangeEquity(ctx, tableCards, samplers, deals, chunkRandGen)
+		})
+	}
+	var err error
+	for i := range results {
+		if i > 0 {
+			results[0].merge(results[i])
+		}
+		if err == nil {
+			err = errs[i]
+		}
+	}
+	result := results[0].result(samplers[0].combos, exact)
+	result.Exact = exact && err == nil
+	result.Partial = err != nil
+	return result, err
+}
+
+// Running totals for a range equity calculation, with each deal weighted by the product of its combos' weights
+type rangeEquityTotals struct {
+	deals            int
+	weight           float64
+	potShares        []float64 // Total weighted pot share for each player
+	potSharesSquared []float64
+	comboWeight      map[Combo]float64 // Total weight of the deals in which the first player held each combo
+	comboPotShares   map[Combo]float64 // Total weighted pot share of the first player with each combo
+}
+
+func newRangeEquityTotals(players int) *rangeEquityTotals {
+	return &rangeEquityTotals{
+		potShares:        make([]float64, players),
+		potSharesSquared: make([]float64, players),
+		comboWeight:      map[Combo]float64{},
+		comboPotShares:   map[Combo]float64{},
+	}
+}
+
+func (t *rangeEquityTotals) add(shares []float64, firstCombo Combo, weight float64) {
+	t.deals++
+	t.weight += weight
+	for i, share := range shares {
+		t.potShares[i] += weight * share
+		t.potSharesSquared[i] += weight * share * share
+	}
+	t.comboWeight[firstCombo] += weight
+	t.comboPotShares[firstCombo] += weight * shares[0]
+}
+
+func (t *rangeEquityTotals) merge(other *rangeEquityTotals) {
+	t.deals += other.deals
+	t.weight += other.weight
+	for i := range t.potShares {
+		t.potShares[i] += other.potShares[i]
+		t.potSharesSquared[i] += other.potSharesSquared[i]
+	}
+	for combo, weight := range other.comboWeight {
+		t.comboWeight[combo] += weight
+		t.comboPotShares[combo] += other.comboPotShares[combo]
+	}
+}
+
+// Summarise the totals, listing the combo equities in the order given
+func (t *rangeEquityTotals) result(firstCombos []Combo, enumerated bool) *RangeEquityResult {
+	result := RangeEquityResult{Deals: t.deals, Equity: make([]poker.Estimate, len(t.potShares))}
+	for i := range t.potShares {
+		if enumerated {
+			if t.weight > 0 {
+				result.Equity[i] = poker.Estimate{Mean: t.potShares[i] / t.weight}
+			}
+		} else {
+			// Simulated deals all have weight 1
+			result.Equity[i] = poker.MeanEstimate(t.potShares[i], t.potSharesSquared[i], t.deals)
+		}
+	}
+	if t.weight == 0 {
+		return &result
+	}
+	for _, combo := range firstCombos {
+		weight := t.comboWeight[combo]
+		if weight == 0 {
+			continue
+		}
+		result.ComboEquity = append(result.ComboEquity, ComboEquity{combo, weight / t.weight, t.comboPotShares[combo] / weight})
+	}
+	return &result
+}
+
+// Fill in each player's share of the pot, given all five table cards and each player's hole cards
+func potShares(tableCards []poker.Card, playerCards [][]poker.Card, strengths []poker.HandStrength, shares []float64) {
+	var best poker.HandStrength
+	for i, cards := range playerCards {
+		strengths[i], _ = evaluate(tableCards, cards, false)
+		if strengths[i] > best {
+			best = strengths[i]
+		}
+	}
+	winners := 0
+	for _, strength := range strengths {
+		if strength == best {
+			winners++
+		}
+	}
+	for i, strength := range strengths {
+		shares[i] = 0
+		if strength == best {
+			shares[i] = 1 / float64(winners)
+		}
+	}
+}
+
+// Visit every deal in which the first player holds one of firstCombos
+func enumerateRangeEquity(ctx context.Context, tableCards []poker.Card, weights []Range, samplers []*rangeSampler, firstCombos []Combo) (*rangeEquityTotals, error) {
+	players := len(samplers)
+	totals := newRangeEquityTotals(players)
+	combos := make([]Combo, players)
+	playerCards := make([][]poker.Card, players)
+	strengths := make([]poker.HandStrength, players)
+	shares := make([]float64, players)
+	var boardBuf [5]poker.Card
+	known := append(boardBuf[:0], tableCards...)
+	var err error
+
+	var deal func(player int, used poker.CardSet, weight float64) bool
+	deal = func(player int, used poker.CardSet, weight float64) bool {
+		if player == players {
+			return poker.ForEachCombination(used.Complement().Cards(), 5-len(known), func(rest []poker.Card) bool {
+				potShares(append(known, rest...), playerCards, strengths, shares)
+				totals.add(shares, combos[0], weight)
+				if totals.deals%poker.CancelCheckInterval == 0 {
+					err = ctx.Err()
+				}
+				return err == nil
+			})
+		}
+		candidates := samplers[player].combos
+		if player == 0 {
+			candidates = firstCombos
+		}
+		for _, combo := range candidates {
+			comboCards := combo.CardSet()
+			if comboCards.Intersects(used) {
+				continue
+			}
+			combos[player] = combo
+			playerCards[player] = combos[player][:]
+			if !deal(player+1, used.Union(comboCards), weight*weights[player][combo]) {
+				return false
+			}
+		}
+		return true
+	}
+	deal(0, poker.NewCardSet(tableCards...), 1)
+	return totals, err
+}
+
+// Simulate random deals, with each player's hole cards drawn from their range
+func simulateRangeEquity(ctx context.Context, tableCards []poker.Card, samplers []*rangeSampler, dealsToPlay int, randGen *rand.Rand) (*rangeEquityTotals, error) {
+	players := len(samplers)
+	totals := newRangeEquityTotals(players)
+	combos := make([]Combo, players)
+	playerCards := make([][]poker.Card, players)
+	strengths := make([]poker.HandStrength, players)
+	shares := make([]float64, players)
+	dead := poker.NewCardSet(tableCards...)
+	p := poker.NewPack()
+	fixing := layout.Fixing(tableCards, nil)
+	known := len(fixing.Cards)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var dealErr error
+
+	_, err := poker.RunHands(ctx, dealsToPlay, func() {
+		if dealErr != nil {
+			return
+		}
+		if !dealFromRanges(samplers, dead, randGen, combos, playerCards) {
+			dealErr = errors.New("Could not deal the players non-overlapping hands from their ranges")
+			cancel()
+			return
+		}
+		fixing.Truncate(known)
+		for player, cards := range playerCards {
+			fixing.Place(layout.HoleCardsStart(player), cards...)
+		}
+		p.ShuffleFixed(randGen, &fixing)
+		potShares(p.Cards[:5], playerCards, strengths, shares)
+		totals.add(shares, combos[0], 1)
+	}, nil)
+	if dealErr != nil {
+		return totals, dealErr
+	}
+	return totals, err
+}
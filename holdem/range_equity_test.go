@@ -0,0 +1,193 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package holdem
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAnyTwoCards(t *testing.T) {
+	if count := AnyTwoCards().ComboCount(); count != 1326 {
+		t.Errorf("Expected 1326 combos, found %v", count)
+	}
+}
+
+func TestRangeEquityCost(t *testing.T) {
+	tableCards := h("2C", "7D", "9H")
+	ranges := []Range{mustParseRange("AA"), mustParseRange("KK,77")}
+	// 6 combos of aces, 6 of kings plus 3 unblocked sevens, and 45 choose 2 turn and river cards
+	if cost, expected := RangeEquityCost(tableCards, ranges), float64(6*9*990); cost != expected {
+		t.Errorf("Expected cost %v, found %v", expected, cost)
+	}
+}
+
+func TestRangeEquitySingleCombos(t *testing.T) {
+	// With one combo per range, the results should match those for known hole cards
+	tableCards := h("KS", "7D", "AH", "8C")
+	ranges := []Range{mustParseRange("9d10c"), mustParseRange("QhJh")}
+	result, err := RangeEquity(context.Background(), tableCards, ranges, 1000, 0, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if !result.Exact || result.Deals != 44 {
+		t.Errorf("Expected exact results over 44 deals, found %v over %v", result.Exact, result.Deals)
+	}
+	// 9-10 wins with a six, one of the three jacks left or a pair of nines; Q-J wins with anything else
+	expected := []float64{10.0 / 44, 34.0 / 44}
+	for i, e := range result.Equity {
+		if math.Abs(e.Mean-expected[i]) > 1e-9 || e.StdErr != 0 {
+			t.Errorf("Expected exact equity %v for range %v, found %v", expected[i], i+1, e)
+		}
+	}
+	if len(result.ComboEquity) != 1 || result.ComboEquity[0].Frequency != 1 || math.Abs(result.ComboEquity[0].Equity-expected[0]) > 1e-9 {
+		t.Errorf("Unexpected combo breakdown %v", result.ComboEquity)
+	}
+}
+
+func assertRangeEquitySanity(result *RangeEquityResult, t *testing.T) {
+	totalEquity := 0.0
+	for _, e := range result.Equity {
+		totalEquity += e.Mean
+	}
+	if math.Abs(totalEquity-1) > 1e-9 {
+		t.Errorf("Expected equities to add up to 1, found %v", totalEquity)
+	}
+	totalFrequency, comboEquity := 0.0, 0.0
+	for _, ce := range result.ComboEquity {
+		totalFrequency += ce.Frequency
+		comboEquity += ce.Frequency * ce.Equity
+	}
+	if math.Abs(totalFrequency-1) > 1e-9 {
+		t.Errorf("Expected combo frequencies to add up to 1, found %v", totalFrequency)
+	}
+	if math.Abs(comboEquity-result.Equity[0].Mean) > 1e-9 {
+		t.Errorf("Expected combo breakdown to add up to equity %v, found %v", result.Equity[0].Mean, comboEquity)
+	}
+}
+
+func TestRangeEquityExactVsSimulated(t *testing.T) {
+	tableCards := h("2C", "7D", "9H")
+	ranges := []Range{mustParseRange("AA,AKs"), mustParseRange("KK,77@50%")}
+	exact, err := RangeEquity(context.Background(), tableCards, ranges, 100000, 0, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if !exact.Exact || exact.Deals == 0 {
+		t.Errorf("Expected exact results, found %v over %v deals", exact.Exact, exact.Deals)
+	}
+	assertRangeEquitySanity(exact, t)
+	if len(exact.ComboEquity) != 10 {
+		t.Errorf("Expected 10 combos in breakdown, found %v", len(exact.ComboEquity))
+	}
+	// Aces are well ahead of this range on such a dry board
+	for _, ce := range exact.ComboEquity {
+		if ce.Combo.String() == "AsAc" && (ce.Equity < 0.6 || ce.Equity > 0.9) {
+			t.Errorf("Unexpected equity for AsAc: %v", ce.Equity)
+		}
+	}
+
+	// The results should not depend on the number of workers
+	other, _ := RangeEquity(context.Background(), tableCards, ranges, 100000, 3, nil)
+	if !reflect.DeepEqual(exact, other) {
+		t.Errorf("Expected identical results with 3 workers")
+	}
+
+	simulated, err := RangeEquity(context.Background(), tableCards, ranges, 20000, 0, rand.New(rand.NewSource(1234)))
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if simulated.Exact || simulated.Deals != 20000 {
+		t.Errorf("Expected 20000 simulated deals, found %v (exact %v)", simulated.Deals, simulated.Exact)
+	}
+	assertRangeEquitySanity(simulated, t)
+	for i := range exact.Equity {
+		if e := simulated.Equity[i]; e.StdErr == 0 || math.Abs(e.Mean-exact.Equity[i].Mean) > 4*e.StdErr {
+			t.Errorf("Expected simulated equity %v close to exact equity %v for range %v", e, exact.Equity[i].Mean, i+1)
+		}
+	}
+}
+
+func TestRangeEquityMultiway(t *testing.T) {
+	tableCards := h("2C", "7D", "9H", "JS")
+	ranges := []Range{mustParseRange("QQ+"), mustParseRange("JJ,99"), nil}
+	if cost := RangeEquityCost(tableCards, ranges); cost > 1e7 {
+		t.Fatalf("Test case too expensive: %v", cost)
+	}
+	result, err := RangeEquity(context.Background(), tableCards, ranges, 10000000, 0, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if !result.Exact || len(result.Equity) != 3 {
+		t.Errorf("Expected exact results for 3 ranges, found %v for %v", result.Exact, len(result.Equity))
+	}
+	assertRangeEquitySanity(result, t)
+	// Sets are a big favourite
+	if result.Equity[1].Mean < 0.8 {
+		t.Errorf("Expected sets to dominate, found equities %v", result.Equity)
+	}
+}
+
+func TestRangeEquityErrors(t *testing.T) {
+	aces := mustParseRange("AA")
+	testCases := []struct {
+		tableCards []string
+		ranges     []Range
+		expected   string
+	}{
+		{nil, []Range{aces}, "At least two ranges"},
+		{[]string{"AS", "AC", "AD"}, []Range{nil, aces}, "Range 2 has no combos left"},
+		{[]string{"AS", "AS"}, []Range{aces, nil}, "Duplicate cards"},
+	}
+	for _, tc := range testCases {
+		_, err := RangeEquity(context.Background(), h(tc.tableCards...), tc.ranges, 1000, 0, nil)
+		if err == nil || !strings.Contains(err.Error(), tc.expected) {
+			t.Errorf("Expected error containing %q for %v, found %v", tc.expected, tc.tableCards, err)
+		}
+	}
+}
+
+func TestRangeEquityCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	ranges := []Range{mustParseRange("AA"), mustParseRange("KK")}
+	result, err := RangeEquity(ctx, nil, ranges, 1000000000, 0, nil)
+	if err != context.Canceled {
+		t.Errorf("Expected cancellation, found %v", err)
+	}
+	if result == nil || result.Exact || !result.Partial {
+		t.Errorf("Expected inexact partial results, found %v", result)
+	}
+}
+
+func TestComboJson(t *testing.T) {
+	bytes, err := json.Marshal(ComboEquity{Combo: NewCombo(h("10H")[0], h("AH")[0])})
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if !strings.Contains(string(bytes), `"Combo":"AhTh"`) {
+		t.Errorf("Expected combo rendered as string, found %v", string(bytes))
+	}
+}
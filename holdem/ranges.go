@@ -0,0 +1,454 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package holdem
+
+import (
+	"errors"
+	"fmt"
+	"github.com/amdw/gopoker/poker"
+	"math/rand"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// A specific two-card holding, with the higher-ranked card first (or for a pocket pair, the lower suit first)
+type Combo [2]poker.Card
+
+// Make a combo from two distinct cards, putting them into canonical order
+func NewCombo(c1, c2 poker.Card) Combo {
+	if c1 == c2 {
+		panic(fmt.Sprintf("Combo cannot contain %v twice", c1))
+	}
+	if c2.Rank > c1.Rank || (c2.Rank == c1.Rank && c2.Suit < c1.Suit) {
+		c1, c2 = c2, c1
+	}
+	return Combo{c1, c2}
+}
+
+func (c Combo) Cards() []poker.Card {
+	return []poker.Card{c[0], c[1]}
+}
+
+func (c Combo) CardSet() poker.CardSet {
+	return poker.NewCardSet(c[0], c[1])
+}
+
+// Render in range notation, e.g. "AhTh"
+func (c Combo) String() string {
+	return rangeCardString(c[0]) + rangeCardString(c[1])
+}
+
+// Combos are rendered as strings in JSON, e.g. "AhTh"
+func (c Combo) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+func rangeRankString(r poker.Rank) string {
+	if r == poker.Ten {
+		return "T"
+	}
+	return r.String()
+}
+
+func rangeCardString(c poker.Card) string {
+	return rangeRankString(c.Rank) + strings.ToLower(c.Suit.String())
+}
+
+// Whether c1 comes before c2 in the canonical order: by rank of each card, highest first, then by suit
+func comboLess(c1, c2 Combo) bool {
+	for i := range c1 {
+		if c1[i].Rank != c2[i].Rank {
+			return c1[i].Rank > c2[i].Rank
+		}
+	}
+	for i := range c1 {
+		if c1[i].Suit != c2[i].Suit {
+			return c1[i].Suit < c2[i].Suit
+		}
+	}
+	return false
+}
+
+// The combos making up a starting pair: six for a pocket pair, four if suited, or twelve if offsuit
+func (pair StartingPair) Combos() []Combo {
+	err := pair.Validate()
+	if err != nil {
+		panic(err)
+	}
+	result := make([]Combo, 0, 12)
+	for s1 := poker.Heart; s1 <= poker.Club; s1++ {
+		for s2 := poker.Heart; s2 <= poker.Club; s2++ {
+			if pair.Rank1 == pair.Rank2 && s2 <= s1 {
+				continue
+			}
+			if pair.Rank1 != pair.Rank2 && (s1 == s2) != pair.SameSuit {
+				continue
+			}
+			result = append(result, NewCombo(poker.Card{Rank: pair.Rank1, Suit: s1}, poker.Card{Rank: pair.Rank2, Suit: s2}))
+		}
+	}
+	return result
+}
+
+// A weighted set of combos, such as an opponent might be thought to hold. Each weight lies in (0, 1], and is the
+// likelihood of the combo relative to one included at full weight. Combos not in the map have weight zero.
+type Range map[Combo]float64
+
+var specificComboRegexp = regexp.MustCompile("^(10|[2-9TJQKA])([HDSC])(10|[2-9TJQKA])([HDSC])$")
+var handClassRegexp = regexp.MustCompile(`^(10|[2-9TJQKA])(10|[2-9TJQKA])([SO]?)(\+?)$`)
+
+// Parse a range in the standard notation, a comma-separated list of items such as "QQ+, AKs, A2s-A5s, KQo, 76s@50%, AhKh".
+// Items are pocket pairs ("QQ"), suited or offsuit hands ("AKs", "KQo") or both ("AK"), or specific combos ("AhKh").
+// A "+" suffix includes the higher pairs, or the higher kickers up to one below the top card ("A2s+" is A2s to AKs).
+// A dash gives an inclusive span of pairs ("22-55") or kickers ("A2s-A5s"). A weight such as "@50%" can follow any item.
+func ParseRange(s string) (Range, error) {
+	result := Range{}
+	for _, item := range strings.Split(strings.Replace(s, " ", "", -1), ",") {
+		if item == "" {
+			continue
+		}
+		spec, weight := item, 1.0
+		if at := strings.Index(item, "@"); at >= 0 {
+			var err error
+			spec = item[:at]
+			weight, err = parseRangeWeight(item[at+1:])
+			if err != nil {
+				return nil, errors.New(fmt.Sprintf("Bad weight in range item %q: %v", item, err))
+			}
+		}
+		combos, err := parseRangeItem(strings.ToUpper(spec))
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("Could not parse range item %q: %v", item, err))
+		}
+		for _, combo := range combos {
+			result[combo] = weight
+		}
+	}
+	return result, nil
+}
+
+// Parse a weight given as a percentage, with or without a trailing % sign
+func parseRangeWeight(s string) (float64, error) {
+	pct, err := strconv.ParseFloat(strings.TrimSuffix(s, "%"), 64)
+	if err != nil {
+		return 0, err
+	}
+	if pct <= 0 || pct > 100 {
+		return 0, errors.New(fmt.Sprintf("Weight must be above 0%% and at most 100%%, found %v%%", pct))
+	}
+	return pct / 100.0, nil
+}
+
+func parseRangeItem(item string) ([]Combo, error) {
+	if match := specificComboRegexp.FindStringSubmatch(item); match != nil {
+		c1, err := poker.MakeCard(match[1] + match[2])
+		if err != nil {
+			return nil, err
+		}
+		c2, err := poker.MakeCard(match[3] + match[4])
+		if err != nil {
+			return nil, err
+		}
+		if c1 == c2 {
+			return nil, errors.New(fmt.Sprintf("Duplicate card %v", c1))
+		}
+		return []Combo{NewCombo(c1, c2)}, nil
+	}
+
+	if parts := strings.Split(item, "-"); len(parts) == 2 {
+		return parseRangeSpan(parts[0], parts[1])
+	}
+
+	class, err := parseRangeClass(item)
+	if err != nil {
+		return nil, err
+	}
+	if !class.plus {
+		return class.combos()
+	}
+	top := poker.Ace
+	if class.high != class.low {
+		top = class.high - 1
+	}
+	return class.withKickers(class.low, top)
+}
+
+// A group of starting hands in range notation, such as "AKs" or "QQ+"
+type rangeClass struct {
+	high, low  poker.Rank
+	suitedness string // "S" for suited, "O" for offsuit or empty for both
+	plus       bool
+}
+
+func parseRangeClass(item string) (rangeClass, error) {
+	match := handClassRegexp.FindStringSubmatch(item)
+	if match == nil {
+		return rangeClass{}, errors.New("Unrecognised hand")
+	}
+	r1, err := poker.MakeRank(match[1])
+	if err != nil {
+		return rangeClass{}, err
+	}
+	r2, err := poker.MakeRank(match[2])
+	if err != nil {
+		return rangeClass{}, err
+	}
+	if r2 > r1 {
+		r1, r2 = r2, r1
+	}
+	return rangeClass{r1, r2, match[3], match[4] == "+"}, nil
+}
+
+func (class rangeClass) combos() ([]Combo, error) {
+	var suitedOptions []bool
+	switch {
+	case class.suitedness == "S":
+		suitedOptions = []bool{true}
+	case class.suitedness == "O" || class.high == class.low:
+		suitedOptions = []bool{false}
+	default:
+		suitedOptions = []bool{true, false}
+	}
+	result := []Combo{}
+	for _, suited := range suitedOptions {
+		pair := StartingPair{Rank1: class.high, Rank2: class.low, SameSuit: suited}
+		if err := pair.Validate(); err != nil {
+			return nil, err
+		}
+		result = append(result, pair.Combos()...)
+	}
+	return result, nil
+}
+
+// The combos of this class, but with the lower card (both cards, for a pair) varying from lowest to highest inclusive
+func (class rangeClass) withKickers(lowest, highest poker.Rank) ([]Combo, error) {
+	result := []Combo{}
+	for r := lowest; r <= highest; r++ {
+		other := class
+		other.low = r
+		if class.high == class.low {
+			other.high = r
+		}
+		combos, err := other.combos()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, combos...)
+	}
+	return result, nil
+}
+
+func parseRangeSpan(from, to string) ([]Combo, error) {
+	c1, err := parseRangeClass(from)
+	if err != nil {
+		return nil, err
+	}
+	c2, err := parseRangeClass(to)
+	if err != nil {
+		return nil, err
+	}
+	if c1.plus || c2.plus {
+		return nil, errors.New("Cannot combine + with a span")
+	}
+	isPair := func(c rangeClass) bool { return c.high == c.low }
+	if isPair(c1) != isPair(c2) || (!isPair(c1) && c1.high != c2.high) {
+		return nil, errors.New("A span must be between two pairs, or two hands with the same top card")
+	}
+	if c1.suitedness != c2.suitedness {
+		return nil, errors.New("Both ends of a span must have the same suitedness")
+	}
+	lowest, highest := c1.low, c2.low
+	if lowest > highest {
+		lowest, highest = highest, lowest
+	}
+	return c1.withKickers(lowest, highest)
+}
+
+// The number of combos in the range, regardless of weight
+func (r Range) ComboCount() int {
+	return len(r)
+}
+
+// The total weight of all combos in the range; the effective number of combos it contains
+func (r Range) TotalWeight() float64 {
+	total := 0.0
+	for _, weight := range r {
+		total += weight
+	}
+	return total
+}
+
+// The combos in the range, in canonical order
+func (r Range) Combos() []Combo {
+	result := make([]Combo, 0, len(r))
+	for combo := range r {
+		result = append(result, combo)
+	}
+	sort.Slice(result, func(i, j int) bool { return comboLess(result[i], result[j]) })
+	return result
+}
+
+// A copy of the range without any combos containing the dead cards, e.g. because they are on the board or in our hand
+func (r Range) RemoveBlocked(dead poker.CardSet) Range {
+	result := make(Range, len(r))
+	for combo, weight := range r {
+		if !combo.CardSet().Intersects(dead) {
+			result[combo] = weight
+		}
+	}
+	return result
+}
+
+// Render the range in compact notation, which ParseRange will accept
+func (r Range) String() string {
+	weights := []float64{}
+	byWeight := make(map[float64]map[Combo]bool)
+	for combo, weight := range r {
+		if byWeight[weight] == nil {
+			byWeight[weight] = make(map[Combo]bool)
+			weights = append(weights, weight)
+		}
+		byWeight[weight][combo] = true
+	}
+	sort.Sort(sort.Reverse(sort.Float64Slice(weights)))
+
+	items := []string{}
+	for _, weight := range weights {
+		suffix := ""
+		if weight < 1 {
+			suffix = "@" + strconv.FormatFloat(100*weight, 'g', -1, 64) + "%"
+		}
+		for _, item := range compactRangeItems(byWeight[weight]) {
+			items = append(items, item+suffix)
+		}
+	}
+	return strings.Join(items, ", ")
+}
+
+// Describe a set of combos in as few range items as possible, using spans and "+" where they are complete
+func compactRangeItems(combos map[Combo]bool) []string {
+	covered := make(map[Combo]bool)
+	complete := func(pair StartingPair) bool {
+		for _, combo := range pair.Combos() {
+			if !combos[combo] {
+				return false
+			}
+		}
+		return true
+	}
+	cover := func(pair StartingPair) {
+		for _, combo := range pair.Combos() {
+			covered[combo] = true
+		}
+	}
+	items := []string{}
+
+	// Pocket pairs, in runs from aces down
+	for high := poker.Ace; high >= poker.Two; high-- {
+		if !complete(StartingPair{Rank1: high, Rank2: high}) {
+			continue
+		}
+		low := high
+		for low > poker.Two && complete(StartingPair{Rank1: low - 1, Rank2: low - 1}) {
+			low--
+		}
+		for r := low; r <= high; r++ {
+			cover(StartingPair{Rank1: r, Rank2: r})
+		}
+		pairName := func(r poker.Rank) string { return rangeRankString(r) + rangeRankString(r) }
+		switch {
+		case low == high:
+			items = append(items, pairName(high))
+		case high == poker.Ace:
+			items = append(items, pairName(low)+"+")
+		default:
+			items = append(items, pairName(high)+"-"+pairName(low))
+		}
+		high = low
+	}
+
+	// Suited then offsuit hands, in runs of kickers for each top card
+	for _, suited := range []bool{true, false} {
+		suffix := "o"
+		if suited {
+			suffix = "s"
+		}
+		for high := poker.Ace; high > poker.Two; high-- {
+			name := func(kicker poker.Rank) string { return rangeRankString(high) + rangeRankString(kicker) + suffix }
+			for top := high - 1; top >= poker.Two; top-- {
+				if !complete(StartingPair{Rank1: high, Rank2: top, SameSuit: suited}) {
+					continue
+				}
+				low := top
+				for low > poker.Two && complete(StartingPair{Rank1: high, Rank2: low - 1, SameSuit: suited}) {
+					low--
+				}
+				for r := low; r <= top; r++ {
+					cover(StartingPair{Rank1: high, Rank2: r, SameSuit: suited})
+				}
+				switch {
+				case low == top:
+					items = append(items, name(top))
+				case top == high-1:
+					items = append(items, name(low)+"+")
+				default:
+					items = append(items, name(top)+"-"+name(low))
+				}
+				top = low
+			}
+		}
+	}
+
+	// Anything left over is listed combo by combo
+	remaining := Range{}
+	for combo := range combos {
+		if !covered[combo] {
+			remaining[combo] = 1
+		}
+	}
+	for _, combo := range remaining.Combos() {
+		items = append(items, combo.String())
+	}
+	return items
+}
+
+// Draws combos from a range in proportion to their weights, leaving out any blocked by known cards
+type rangeSampler struct {
+	combos     []Combo
+	cumulative []float64 // Running totals of the combos' weights
+}
+
+func newRangeSampler(r Range, dead poker.CardSet) *rangeSampler {
+	combos := r.RemoveBlocked(dead).Combos()
+	cumulative := make([]float64, len(combos))
+	total := 0.0
+	for i, combo := range combos {
+		total += r[combo]
+		cumulative[i] = total
+	}
+	return &rangeSampler{combos, cumulative}
+}
+
+func (s *rangeSampler) sample(randGen *rand.Rand) Combo {
+	total := s.cumulative[len(s.cumulative)-1]
+	return s.combos[sort.SearchFloat64s(s.cumulative, randGen.Float64()*total)]
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package holdem
+
+import (
+	"github.com/amdw/gopoker/poker"
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestStartingPairCombos(t *testing.T) {
+	cases := []struct {
+		pair     StartingPair
+		expected int
+	}{
+		{sp("A", "A", false), 6},
+		{sp("A", "K", true), 4},
+		{sp("A", "K", false), 12},
+		{sp("2", "7", false), 12},
+	}
+	for _, c := range cases {
+		combos := c.pair.Combos()
+		if len(combos) != c.expected {
+			t.Errorf("Expected %v combos for %+v, found %v", c.expected, c.pair, len(combos))
+		}
+		seen := make(map[Combo]bool)
+		for _, combo := range combos {
+			if seen[combo] || combo[0] == combo[1] || NewCombo(combo[1], combo[0]) != combo {
+				t.Errorf("Bad or repeated combo %v for %+v", combo, c.pair)
+			}
+			seen[combo] = true
+			if (combo[0].Suit == combo[1].Suit) != c.pair.SameSuit {
+				t.Errorf("Combo %v has wrong suitedness for %+v", combo, c.pair)
+			}
+		}
+	}
+}
+
+func TestParseRange(t *testing.T) {
+	cases := []struct {
+		spec   string
+		combos int
+		weight float64
+	}{
+		{"", 0, 0},
+		{"AA", 6, 6},
+		{"QQ+", 18, 18},
+		{"22+", 78, 78},
+		{"22-55", 24, 24},
+		{"55-22", 24, 24},
+		{"AKs", 4, 4},
+		{"AKo", 12, 12},
+		{"AK", 16, 16},
+		{"KA", 16, 16},
+		{"A2s-A5s", 16, 16},
+		{"A2s+", 48, 48},
+		{"K9+", 64, 64},
+		{"KQo", 12, 12},
+		{"76s@50%", 4, 2},
+		{"76s@50", 4, 2},
+		{"AhKh", 1, 1},
+		{"ahkh", 1, 1},
+		{"ThTs", 1, 1},
+		{"TT, 10h9h", 7, 7},
+		{"QQ+, AKs, A2s-A5s, KQo, 76s@50%", 18 + 4 + 16 + 12 + 4, 18 + 4 + 16 + 12 + 2},
+		// Later items override earlier ones
+		{"AKs, AhKh@25%", 4, 3.25},
+	}
+	for _, c := range cases {
+		r, err := ParseRange(c.spec)
+		if err != nil {
+			t.Errorf("Unexpected error parsing %q: %v", c.spec, err)
+			continue
+		}
+		if r.ComboCount() != c.combos || r.TotalWeight() != c.weight {
+			t.Errorf("Expected %v combos with weight %v for %q, found %v with weight %v", c.combos, c.weight, c.spec, r.ComboCount(), r.TotalWeight())
+		}
+	}
+
+	r, _ := ParseRange("AhKh")
+	if r[NewCombo(poker.C("KH"), poker.C("AH"))] != 1 {
+		t.Errorf("Expected AhKh in range, found %v", r)
+	}
+}
+
+func TestParseRangeErrors(t *testing.T) {
+	cases := map[string]string{
+		"AKx":       "Could not parse range item",
+		"AAs":       "cannot be the same suit",
+		"AhAh":      "Duplicate card",
+		"A2s-K5s":   "same top card",
+		"22-A5s":    "same top card",
+		"A2s-A5o":   "same suitedness",
+		"22+-55":    "Could not parse",
+		"AKs@0%":    "Weight must be above 0%",
+		"AKs@150%":  "at most 100%",
+		"AKs@lots":  "Bad weight",
+		"QQ+, 1Q":   "Could not parse range item \"1Q\"",
+		"QQ+,AK,ZZ": "Could not parse range item \"ZZ\"",
+	}
+	for spec, expected := range cases {
+		_, err := ParseRange(spec)
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected error containing %q for %q, found %v", expected, spec, err)
+		}
+	}
+}
+
+func TestFormatRange(t *testing.T) {
+	cases := map[string]string{
+		"":                                "",
+		"QQ+, AKs, A2s-A5s, KQo, 76s@50%": "QQ+, AKs, A5s-A2s, KQo, 76s@50%",
+		"22+":                             "22+",
+		"55-22, TT":                       "TT, 55-22",
+		"A2s+, AKo":                       "A2s+, AKo",
+		"AK":                              "AKs, AKo",
+		"K9+":                             "K9s+, K9o+",
+		"AhKh, AsKs":                      "AhKh, AsKs",
+		"AKs, AhKh@25%":                   "AdKd, AsKs, AcKc, AhKh@25%",
+		"JJ@33.5%, 99@33.5%, TT@33.5%":    "JJ-99@33.5%",
+	}
+	for spec, expected := range cases {
+		r, err := ParseRange(spec)
+		if err != nil {
+			t.Errorf("Unexpected error parsing %q: %v", spec, err)
+			continue
+		}
+		if r.String() != expected {
+			t.Errorf("Expected %q to be formatted as %q, found %q", spec, expected, r.String())
+		}
+		// Formatting should round-trip
+		reparsed, err := ParseRange(r.String())
+		if err != nil || len(reparsed) != len(r) {
+			t.Errorf("Could not round-trip %q via %q: %v", spec, r.String(), err)
+			continue
+		}
+		for combo, weight := range r {
+			if reparsed[combo] != weight {
+				t.Errorf("Weight of %v changed from %v to %v in round trip of %q", combo, weight, reparsed[combo], spec)
+			}
+		}
+	}
+}
+
+func TestRemoveBlocked(t *testing.T) {
+	r, _ := ParseRange("AA, AKs, KQo")
+	dead := poker.NewCardSet(h("AS", "QD")...)
+	remaining := r.RemoveBlocked(dead)
+	// 3 AA combos without the ace of spades, 3 AKs, 12 - 3 - 3 + 0 = 9 KQo (no queen of diamonds, and offsuit)
+	if remaining.ComboCount() != 3+3+9 {
+		t.Errorf("Expected 15 unblocked combos, found %v: %v", remaining.ComboCount(), remaining)
+	}
+	for _, combo := range remaining.Combos() {
+		if combo.CardSet().Intersects(dead) {
+			t.Errorf("Blocked combo %v not removed", combo)
+		}
+	}
+	if r.ComboCount() != 6+4+12 {
+		t.Errorf("Original range should be unchanged, found %v combos", r.ComboCount())
+	}
+}
+
+func TestRangeSampler(t *testing.T) {
+	r, _ := ParseRange("AhKh, AsKs@25%, AdKd")
+	sampler := newRangeSampler(r, poker.NewCardSet(poker.C("KD")))
+	if len(sampler.combos) != 2 {
+		t.Fatalf("Expected blocked combo to be removed, found %v", sampler.combos)
+	}
+	randGen := rand.New(rand.NewSource(1234))
+	counts := make(map[Combo]int)
+	samples := 100000
+	for i := 0; i < samples; i++ {
+		counts[sampler.sample(randGen)]++
+	}
+	if freq := float64(counts[NewCombo(poker.C("AH"), poker.C("KH"))]) / float64(samples); math.Abs(freq-0.8) > 0.01 {
+		t.Errorf("Expected AhKh 80%% of the time, found %v", freq)
+	}
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package holdem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/amdw/gopoker/poker"
+	"math/rand"
+)
+
+// The most players who can be dealt in from a short pack
+const ShortDeckMaxPlayers = (36 - 5) / 2
+
+// Check that the known cards all come from a short pack, and that there are few enough players to deal to
+func ValidateShortDeck(tableCards, yourCards []poker.Card, players int) error {
+	for _, cards := range [][]poker.Card{tableCards, yourCards} {
+		for _, c := range cards {
+			if !poker.ShortDeckCards.Contains(c) {
+				return errors.New(fmt.Sprintf("Card %v is not in a short pack", c))
+			}
+		}
+	}
+	if players > ShortDeckMaxPlayers {
+		return errors.New(fmt.Sprintf("At most %v players can be dealt in from a short pack, found %v", ShortDeckMaxPlayers, players))
+	}
+	return nil
+}
+
+// As DealOutcomes, but for short-deck Hold'em under the given rules
+func ShortDeckOutcomes(rules poker.ShortDeckRules, onTable []poker.Card, playerCards [][]poker.Card) []PlayerOutcome {
+	return shortDeckOutcomes(rules, onTable, playerCards, true)
+}
+
+func shortDeckOutcomes(rules poker.ShortDeckRules, onTable []poker.Card, playerCards [][]poker.Card, wantCards bool) []PlayerOutcome {
+	return rankedOutcomes(rules.BestHand, rules.Beats, onTable, playerCards, wantCards)
+}
+
+// As SimulateHoldem, but for short-deck Hold'em under the given rules
+func SimulateShortDeck(rules poker.ShortDeckRules, tableCards, yourCards []poker.Card, players, handsToPlay int, randGen *rand.Rand) *poker.Simulator {
+	sim, _ := poker.Simulate(context.Background(), rules.Ranking(), players, handsToPlay, holdemDealer(tableCards, yourCards, players, nil, &rules)(), randGen, nil)
+	return sim
+}
+
+// As SimulateHoldemParallelContext, but for short-deck Hold'em under the given rules
+func SimulateShortDeckParallelContext(ctx context.Context, rules poker.ShortDeckRules, tableCards, yourCards []poker.Card, players, handsToPlay, workers int, randGen *rand.Rand, progress poker.ProgressFunc) (*poker.Simulator, error) {
+	return poker.SimulateParallel(ctx, rules.Ranking(), players, handsToPlay, workers, holdemDealer(tableCards, yourCards, players, nil, &rules), randGen, progress)
+}
+
+// As SimulateHoldemAdaptive, but for short-deck Hold'em under the given rules
+func SimulateShortDeckAdaptive(ctx context.Context, rules poker.ShortDeckRules, tableCards, yourCards []poker.Card, players int, targetHalfWidth float64, maxHands, workers int, randGen *rand.Rand, progress poker.ProgressFunc) (*poker.Simulator, error) {
+	return poker.SimulateAdaptive(ctx, rules.Ranking(), players, targetHalfWidth, maxHands, workers, holdemDealer(tableCards, yourCards, players, nil, &rules), randGen, progress)
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package holdem
+
+import (
+	"context"
+	"github.com/amdw/gopoker/poker"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestShortDeckOutcomes(t *testing.T) {
+	standard, tripsBeatStraight := poker.ShortDeckRules{}, poker.ShortDeckRules{TripsBeatStraight: true}
+	testCases := []struct {
+		rules            poker.ShortDeckRules
+		tableCards       []poker.Card
+		playerCards      [][]poker.Card
+		expectedOutcomes []PlayerOutcome
+	}{
+		// The flush beats the full house
+		{standard, h("KH", "KD", "9H", "7H", "AS"), hs(h("AH", "6H"), h("AD", "AC")),
+			pos(po(hl("Flush", "A", "K", "9", "7", "6"), h("AH", "KH", "9H", "7H", "6H"), true, 1.0),
+				po(hl("FullHouse", "A", "K"), h("AD", "AC", "AS", "KH", "KD"), false, 0))},
+		// A-6-7-8-9 is a straight, beating trips unless the rules say otherwise
+		{standard, h("6C", "7D", "8S", "QH", "QD"), hs(h("AH", "9D"), h("QS", "10C")),
+			pos(po(hl("Straight", "9"), h("AH", "6C", "7D", "8S", "9D"), true, 1.0),
+				po(hl("ThreeOfAKind", "Q", "10", "8"), h("QS", "QH", "QD", "10C", "8S"), false, 0))},
+		{tripsBeatStraight, h("6C", "7D", "8S", "QH", "QD"), hs(h("AH", "9D"), h("QS", "10C")),
+			pos(po(hl("Straight", "9"), h("AH", "6C", "7D", "8S", "9D"), false, 0),
+				po(hl("ThreeOfAKind", "Q", "10", "8"), h("QS", "QH", "QD", "10C", "8S"), true, 1.0))},
+		// Both players play the board
+		{standard, h("AS", "KS", "QS", "JS", "10S"), hs(h("6C", "7C"), h("8C", "9C")),
+			pos(po(hl("StraightFlush", "A"), h("AS", "KS", "QS", "JS", "10S"), true, 0.5),
+				po(hl("StraightFlush", "A"), h("AS", "KS", "QS", "JS", "10S"), true, 0.5))},
+	}
+	for _, tc := range testCases {
+		outcomes := ShortDeckOutcomes(tc.rules, tc.tableCards, tc.playerCards)
+		for i, outcome := range outcomes {
+			expected := tc.expectedOutcomes[i]
+			if outcome.Player != expected.Player || !levelsEqual(outcome.Level, expected.Level) || outcome.Won != expected.Won || outcome.PotFractionWon != expected.PotFractionWon || !poker.CardsEqual(outcome.Cards, expected.Cards) {
+				t.Errorf("Expected %+v for %v and %v, found %+v", expected, tc.tableCards, tc.playerCards, outcome)
+			}
+		}
+	}
+}
+
+func TestValidateShortDeck(t *testing.T) {
+	if err := ValidateShortDeck(h("6C", "7D", "AS"), h("KD", "10H"), ShortDeckMaxPlayers); err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+	if err := ValidateShortDeck(h("6C", "7D", "5S"), h("KD", "10H"), 2); err == nil {
+		t.Errorf("Expected error for a five on the table")
+	}
+	if err := ValidateShortDeck([]poker.Card{}, h("2D"), 2); err == nil {
+		t.Errorf("Expected error for a two in your hand")
+	}
+	if err := ValidateShortDeck([]poker.Card{}, []poker.Card{}, ShortDeckMaxPlayers+1); err == nil {
+		t.Errorf("Expected error for too many players")
+	}
+}
+
+func TestShortDeckSimSanity(t *testing.T) {
+	players := 6
+	simulations := 10000
+	for _, rules := range []poker.ShortDeckRules{{}, {TripsBeatStraight: true}} {
+		randGen := rand.New(rand.NewSource(1234))
+		sim := SimulateShortDeck(rules, []poker.Card{}, h("AS", "KD"), players, simulations, randGen)
+		if sim.Ranking != rules.Ranking() {
+			t.Errorf("Expected ranking %v, found %v", rules.Ranking(), sim.Ranking)
+		}
+		poker.TestAssertSimSanity(sim, players, simulations, t)
+
+		sim, err := SimulateShortDeckParallelContext(context.Background(), rules, h("6C", "7D", "8S"), h("AS", "KD"), ShortDeckMaxPlayers, simulations, 4, randGen, nil)
+		if err != nil {
+			t.Errorf("Unexpected error %v", err)
+		}
+		poker.TestAssertSimSanity(sim, ShortDeckMaxPlayers, simulations, t)
+	}
+}
+
+func TestShortDeckReproducibility(t *testing.T) {
+	rules := poker.ShortDeckRules{}
+	expected, _ := SimulateShortDeckParallelContext(context.Background(), rules, h("JH"), h("QS", "QD"), 3, 5000, 1, rand.New(rand.NewSource(1234)), nil)
+	for _, workers := range []int{2, 5} {
+		sim, _ := SimulateShortDeckParallelContext(context.Background(), rules, h("JH"), h("QS", "QD"), 3, 5000, workers, rand.New(rand.NewSource(1234)), nil)
+		if !reflect.DeepEqual(expected, sim) {
+			t.Errorf("Expected identical results with %v workers", workers)
+		}
+	}
+}
+
+func TestShortDeckAdaptive(t *testing.T) {
+	target := 0.01
+	maxHands := 1000000
+	rules := poker.ShortDeckRules{TripsBeatStraight: true}
+	sim, err := SimulateShortDeckAdaptive(context.Background(), rules, []poker.Card{}, h("AS", "AD"), 2, target, maxHands, 0, rand.New(rand.NewSource(1234)), nil)
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+	if sim.Ranking != rules.Ranking() {
+		t.Errorf("Expected ranking %v, found %v", rules.Ranking(), sim.Ranking)
+	}
+	poker.TestAssertSimSanity(sim, 2, sim.HandCount, t)
+	equity := sim.Equity()
+	if equity.HalfWidth(poker.Z95) > target || sim.HandCount >= maxHands {
+		t.Errorf("Expected to stop once half-width was below %v, found %v after %v hands", target, equity.HalfWidth(poker.Z95), sim.HandCount)
+	}
+	// Aces are a smaller favourite against a random hand from a short pack than from a full one
+	if equity.Mean > 0.852 || equity.Mean < 0.7 {
+		t.Errorf("Expected equity between 0.7 and 0.852, found %+v", equity)
+	}
+}
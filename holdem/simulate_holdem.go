@@ -20,115 +20,178 @@ along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
 package holdem
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/amdw/gopoker/poker"
 	"math/rand"
-	"time"
 )
 
-func SimulateHoldem(tableCards, yourCards []poker.Card, players, handsToPlay int) *poker.Simulator {
-	s := poker.Simulator{}
-	// Very crude attempt to detect situation where exhaustive enumeration is cheaper than simulation
-	if len(tableCards) == 5 && len(yourCards) == 2 && players == 2 && handsToPlay > 990 {
-		enumerateHoldem(&s, tableCards, yourCards, players)
-		return &s
+// Simulate a number of hands of Texas Hold'em, in which the given table cards and your cards are known.
+// All randomness comes from randGen, so the results are reproducible given its seed.
+func SimulateHoldem(tableCards, yourCards []poker.Card, players, handsToPlay int, randGen *rand.Rand) *poker.Simulator {
+	sim, _ := SimulateHoldemContext(context.Background(), tableCards, yourCards, players, handsToPlay, randGen, nil)
+	return sim
+}
+
+// As SimulateHoldem, but stop early if ctx is cancelled, and send periodic progress reports to progress (if non-nil).
+// If the simulation is cut short, the results of the hands played so far are returned, along with ctx.Err().
+func SimulateHoldemContext(ctx context.Context, tableCards, yourCards []poker.Card, players, handsToPlay int, randGen *rand.Rand, progress poker.ProgressFunc) (*poker.Simulator, error) {
+	if shouldEnumerate(tableCards, yourCards, players, handsToPlay) {
+		return EnumerateHoldem(ctx, tableCards, yourCards, players, 1, progress)
 	}
-	s.Reset(players, handsToPlay)
-	p := poker.NewPack()
-	randGen := rand.New(rand.NewSource(time.Now().UnixNano()))
-	for i := 0; i < handsToPlay; i++ {
-		shuffleFixing(&p, tableCards, yourCards, randGen)
-		handOutcome := SimulateOneHoldemHand(&p, players, randGen)
-		s.ProcessHand(handOutcome)
+	return poker.Simulate(ctx, poker.HighRanking, players, handsToPlay, holdemDealer(tableCards, yourCards, players, nil, nil)(), randGen, progress)
+}
+
+// As SimulateHoldem, but split the hands between several goroutines and merge the results.
+// If workers is not positive, one worker per CPU is used. The results do not depend on the number of workers.
+func SimulateHoldemParallel(tableCards, yourCards []poker.Card, players, handsToPlay, workers int, randGen *rand.Rand) *poker.Simulator {
+	sim, _ := SimulateHoldemParallelContext(context.Background(), tableCards, yourCards, players, handsToPlay, workers, randGen, nil)
+	return sim
+}
+
+// As SimulateHoldemParallel, but with cancellation and progress reporting as for SimulateHoldemContext.
+func SimulateHoldemParallelContext(ctx context.Context, tableCards, yourCards []poker.Card, players, handsToPlay, workers int, randGen *rand.Rand, progress poker.ProgressFunc) (*poker.Simulator, error) {
+	if shouldEnumerate(tableCards, yourCards, players, handsToPlay) {
+		return EnumerateHoldem(ctx, tableCards, yourCards, players, workers, progress)
 	}
-	return &s
+	return poker.SimulateParallel(ctx, poker.HighRanking, players, handsToPlay, workers, holdemDealer(tableCards, yourCards, players, nil, nil), randGen, progress)
 }
 
-func enumerateHoldem(s *poker.Simulator, tableCards, yourCards []poker.Card, players int) {
-	s.Reset(players, 0)
-	randGen := rand.New(rand.NewSource(time.Now().UnixNano()))
-	// For now we only enumerate the case where we have only one opponent and a full set of table cards.
-	remainingPack := make([]poker.Card, 45)
-	i := 0
-	isUsed := func(card poker.Card) bool {
-		for _, c := range tableCards {
-			if c == card {
-				return true
-			}
-		}
-		for _, c := range yourCards {
-			if c == card {
-				return true
-			}
-		}
-		return false
+// As SimulateHoldemParallelContext, but each opponent's hole cards are drawn from the corresponding entry of
+// opponentRanges in proportion to the combos' weights, consistently with the known cards and with each other.
+// A nil range means that opponent is dealt random cards. There is one more player than there are ranges.
+// An error is returned without simulating if a range has no combos left once the known cards are removed.
+func SimulateHoldemRanges(ctx context.Context, tableCards, yourCards []poker.Card, opponentRanges []Range, handsToPlay, workers int, randGen *rand.Rand, progress poker.ProgressFunc) (*poker.Simulator, error) {
+	players := len(opponentRanges) + 1
+	samplers, err := newOpponentSamplers(tableCards, yourCards, opponentRanges)
+	if err != nil {
+		return nil, err
 	}
-	for _, card := range poker.NewPack().Cards {
-		if !isUsed(card) {
-			remainingPack[i] = card
-			i++
-		}
+	if samplers == nil {
+		return SimulateHoldemParallelContext(ctx, tableCards, yourCards, players, handsToPlay, workers, randGen, progress)
 	}
-	opponentHands := poker.AllCardCombinations(remainingPack, 2)
-	for _, opponentHand := range opponentHands {
-		playerCards := [][]poker.Card{yourCards, opponentHand}
-		outcomes := DealOutcomes(tableCards, playerCards)
-		handOutcome := calcHandOutcome(outcomes, randGen)
-		s.ProcessHand(handOutcome)
-		s.HandCount++
+	return poker.SimulateParallel(ctx, poker.HighRanking, players, handsToPlay, workers, holdemDealer(tableCards, yourCards, players, samplers, nil), randGen, progress)
+}
+
+// Simulate Hold'em hands in batches until the 95% confidence interval for our pot equity has a half-width of at most
+// targetHalfWidth (e.g. 0.005 for plus or minus half a percent), or maxHands hands have been played, whichever is first.
+// Batches are run as for SimulateHoldemParallelContext, so the results depend only on the seed of randGen.
+func SimulateHoldemAdaptive(ctx context.Context, tableCards, yourCards []poker.Card, players int, targetHalfWidth float64, maxHands, workers int, randGen *rand.Rand, progress poker.ProgressFunc) (*poker.Simulator, error) {
+	if shouldEnumerate(tableCards, yourCards, players, maxHands) {
+		return EnumerateHoldem(ctx, tableCards, yourCards, players, workers, progress)
 	}
+	return poker.SimulateAdaptive(ctx, poker.HighRanking, players, targetHalfWidth, maxHands, workers, holdemDealer(tableCards, yourCards, players, nil, nil), randGen, progress)
 }
 
-func calcHandOutcome(outcomes []PlayerOutcome, randGen *rand.Rand) *poker.HandOutcome {
-	if len(outcomes) < 2 {
-		panic(fmt.Sprintf("Expected at least two players, found %v", len(outcomes)))
+// As SimulateHoldemAdaptive, but with opponent ranges as for SimulateHoldemRanges
+func SimulateHoldemRangesAdaptive(ctx context.Context, tableCards, yourCards []poker.Card, opponentRanges []Range, targetHalfWidth float64, maxHands, workers int, randGen *rand.Rand, progress poker.ProgressFunc) (*poker.Simulator, error) {
+	players := len(opponentRanges) + 1
+	samplers, err := newOpponentSamplers(tableCards, yourCards, opponentRanges)
+	if err != nil {
+		return nil, err
 	}
-	ourOutcome := outcomes[0]
-	if ourOutcome.Player != 1 {
-		panic(fmt.Sprintf("Expected player 1 outcome first, found %v", ourOutcome.Player))
+	if samplers == nil {
+		return SimulateHoldemAdaptive(ctx, tableCards, yourCards, players, targetHalfWidth, maxHands, workers, randGen, progress)
 	}
+	return poker.SimulateAdaptive(ctx, poker.HighRanking, players, targetHalfWidth, maxHands, workers, holdemDealer(tableCards, yourCards, players, samplers, nil), randGen, progress)
+}
 
-	var bestOpponentOutcome PlayerOutcome
-	for i := 1; i < len(outcomes); i++ {
-		if i == 1 || poker.Beats(outcomes[i].Level, bestOpponentOutcome.Level) {
-			bestOpponentOutcome = outcomes[i]
+// Give up trying to deal opponents non-overlapping hands from their ranges after this many attempts
+const maxRangeDealAttempts = 10000
+
+// Samplers for each opponent's range (nil for opponents dealt random cards), or nil if no opponent has a range
+func newOpponentSamplers(tableCards, yourCards []poker.Card, opponentRanges []Range) ([]*rangeSampler, error) {
+	dead := poker.NewCardSet(tableCards...).Union(poker.NewCardSet(yourCards...))
+	samplers := make([]*rangeSampler, len(opponentRanges))
+	anyRanges := false
+	for i, r := range opponentRanges {
+		if r == nil {
+			continue
+		}
+		samplers[i] = newRangeSampler(r, dead)
+		if len(samplers[i].combos) == 0 {
+			return nil, errors.New(fmt.Sprintf("Range for opponent %v has no combos left once the known cards are removed", i+1))
 		}
+		anyRanges = true
 	}
+	if !anyRanges {
+		return nil, nil
+	}
+	return samplers, nil
+}
 
-	randomOpponentOutcome := outcomes[1+randGen.Intn(len(outcomes)-1)]
+// Deal each opponent who has a range a combo from it, such that no card is dealt twice, filling in opponentCards
+// (using combos for storage). Opponents without a range get nil. Whenever two hands clash, all the hands are dealt
+// again, so that every consistent deal has a probability proportional to the product of its weights.
+// Returns false if no consistent deal could be found.
+func dealFromRanges(samplers []*rangeSampler, dead poker.CardSet, randGen *rand.Rand, combos []Combo, opponentCards [][]poker.Card) bool {
+	for attempt := 0; attempt < maxRangeDealAttempts; attempt++ {
+		used := dead
+		ok := true
+		for i, sampler := range samplers {
+			if sampler == nil {
+				opponentCards[i] = nil
+				continue
+			}
+			combos[i] = sampler.sample(randGen)
+			if combos[i].CardSet().Intersects(used) {
+				ok = false
+				break
+			}
+			used = used.Union(combos[i].CardSet())
+			opponentCards[i] = combos[i][:]
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
 
-	return &poker.HandOutcome{ourOutcome.Won, bestOpponentOutcome.Won, randomOpponentOutcome.Won,
-		ourOutcome.PotFractionWon, bestOpponentOutcome.PotFractionWon, randomOpponentOutcome.PotFractionWon,
-		ourOutcome.Level, bestOpponentOutcome.Level, randomOpponentOutcome.Level}
+// Dealers for simulating Hold'em hands in which the given cards are known, drawing the opponents' cards from their
+// ranges if samplers is not nil, and playing short-deck Hold'em under the given rules if they are not nil
+func holdemDealer(tableCards, yourCards []poker.Card, players int, samplers []*rangeSampler, rules *poker.ShortDeckRules) func() poker.Dealer {
+	dead := poker.NewCardSet(tableCards...).Union(poker.NewCardSet(yourCards...))
+	return func() poker.Dealer {
+		p := poker.NewPack()
+		if rules != nil {
+			p = poker.NewShortPack()
+		}
+		fixing := layout.Fixing(tableCards, yourCards)
+		known := len(fixing.Cards)
+		var combos []Combo
+		var opponentCards [][]poker.Card
+		if samplers != nil {
+			combos = make([]Combo, len(samplers))
+			opponentCards = make([][]poker.Card, len(samplers))
+		}
+		return func(randGen *rand.Rand) ([]poker.GameOutcome, error) {
+			if samplers != nil {
+				if !dealFromRanges(samplers, dead, randGen, combos, opponentCards) {
+					return nil, errors.New("Could not deal the opponents non-overlapping hands from their ranges")
+				}
+				fixing.Truncate(known)
+				for opponent, cards := range opponentCards {
+					fixing.Place(layout.HoleCardsStart(opponent+1), cards...)
+				}
+			}
+			p.ShuffleFixed(randGen, &fixing)
+			onTable, playerCards := Deal(&p, players)
+			if rules == nil {
+				return gameOutcomes(dealOutcomes(onTable, playerCards, false)), nil
+			}
+			return gameOutcomes(shortDeckOutcomes(*rules, onTable, playerCards, false)), nil
+		}
+	}
 }
 
 // Play out one hand of Texas Hold'em and return whether or not player 1 won,
 // plus player 1's hand level, plus the best hand level of any of player 1's opponents.
 func SimulateOneHoldemHand(p *poker.Pack, players int, randGen *rand.Rand) *poker.HandOutcome {
 	onTable, playerCards := Deal(p, players)
-	outcomes := DealOutcomes(onTable, playerCards)
-	return calcHandOutcome(outcomes, randGen)
-}
-
-// Shuffle the pack, but fix certain cards in place. For use in simulations.
-// It is assumed that there are no duplicate cards in (tableCards+yourCards).
-func shuffleFixing(p *poker.Pack, tableCards, yourCards []poker.Card, randGen *rand.Rand) {
-	if len(tableCards) > 5 || len(yourCards) > 2 {
-		panic(fmt.Sprintf("Maximum of 5 table cards and 2 hole cards supported, found %v and %v", len(tableCards), len(yourCards)))
-	}
-
-	// Just shuffle the pack and then swap the fixed cards into place from wherever they are in the deck
-	p.Shuffle(randGen)
-	for i := 0; i < len(tableCards); i++ {
-		swapIdx := p.IndexOf(tableCards[i])
-		p.Cards[i], p.Cards[swapIdx] = p.Cards[swapIdx], p.Cards[i]
-	}
-	for i := 0; i < len(yourCards); i++ {
-		swapIdx := p.IndexOf(yourCards[i])
-		targetIdx := i + 5
-		p.Cards[targetIdx], p.Cards[swapIdx] = p.Cards[swapIdx], p.Cards[targetIdx]
-	}
+	outcomes := gameOutcomes(dealOutcomes(onTable, playerCards, false))
+	return poker.NewHandOutcome(outcomes, 1+randGen.Intn(players-1), poker.HighRanking)
 }
 
 type StartingPair struct {
@@ -149,15 +212,15 @@ func (pair StartingPair) SampleCards() (poker.Card, poker.Card) {
 		panic(err)
 	}
 	// Just pick arbitrary suits, either the same or different
-	card1 := poker.Card{pair.Rank1, poker.Club}
-	card2 := poker.Card{pair.Rank2, poker.Heart}
+	card1 := poker.Card{Rank: pair.Rank1, Suit: poker.Club}
+	card2 := poker.Card{Rank: pair.Rank2, Suit: poker.Heart}
 	if pair.SameSuit {
 		card2.Suit = poker.Club
 	}
 	return card1, card2
 }
 
-func (pair StartingPair) RunSimulation(players, handsToPlay int) *poker.Simulator {
+func (pair StartingPair) RunSimulation(players, handsToPlay int, randGen *rand.Rand) *poker.Simulator {
 	card1, card2 := pair.SampleCards()
-	return SimulateHoldem([]poker.Card{}, []poker.Card{card1, card2}, players, handsToPlay)
+	return SimulateHoldem([]poker.Card{}, []poker.Card{card1, card2}, players, handsToPlay, randGen)
 }
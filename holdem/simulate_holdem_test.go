@@ -20,23 +20,208 @@ along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
 package holdem
 
 import (
+	"context"
 	"fmt"
 	"github.com/amdw/gopoker/poker"
 	"math"
 	"math/rand"
+	"reflect"
+	"strings"
 	"testing"
 )
 
 func TestSimSanity(t *testing.T) {
 	players := 5
 	simulations := 10000
-	sim := SimulateHoldem([]poker.Card{}, []poker.Card{}, players, simulations)
+	randGen := rand.New(rand.NewSource(1234)) // Deterministic for repeatable tests
+	sim := SimulateHoldem([]poker.Card{}, []poker.Card{}, players, simulations, randGen)
 	poker.TestAssertSimSanity(sim, players, simulations, t)
 }
 
+func TestParallelSimSanity(t *testing.T) {
+	players := 5
+	simulations := 10001
+	for _, workers := range []int{0, 1, 4} {
+		randGen := rand.New(rand.NewSource(1234))
+		sim := SimulateHoldemParallel(h("AS", "KD"), []poker.Card{}, players, simulations, workers, randGen)
+		poker.TestAssertSimSanity(sim, players, simulations, t)
+	}
+}
+
+func TestReproducibility(t *testing.T) {
+	players := 4
+	simulations := 5000
+	run := func(seed int64) *poker.Simulator {
+		return SimulateHoldem(h("2C", "7D", "JS"), h("AS", "KD"), players, simulations, rand.New(rand.NewSource(seed)))
+	}
+	if !reflect.DeepEqual(run(1234), run(1234)) {
+		t.Errorf("Expected identical results from identical seeds")
+	}
+	if reflect.DeepEqual(run(1234), run(4321)) {
+		t.Errorf("Expected different results from different seeds")
+	}
+
+	// Parallel results should depend only on the seed, not on the number of workers
+	expected := SimulateHoldemParallel([]poker.Card{}, h("AS", "KD"), players, simulations, 1, rand.New(rand.NewSource(1234)))
+	for _, workers := range []int{2, 3, 8} {
+		sim := SimulateHoldemParallel([]poker.Card{}, h("AS", "KD"), players, simulations, workers, rand.New(rand.NewSource(1234)))
+		if !reflect.DeepEqual(expected, sim) {
+			t.Errorf("Expected identical results with %v workers", workers)
+		}
+	}
+}
+
+func TestSimCancellation(t *testing.T) {
+	players := 5
+	simulations := 1000000
+	ctx, cancel := context.WithCancel(context.Background())
+	reports := 0
+	progress := func(p poker.Progress) {
+		reports++
+		if p.HandsToPlay != simulations || p.HandsPlayed > simulations {
+			t.Errorf("Insane progress report %+v", p)
+		}
+		if p.WinRate() < 0 || p.WinRate() > 1 || p.PotShare() < 0 || p.PotShare() > p.WinRate() {
+			t.Errorf("Insane estimates in progress report %+v", p)
+		}
+		if p.HandsPlayed >= 5000 {
+			cancel()
+		}
+	}
+	randGen := rand.New(rand.NewSource(1234))
+	sim, err := SimulateHoldemParallelContext(ctx, []poker.Card{}, h("AS", "AD"), players, simulations, 4, randGen, progress)
+	if err != context.Canceled {
+		t.Errorf("Expected cancellation error, found %v", err)
+	}
+	if sim.HandCount >= simulations || sim.HandCount < 5000 {
+		t.Errorf("Expected partial simulation, found %v hands", sim.HandCount)
+	}
+	poker.TestAssertSimSanity(sim, players, sim.HandCount, t)
+	if reports == 0 {
+		t.Errorf("Expected some progress reports")
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	sim, err = SimulateHoldemContext(ctx, []poker.Card{}, h("AS", "AD"), players, simulations, randGen, nil)
+	if err != context.Canceled || sim.HandCount != 0 {
+		t.Errorf("Expected immediate cancellation, found %v hands and error %v", sim.HandCount, err)
+	}
+}
+
+func TestAdaptiveSimulation(t *testing.T) {
+	target := 0.005
+	maxHands := 1000000
+	randGen := rand.New(rand.NewSource(1234))
+	sim, err := SimulateHoldemAdaptive(context.Background(), []poker.Card{}, h("AS", "AD"), 2, target, maxHands, 0, randGen, nil)
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+	poker.TestAssertSimSanity(sim, 2, sim.HandCount, t)
+	equity := sim.Equity()
+	if equity.HalfWidth(poker.Z95) > target || sim.HandCount >= maxHands || sim.HandCount <= poker.AdaptiveInitialBatch {
+		t.Errorf("Expected to stop once half-width was below %v, found %v after %v hands", target, equity.HalfWidth(poker.Z95), sim.HandCount)
+	}
+	// Aces win about 85.2% of the pot heads-up against a random hand
+	if math.Abs(equity.Mean-0.852) > 0.01 {
+		t.Errorf("Expected equity around 0.852, found %+v", equity)
+	}
+
+	// Stop at the maximum if the target is too ambitious
+	sim, err = SimulateHoldemAdaptive(context.Background(), []poker.Card{}, h("AS", "AD"), 2, 0.0001, 25000, 0, randGen, nil)
+	if err != nil || sim.HandCount != 25000 {
+		t.Errorf("Expected to stop after 25000 hands, found %v (error %v)", sim.HandCount, err)
+	}
+
+	// Exhaustive enumeration gives an exact answer straight away
+	sim, err = SimulateHoldemAdaptive(context.Background(), h("KS", "7D", "AH", "8C", "8D"), h("9D", "7C"), 2, target, maxHands, 0, randGen, nil)
+	if err != nil || !sim.Exhaustive || sim.HandCount != 990 || sim.Equity().StdErr != 0 {
+		t.Errorf("Expected exact enumeration, found %v hands, exhaustive %v, error %v", sim.HandCount, sim.Exhaustive, err)
+	}
+
+	// Results depend only on the seed
+	run := func() *poker.Simulator {
+		sim, _ := SimulateHoldemAdaptive(context.Background(), []poker.Card{}, h("JS", "10S"), 3, 0.01, maxHands, 0, rand.New(rand.NewSource(4321)), nil)
+		return sim
+	}
+	if !reflect.DeepEqual(run(), run()) {
+		t.Errorf("Expected identical adaptive results from identical seeds")
+	}
+}
+
+func mustParseRange(spec string) Range {
+	r, err := ParseRange(spec)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+func TestSimulateHoldemRanges(t *testing.T) {
+	ctx := context.Background()
+	randGen := rand.New(rand.NewSource(1234))
+	yourCards := h("AS", "AD")
+
+	// Aces have about 82% equity against kings
+	sim, err := SimulateHoldemRanges(ctx, []poker.Card{}, yourCards, []Range{mustParseRange("KK")}, 20000, 0, randGen, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	poker.TestAssertSimSanity(sim, 2, 20000, t)
+	if equity := sim.Equity(); math.Abs(equity.Mean-0.82) > 0.015 {
+		t.Errorf("Expected equity around 0.82 for aces against kings, found %+v", equity)
+	}
+	if sim.BestOpponentClassCounts[poker.HighCard] != 0 {
+		t.Errorf("Opponent holding kings can never have a high card hand, found %v", sim.BestOpponentClassCounts[poker.HighCard])
+	}
+
+	// Two opponents with ranges, plus one with random cards
+	ranges := []Range{mustParseRange("KK"), nil, mustParseRange("QQ+, AKs")}
+	sim, err = SimulateHoldemRanges(ctx, h("KH", "7D", "2C"), yourCards, ranges, 5000, 0, randGen, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	poker.TestAssertSimSanity(sim, 4, 5000, t)
+
+	// The results depend only on the seed
+	expected, _ := SimulateHoldemRanges(ctx, []poker.Card{}, yourCards, ranges, 3000, 1, rand.New(rand.NewSource(4321)), nil)
+	actual, _ := SimulateHoldemRanges(ctx, []poker.Card{}, yourCards, ranges, 3000, 4, rand.New(rand.NewSource(4321)), nil)
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected identical results from identical seeds regardless of worker count")
+	}
+
+	// Without any ranges, this is the same as an ordinary simulation
+	expected = SimulateHoldemParallel(h("KH", "7D", "2C"), yourCards, 3, 3000, 0, rand.New(rand.NewSource(4321)))
+	actual, _ = SimulateHoldemRanges(ctx, h("KH", "7D", "2C"), yourCards, []Range{nil, nil}, 3000, 0, rand.New(rand.NewSource(4321)), nil)
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected random opponents when no ranges are given")
+	}
+
+	sim, err = SimulateHoldemRangesAdaptive(ctx, []poker.Card{}, yourCards, []Range{mustParseRange("KK")}, 0.01, 1000000, 0, randGen, nil)
+	if err != nil || sim.Equity().HalfWidth(poker.Z95) > 0.01 || sim.HandCount >= 1000000 {
+		t.Errorf("Expected adaptive simulation to reach target, found %v hands and error %v", sim.HandCount, err)
+	}
+}
+
+func TestSimulateHoldemRangeErrors(t *testing.T) {
+	ctx := context.Background()
+	randGen := rand.New(rand.NewSource(1234))
+	_, err := SimulateHoldemRanges(ctx, []poker.Card{}, h("AH", "KS"), []Range{nil, mustParseRange("AhAd")}, 1000, 0, randGen, nil)
+	if err == nil || !strings.Contains(err.Error(), "Range for opponent 2 has no combos left") {
+		t.Errorf("Expected error about blocked range, found %v", err)
+	}
+
+	// Both opponents can only hold the same hand
+	sim, err := SimulateHoldemRanges(ctx, []poker.Card{}, h("2H", "2S"), []Range{mustParseRange("AhKh"), mustParseRange("AhKh")}, 1000, 0, randGen, nil)
+	if err == nil || !strings.Contains(err.Error(), "non-overlapping") || sim.HandCount != 0 {
+		t.Errorf("Expected error about inconsistent ranges, found %v", err)
+	}
+}
+
 func TestTwoPlayers(t *testing.T) {
 	simulations := 10000
-	sim := SimulateHoldem([]poker.Card{}, []poker.Card{}, 2, simulations)
+	randGen := rand.New(rand.NewSource(1234))
+	sim := SimulateHoldem([]poker.Card{}, []poker.Card{}, 2, simulations, randGen)
 	poker.TestAssertSimSanity(sim, 2, simulations, t)
 	// We can make some extra assertions here, as it's impossible for a pot to be split among opponents
 	totalPotsWon := sim.PotsWon + sim.BestOpponentPotsWon
@@ -52,7 +237,8 @@ func TestTwoPlayers(t *testing.T) {
 func TestEnumeration(t *testing.T) {
 	yourCards := h("9D", "7C")
 	tableCards := h("KS", "7D", "AH", "8C", "8D")
-	sim := SimulateHoldem(tableCards, yourCards, 2, 10000)
+	randGen := rand.New(rand.NewSource(1234))
+	sim := SimulateHoldem(tableCards, yourCards, 2, 10000, randGen)
 
 	// Should only do 45C2 = 990 simulations, one for each possible hand our opponent holds
 	poker.TestAssertSimSanity(sim, 2, 990, t)
@@ -188,7 +374,7 @@ func TestFixedShuffle(t *testing.T) {
 	myCards := h("KS", "AC")
 	tableCards := h("10D", "2C", "AS", "4D", "6H")
 	for testNum := 0; testNum < 1000; testNum++ {
-		shuffleFixing(&pack, tableCards, myCards, randGen)
+		layout.ShuffleFixing(&pack, tableCards, myCards, randGen)
 		tCards, pCards := Deal(&pack, 5)
 		if !poker.CardsEqual(tableCards, tCards) {
 			t.Errorf("Expected table cards %q, found %q", tableCards, tCards)
@@ -234,8 +420,9 @@ func TestPairs(t *testing.T) {
 	pairs := []StartingPair{sp("K", "Q", false), sp("K", "Q", true), sp("K", "K", false)}
 	players := 6
 	simCount := 1000
+	randGen := rand.New(rand.NewSource(1234))
 	for _, pair := range pairs {
-		sim := pair.RunSimulation(players, simCount)
+		sim := pair.RunSimulation(players, simCount, randGen)
 		poker.TestAssertSimSanity(sim, players, simCount, t)
 	}
 }
@@ -0,0 +1,29 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package holdem
+
+import (
+	"github.com/amdw/gopoker/poker"
+)
+
+// As DealOutcomes, but with the given wild cards. The hands are dealt from a pack with any jokers added.
+func WildOutcomes(wild poker.WildCards, onTable []poker.Card, playerCards [][]poker.Card) []PlayerOutcome {
+	return rankedOutcomes(wild.BestHand, poker.Beats, onTable, playerCards, true)
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package holdem
+
+import (
+	"github.com/amdw/gopoker/poker"
+	"math/rand"
+	"testing"
+)
+
+func TestWildOutcomes(t *testing.T) {
+	deuces := poker.WildCards{Ranks: []poker.Rank{poker.Two}}
+	// The deuce makes five of a kind, beating the straight flush
+	outcomes := WildOutcomes(deuces, h("KS", "KD", "KH", "QS", "JS"), hs(h("2C", "KC"), h("AS", "10S")))
+	if !levelsEqual(hl("FiveOfAKind", "K"), outcomes[0].Level) || outcomes[0].PotFractionWon != 1 {
+		t.Errorf("Expected five kings to win, found %+v", outcomes[0])
+	}
+	if !levelsEqual(hl("StraightFlush", "A"), outcomes[1].Level) || outcomes[1].PotFractionWon != 0 {
+		t.Errorf("Expected a losing royal flush, found %+v", outcomes[1])
+	}
+	if len(outcomes[0].Cards) != 5 {
+		t.Errorf("Expected five cards for the winning hand, found %v", outcomes[0].Cards)
+	}
+
+	// The bug only counts as an ace here, so both players have aces full of kings
+	bug := poker.WildCards{Bug: true}
+	outcomes = WildOutcomes(bug, h("AS", "AD", "KH", "KS", "3C"), hs(h("JK1", "7D"), h("AC", "4D")))
+	for _, o := range outcomes {
+		if !levelsEqual(hl("FullHouse", "A", "K"), o.Level) || o.PotFractionWon != 0.5 {
+			t.Errorf("Expected a split pot with aces full, found %+v", o)
+		}
+	}
+}
+
+func TestWildGame(t *testing.T) {
+	names := map[string]string{
+		"holdem-deuces": "Texas Hold'em (2s wild)",
+		"holdem-jokers": "Texas Hold'em (jokers wild)",
+		"holdem-bug":    "Texas Hold'em (joker as bug wild)",
+	}
+	for key, name := range names {
+		if g, _ := poker.LookupGame(key); g.Name() != name {
+			t.Errorf("Expected %q for %v, found %q", name, key, g.Name())
+		}
+	}
+
+	game := WildGame{Wild: poker.WildCards{Bug: true}, Jokers: 1}
+	if pack := game.NewPack(); pack.Size() != 53 {
+		t.Errorf("Expected 53 cards in play, found %v", pack.Size())
+	}
+	if err := game.ValidateKnownCards(h("JK1"), h("AS", "AD"), 2); err != nil {
+		t.Errorf("Unexpected error for a known joker: %v", err)
+	}
+	if err := game.ValidateKnownCards(h("JK2"), nil, 2); err == nil {
+		t.Errorf("Expected error for a second joker")
+	}
+
+	players := 4
+	simulations := 2000
+	sim := poker.SimulateGame(game, h("JK1"), h("AS", "KD"), players, simulations, rand.New(rand.NewSource(1234)))
+	poker.TestAssertSimSanity(sim, players, simulations, t)
+	sim = poker.SimulateGame(WildGame{Jokers: 2}, nil, h("JK1", "JK2"), players, simulations, rand.New(rand.NewSource(1234)))
+	poker.TestAssertSimSanity(sim, players, simulations, t)
+	if sim.OurClassCounts[poker.HighCard]+sim.OurClassCounts[poker.OnePair]+sim.OurClassCounts[poker.TwoPair] != 0 {
+		t.Errorf("Two jokers should always make at least three of a kind, found %v", sim.OurClassCounts)
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package lowball27
+
+import (
+	"fmt"
+	"github.com/amdw/gopoker/poker"
+)
+
+// A deuce-to-seven hand is always exactly five cards, with nothing shared on the table
+const HandSize = 5
+
+// The number of draws in each variant
+const (
+	SingleDraw = 1
+	TripleDraw = 3
+)
+
+// No more than ten players can be dealt in from one pack, though far fewer can draw without reshuffling the discards
+const MaxPlayers = 52 / HandSize
+
+// Give each player their starting hand from the top of the pack, in turn
+func Deal(pack *poker.Pack, players int) [][]poker.Card {
+	if players < 1 || players > MaxPlayers {
+		panic(fmt.Sprintf("Between 1 and %v players supported, found %v", MaxPlayers, players))
+	}
+	playerCards := make([][]poker.Card, players)
+	for i := 0; i < players; i++ {
+		playerCards[i] = pack.Cards[i*HandSize : (i+1)*HandSize]
+	}
+	return playerCards
+}
+
+type PlayerOutcome struct {
+	Player         int
+	Level          poker.HandLevel
+	Won            bool
+	PotFractionWon float64
+}
+
+// Classify each player's final hand as a deuce-to-seven low, and share the pot between the lowest. The outcome for
+// playerCards[i] is at index i, numbered as player i+1.
+func PlayerOutcomes(playerCards [][]poker.Card) []PlayerOutcome {
+	result := make([]PlayerOutcome, len(playerCards))
+	bestLevel := poker.DeuceToSevenLowRanking.MinLevel()
+	for i, hand := range playerCards {
+		// Classification sorts the cards, so leave the player's own cards alone
+		cards := make([]poker.Card, len(hand))
+		copy(cards, hand)
+		level := poker.ClassifyDeuceToSevenLow(cards)
+		result[i] = PlayerOutcome{i + 1, level, false, 0}
+		if !poker.BeatsDeuceToSevenLow(bestLevel, level) {
+			bestLevel = level
+		}
+	}
+
+	winners := 0
+	for _, outcome := range result {
+		if !poker.BeatsDeuceToSevenLow(bestLevel, outcome.Level) {
+			winners++
+		}
+	}
+	for i, outcome := range result {
+		if !poker.BeatsDeuceToSevenLow(bestLevel, outcome.Level) {
+			result[i].Won = true
+			result[i].PotFractionWon = 1.0 / float64(winners)
+		}
+	}
+	return result
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package lowball27
+
+import (
+	"github.com/amdw/gopoker/poker"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+var h = poker.TestMakeHand
+var hl = poker.TestMakeHandLevel
+
+func TestDeal(t *testing.T) {
+	pack := poker.NewPack()
+	pack.Shuffle(rand.New(rand.NewSource(1234)))
+	playerCards := Deal(&pack, MaxPlayers)
+	seen := poker.CardSet(0)
+	for i, hand := range playerCards {
+		if len(hand) != HandSize {
+			t.Errorf("Expected %v cards for player %v, found %v", HandSize, i+1, len(hand))
+		}
+		seen = seen.Union(poker.NewCardSet(hand...))
+	}
+	if seen.Count() != HandSize*MaxPlayers {
+		t.Errorf("Expected %v distinct cards, found %v", HandSize*MaxPlayers, seen.Count())
+	}
+}
+
+func TestPlayerOutcomes(t *testing.T) {
+	playerCards := [][]poker.Card{
+		h("AS", "2C", "3D", "4H", "5S"), // Ace-high, not a straight
+		h("7S", "5C", "4D", "3H", "2S"), // The nuts
+		h("6H", "4H", "3H", "2H", "5H"), // A straight flush
+	}
+	outcomes := PlayerOutcomes(playerCards)
+	expected := []poker.HandLevel{hl("HighCard", "A", "5", "4", "3", "2"), hl("HighCard", "7", "5", "4", "3", "2"), hl("StraightFlush", "6")}
+	for i, outcome := range outcomes {
+		if outcome.Player != i+1 || !reflect.DeepEqual(expected[i], outcome.Level) || outcome.Won != (i == 1) {
+			t.Errorf("Unexpected outcome for player %v: %v", i+1, outcome)
+		}
+	}
+	if !reflect.DeepEqual(h("AS", "2C", "3D", "4H", "5S"), playerCards[0]) {
+		t.Errorf("Expected player cards to be left alone, found %v", playerCards[0])
+	}
+
+	outcomes = PlayerOutcomes([][]poker.Card{
+		h("8S", "6C", "4D", "3H", "2S"),
+		h("8D", "6H", "4S", "3C", "2D"),
+	})
+	for _, outcome := range outcomes {
+		if !outcome.Won || outcome.PotFractionWon != 0.5 {
+			t.Errorf("Expected split pot, found %v", outcome)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package lowball27
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/amdw/gopoker/poker"
+	"math/rand"
+)
+
+// Results of simulating the draws from a starting hand
+type DrawSimulator struct {
+	HandCount   int
+	ClassCounts []int // How often each class of final hand was made (a straight or flush counts as such, not as a low)
+	LowCounts   []int // How often each no-pair low was made, by its top card (e.g. LowCounts[poker.Seven] for seven-lows)
+}
+
+func (s *DrawSimulator) Reset(handsToPlay int) {
+	s.HandCount = handsToPlay
+	s.ClassCounts = make([]int, poker.MAX_HANDCLASS)
+	s.LowCounts = make([]int, poker.Ace+1)
+}
+
+func (s *DrawSimulator) ProcessHand(level poker.HandLevel) {
+	s.ClassCounts[level.Class]++
+	if level.Class == poker.HighCard {
+		s.LowCounts[level.Tiebreaks[0]]++
+	}
+}
+
+// Combine the results of another simulation of the same draw into this one
+func (s *DrawSimulator) Merge(other *DrawSimulator) {
+	s.HandCount += other.HandCount
+	for i := range s.ClassCounts {
+		s.ClassCounts[i] += other.ClassCounts[i]
+	}
+	for i := range s.LowCounts {
+		s.LowCounts[i] += other.LowCounts[i]
+	}
+}
+
+// The chance of ending with a no-pair low headed by the given rank or better (e.g. poker.Eight for an eight-low or better)
+func (s *DrawSimulator) MadeLow(rank poker.Rank) poker.Estimate {
+	count := 0
+	for r := poker.Two; r <= rank; r++ {
+		count += s.LowCounts[r]
+	}
+	return poker.ProportionEstimate(count, s.HandCount)
+}
+
+// Check a starting hand, the cards to be discarded from it on the first draw, any other cards known to be out of the
+// pack (such as cards exposed by the dealer) and the number of draws
+func ValidateDraw(yourCards, discards, deadCards []poker.Card, draws int) error {
+	if len(yourCards) != HandSize {
+		return errors.New(fmt.Sprintf("Exactly %v player cards required, found %v", HandSize, len(yourCards)))
+	}
+	if draws < SingleDraw || draws > TripleDraw {
+		return errors.New(fmt.Sprintf("Between %v and %v draws supported, found %v", SingleDraw, TripleDraw, draws))
+	}
+	if err := poker.ValidateStandardCards(yourCards, deadCards); err != nil {
+		return err
+	}
+	hand := poker.NewCardSet(yourCards...)
+	if hand.Count() != len(yourCards) {
+		return errors.New("Duplicate cards found in player cards")
+	}
+	if discardSet := poker.NewCardSet(discards...); discardSet.Count() != len(discards) || !hand.ContainsAll(discardSet) {
+		return errors.New(fmt.Sprintf("Discards %v must be distinct cards from the player cards", discards))
+	}
+	if maxDead := 52 - HandSize*(1+draws); len(deadCards) > maxDead {
+		return errors.New(fmt.Sprintf("Maximum of %v dead cards allowed with %v draws, found %v", maxDead, draws, len(deadCards)))
+	}
+	if deadSet := poker.NewCardSet(deadCards...); deadSet.Count() != len(deadCards) || deadSet.Intersects(hand) {
+		return errors.New("Duplicate cards found in dead cards")
+	}
+	return nil
+}
+
+// Simulate drawing from a starting hand, discarding the given cards on the first draw. On any later draws, we stand
+// pat on a no-pair low at least as good as the worst card kept on the first draw (or a seven-low, if better), and
+// otherwise throw aces, pairs and cards above that rank. The cards must pass ValidateDraw.
+func SimulateDraw(yourCards, discards, deadCards []poker.Card, draws, handsToPlay int, randGen *rand.Rand) *DrawSimulator {
+	sim, _ := SimulateDrawContext(context.Background(), yourCards, discards, deadCards, draws, handsToPlay, randGen)
+	return sim
+}
+
+// SimulateDraw, but giving up with ctx.Err() once ctx is cancelled. HandCount is then the number of draws made so far.
+func SimulateDrawContext(ctx context.Context, yourCards, discards, deadCards []poker.Card, draws, handsToPlay int, randGen *rand.Rand) (*DrawSimulator, error) {
+	s := DrawSimulator{}
+	s.Reset(handsToPlay)
+
+	discardSet := poker.NewCardSet(discards...)
+	breakRank := poker.Seven
+	for _, c := range yourCards {
+		if !discardSet.Contains(c) && c.Rank > breakRank {
+			breakRank = c.Rank
+		}
+	}
+
+	// Our hand is dealt from the top of the pack, and the dead cards kept at the bottom, out of reach of the draws
+	fixing := poker.Fixing{}
+	fixing.Place(0, yourCards...)
+	fixing.PlaceAtBottom(52, deadCards...)
+
+	p := poker.NewPack()
+	var handBuf, levelBuf [HandSize]poker.Card
+	hand := handBuf[:]
+	played, err := poker.RunHands(ctx, handsToPlay, func() {
+		p.ShuffleFixed(randGen, &fixing)
+		copy(hand, yourCards)
+		next := HandSize
+		for j, c := range hand {
+			if discardSet.Contains(c) {
+				hand[j] = p.Cards[next]
+				next++
+			}
+		}
+		for d := 1; d < draws; d++ {
+			copy(levelBuf[:], hand)
+			for _, j := range redrawPositions(hand, poker.ClassifyDeuceToSevenLow(levelBuf[:]), breakRank) {
+				hand[j] = p.Cards[next]
+				next++
+			}
+		}
+		copy(levelBuf[:], hand)
+		s.ProcessHand(poker.ClassifyDeuceToSevenLow(levelBuf[:]))
+	}, nil)
+	s.HandCount = played
+	return &s, err
+}
+
+// SimulateDraw spread over several goroutines (one per CPU if workers is not positive), which deal the same hands
+// however many of them there are
+func SimulateDrawParallel(yourCards, discards, deadCards []poker.Card, draws, handsToPlay, workers int, randGen *rand.Rand) *DrawSimulator {
+	sim, _ := SimulateDrawParallelContext(context.Background(), yourCards, discards, deadCards, draws, handsToPlay, workers, randGen)
+	return sim
+}
+
+// SimulateDrawParallel, with cancellation as for SimulateDrawContext
+func SimulateDrawParallelContext(ctx context.Context, yourCards, discards, deadCards []poker.Card, draws, handsToPlay, workers int, randGen *rand.Rand) (*DrawSimulator, error) {
+	chunks := poker.ChunkCount(handsToPlay)
+	results := make([]*DrawSimulator, chunks)
+	errs := make([]error, chunks)
+	poker.RunParallel(handsToPlay, workers, randGen, func(chunk, hands int, chunkRandGen *rand.Rand) {
+		results[chunk], errs[chunk] = SimulateDrawContext(ctx, yourCards, discards, deadCards, draws, hands, chunkRandGen)
+	})
+	var err error
+	for i, result := range results {
+		if i > 0 {
+			results[0].Merge(result)
+		}
+		if err == nil {
+			err = errs[i]
+		}
+	}
+	return results[0], err
+}
+
+// The positions of the cards to throw from a hand of the given level on a draw after the first
+func redrawPositions(hand []poker.Card, level poker.HandLevel, breakRank poker.Rank) []int {
+	if level.Class == poker.HighCard && level.Tiebreaks[0] <= breakRank {
+		return nil
+	}
+	result := []int{}
+	seen := make([]bool, poker.Ace+1)
+	highest := 0
+	for i, c := range hand {
+		if c.Rank == poker.Ace || c.Rank > breakRank || seen[c.Rank] {
+			result = append(result, i)
+		}
+		seen[c.Rank] = true
+		if c.Rank > hand[highest].Rank {
+			highest = i
+		}
+	}
+	if len(result) == 0 {
+		// A straight or flush: break it up
+		result = append(result, highest)
+	}
+	return result
+}
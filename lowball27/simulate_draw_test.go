@@ -0,0 +1,122 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package lowball27
+
+import (
+	"context"
+	"github.com/amdw/gopoker/poker"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateDraw(t *testing.T) {
+	if err := ValidateDraw(h("7S", "5C", "4D", "3H", "KS"), h("KS"), h("2S"), TripleDraw); err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+	testCases := []struct {
+		yourCards, discards, deadCards []poker.Card
+		draws                          int
+		expected                       string
+	}{
+		{h("7S", "5C", "4D", "3H"), nil, nil, SingleDraw, "Exactly 5"},
+		{h("7S", "5C", "4D", "3H", "KS"), nil, nil, 4, "Between 1 and 3"},
+		{h("7S", "5C", "4D", "3H", "7S"), nil, nil, SingleDraw, "Duplicate"},
+		{h("7S", "5C", "4D", "3H", "KS"), h("KD"), nil, SingleDraw, "Discards"},
+		{h("7S", "5C", "4D", "3H", "KS"), nil, h("7S"), SingleDraw, "Duplicate"},
+		{h("7S", "5C", "4D", "3H", "KS"), nil, h("JK2"), SingleDraw, "not in a standard pack"},
+	}
+	for _, tc := range testCases {
+		err := ValidateDraw(tc.yourCards, tc.discards, tc.deadCards, tc.draws)
+		if err == nil || !strings.Contains(err.Error(), tc.expected) {
+			t.Errorf("Expected error containing %q, found %v", tc.expected, err)
+		}
+	}
+}
+
+func TestRedrawPositions(t *testing.T) {
+	testCases := []struct {
+		hand      []poker.Card
+		breakRank poker.Rank
+		expected  []int
+	}{
+		{h("7S", "5C", "4D", "3H", "2S"), poker.Seven, nil},
+		{h("8S", "5C", "4D", "3H", "2S"), poker.Seven, []int{0}},
+		{h("8S", "5C", "4D", "3H", "2S"), poker.Eight, nil},
+		{h("7S", "5C", "5D", "AH", "2S"), poker.Seven, []int{2, 3}},
+		{h("6S", "5C", "4D", "3H", "2S"), poker.Seven, []int{0}},
+	}
+	for _, tc := range testCases {
+		levelCards := make([]poker.Card, len(tc.hand))
+		copy(levelCards, tc.hand)
+		result := redrawPositions(tc.hand, poker.ClassifyDeuceToSevenLow(levelCards), tc.breakRank)
+		if len(result) != len(tc.expected) || (len(result) > 0 && !reflect.DeepEqual(tc.expected, result)) {
+			t.Errorf("Expected to throw %v from %v, found %v", tc.expected, tc.hand, result)
+		}
+	}
+}
+
+func TestSimulateDraw(t *testing.T) {
+	// Standing pat on a made seven-low always makes it
+	pat := SimulateDraw(h("7S", "5C", "4D", "3H", "2S"), nil, nil, TripleDraw, 1000, rand.New(rand.NewSource(1234)))
+	if pat.HandCount != 1000 || pat.LowCounts[poker.Seven] != 1000 {
+		t.Errorf("Expected seven-low every time, found %v", pat.LowCounts)
+	}
+
+	// A one-card draw to 7-5-4-3 makes a seven-low with any of four deuces out of 47 cards (a six makes a straight),
+	// and an eight-low or better with four more outs
+	single := SimulateDraw(h("7S", "5C", "4D", "3H", "KS"), h("KS"), nil, SingleDraw, 20000, rand.New(rand.NewSource(1234)))
+	if est := single.MadeLow(poker.Seven); est.Mean < 0.07 || est.Mean > 0.1 {
+		t.Errorf("Expected about 8.5%% chance of a seven-low, found %v", est)
+	}
+	if est := single.MadeLow(poker.Eight); est.Mean < 0.15 || est.Mean > 0.19 {
+		t.Errorf("Expected about 17%% chance of an eight-low or better, found %v", est)
+	}
+	triple := SimulateDraw(h("7S", "5C", "4D", "3H", "KS"), h("KS"), nil, TripleDraw, 20000, rand.New(rand.NewSource(1234)))
+	if triple.MadeLow(poker.Seven).Mean <= single.MadeLow(poker.Seven).Mean+0.1 {
+		t.Errorf("Expected three draws to do much better than one, found %v vs %v", triple.MadeLow(poker.Seven), single.MadeLow(poker.Seven))
+	}
+	total := 0
+	for _, count := range triple.ClassCounts {
+		total += count
+	}
+	if total != triple.HandCount || triple.MadeLow(poker.Ace).Mean*float64(triple.HandCount) != float64(triple.ClassCounts[poker.HighCard]) {
+		t.Errorf("Inconsistent counts %v and %v", triple.ClassCounts, triple.LowCounts)
+	}
+}
+
+func TestSimulateDrawParallel(t *testing.T) {
+	yourCards, discards := h("8S", "6C", "2D", "2H", "KS"), h("2H", "KS")
+	sim := SimulateDrawParallel(yourCards, discards, h("3C"), TripleDraw, 5000, 4, rand.New(rand.NewSource(1234)))
+	other := SimulateDrawParallel(yourCards, discards, h("3C"), TripleDraw, 5000, 1, rand.New(rand.NewSource(1234)))
+	if sim.HandCount != 5000 || !reflect.DeepEqual(sim, other) {
+		t.Errorf("Expected identical results with different numbers of workers")
+	}
+}
+
+func TestSimulateDrawCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	sim, err := SimulateDrawParallelContext(ctx, h("7S", "5C", "4D", "3H", "KS"), h("KS"), nil, TripleDraw, 1000, 0, rand.New(rand.NewSource(1234)))
+	if err != context.Canceled || sim.HandCount >= 1000 {
+		t.Errorf("Expected cancellation, found %v after %v hands", err, sim.HandCount)
+	}
+}
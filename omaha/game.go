@@ -0,0 +1,79 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package omaha
+
+import (
+	"fmt"
+	"github.com/amdw/gopoker/poker"
+)
+
+// Omaha high with the given number of hole cards (between MinHoleCards and MaxHoleCards), as a poker.Game
+type Game struct {
+	HoleCards int
+}
+
+func init() {
+	poker.RegisterGame("omaha", Game{4})
+	poker.RegisterGame("omaha5", Game{5})
+	poker.RegisterGame("omaha6", Game{6})
+}
+
+func (g Game) Name() string {
+	switch g.HoleCards {
+	case 5:
+		return "Five-card Omaha"
+	case 6:
+		return "Six-card Omaha"
+	}
+	return "Omaha"
+}
+
+func (g Game) NewPack() poker.Pack {
+	return poker.NewPack()
+}
+
+func (g Game) Layout() poker.DealLayout {
+	return layout(g.HoleCards)
+}
+
+func (g Game) SettlementRules() poker.SettlementRules {
+	return poker.SettlementRules{Ranking: poker.HighRanking}
+}
+
+func (g Game) ValidateKnownCards(tableCards, yourCards []poker.Card, players int) error {
+	return poker.ValidateGameCards(g, tableCards, yourCards, players)
+}
+
+func (g Game) Outcomes(tableCards []poker.Card, playerCards [][]poker.Card) []poker.GameOutcome {
+	outcomes := PlayerOutcomes(tableCards, playerCards)
+	result := make([]poker.GameOutcome, len(outcomes))
+	for i, o := range outcomes {
+		result[i] = poker.GameOutcome{Player: o.Player, Level: o.Level, Cards: o.Cards, PotFractionWon: o.PotFractionWon}
+	}
+	return result
+}
+
+// Where the cards are dealt from the pack, given the number of hole cards each player gets
+func layout(holeCards int) poker.DealLayout {
+	if holeCards < MinHoleCards || holeCards > MaxHoleCards {
+		panic(fmt.Sprintf("Between %v and %v hole cards supported, found %v", MinHoleCards, MaxHoleCards, holeCards))
+	}
+	return poker.DealLayout{TableCards: 5, HoleCards: holeCards}
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package omaha
+
+import (
+	"github.com/amdw/gopoker/poker"
+	"math/rand"
+	"testing"
+)
+
+func TestGameRegistration(t *testing.T) {
+	expected := map[string]string{"omaha": "Omaha", "omaha5": "Five-card Omaha", "omaha6": "Six-card Omaha"}
+	for key, name := range expected {
+		if g, ok := poker.LookupGame(key); !ok || g.Name() != name {
+			t.Errorf("Expected %v to be registered as %q, found %v", name, key, g)
+		}
+	}
+}
+
+func TestGameValidation(t *testing.T) {
+	if err := (Game{6}).ValidateKnownCards(h("AS", "KS", "QS"), h("2C", "3C", "4C", "5C", "6C", "7C"), 7); err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+	if err := (Game{6}).ValidateKnownCards(nil, nil, 8); err == nil {
+		t.Errorf("Expected error for 8 players with six hole cards")
+	}
+	if err := (Game{4}).ValidateKnownCards(nil, h("2C", "3C", "4C", "5C", "6C"), 2); err == nil {
+		t.Errorf("Expected error for five hole cards in four-card Omaha")
+	}
+}
+
+func TestGameOutcomes(t *testing.T) {
+	randGen := rand.New(rand.NewSource(1234))
+	pack := poker.NewPack()
+	for i := 0; i < 100; i++ {
+		pack.Shuffle(randGen)
+		tableCards, playerCards := Deal(&pack, 5, 4)
+		expected := PlayerOutcomes(tableCards, playerCards)
+		for j, outcome := range (Game{4}).Outcomes(tableCards, playerCards) {
+			if outcome.Player != expected[j].Player || outcome.PotFractionWon != expected[j].PotFractionWon || !poker.CardsEqual(outcome.Cards, expected[j].Cards) {
+				t.Errorf("Expected %+v, found %+v", expected[j], outcome)
+			}
+		}
+	}
+}
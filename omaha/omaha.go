@@ -0,0 +1,94 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package omaha
+
+import (
+	"github.com/amdw/gopoker/poker"
+)
+
+type PlayerOutcome struct {
+	Player         int
+	Level          poker.HandLevel
+	Cards          []poker.Card
+	Won            bool
+	PotFractionWon float64
+}
+
+// Omaha is most often played with four hole cards, but five- and six-card variants are also played
+const (
+	MinHoleCards = 4
+	MaxHoleCards = 6
+)
+
+// The most players who can be dealt in from one pack, given the number of hole cards each
+func MaxPlayers(holeCards int) int {
+	return (52 - 5) / holeCards
+}
+
+// Deal the table cards, followed by holeCards cards for each player in turn
+func Deal(pack *poker.Pack, players, holeCards int) (tableCards []poker.Card, playerCards [][]poker.Card) {
+	return layout(holeCards).Deal(pack, players)
+}
+
+// Find the best high hand a player can make, using exactly two of their hole cards and three from the table
+func classify(tableCards, holeCards []poker.Card) (poker.HandStrength, []poker.Card) {
+	possibleCombinations := poker.OmahaCombinations(tableCards, holeCards)
+
+	bestHand := possibleCombinations[0]
+	bestStrength := poker.Evaluate5(bestHand)
+	for i := 1; i < len(possibleCombinations); i++ {
+		strength := poker.Evaluate5(possibleCombinations[i])
+		if strength > bestStrength {
+			bestHand = possibleCombinations[i]
+			bestStrength = strength
+		}
+	}
+	return bestStrength, bestHand
+}
+
+// Assess the hand each player holds and work out how the pot is divided between them.
+// Outcomes are in the same order as playerCards, with player numbers starting from 1.
+func PlayerOutcomes(tableCards []poker.Card, playerCards [][]poker.Card) []PlayerOutcome {
+	result := make([]PlayerOutcome, len(playerCards))
+	strengths := make([]poker.HandStrength, len(playerCards))
+	var bestStrength poker.HandStrength
+	for i, hand := range playerCards {
+		strength, cards := classify(tableCards, hand)
+		result[i] = PlayerOutcome{i + 1, strength.Level(), cards, false, 0}
+		strengths[i] = strength
+		if strength > bestStrength {
+			bestStrength = strength
+		}
+	}
+
+	winners := 0
+	for _, strength := range strengths {
+		if strength == bestStrength {
+			winners++
+		}
+	}
+	for i, strength := range strengths {
+		if strength == bestStrength {
+			result[i].Won = true
+			result[i].PotFractionWon = 1.0 / float64(winners)
+		}
+	}
+	return result
+}
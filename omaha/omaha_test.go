@@ -0,0 +1,136 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package omaha
+
+import (
+	"github.com/amdw/gopoker/poker"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+var h = poker.TestMakeHand
+
+func TestDeal(t *testing.T) {
+	pack := poker.NewPack()
+	randGen := rand.New(rand.NewSource(1234))
+	pack.Shuffle(randGen)
+
+	playerCount := 6
+	tableCards, playerCards := Deal(&pack, playerCount, 4)
+	if len(tableCards) != 5 {
+		t.Errorf("Expected five table cards, found %v", len(tableCards))
+	}
+	seen := poker.NewCardSet(tableCards...)
+	if len(playerCards) != playerCount {
+		t.Errorf("Expected %v sets of player cards, found %v", playerCount, len(playerCards))
+	}
+	for i, hand := range playerCards {
+		if len(hand) != 4 {
+			t.Errorf("Expected four cards for player %v, found %v", i+1, len(hand))
+		}
+		if seen.Intersects(poker.NewCardSet(hand...)) {
+			t.Errorf("Found duplicate cards in hand %v", hand)
+		}
+		seen = seen.Union(poker.NewCardSet(hand...))
+	}
+}
+
+func TestDealHoleCards(t *testing.T) {
+	pack := poker.NewPack()
+	pack.Shuffle(rand.New(rand.NewSource(1234)))
+	for holeCards := MinHoleCards; holeCards <= MaxHoleCards; holeCards++ {
+		players := MaxPlayers(holeCards)
+		tableCards, playerCards := Deal(&pack, players, holeCards)
+		seen := poker.NewCardSet(tableCards...)
+		for i, hand := range playerCards {
+			if len(hand) != holeCards {
+				t.Errorf("Expected %v cards for player %v, found %v", holeCards, i+1, len(hand))
+			}
+			seen = seen.Union(poker.NewCardSet(hand...))
+		}
+		if expected := 5 + players*holeCards; seen.Count() != expected {
+			t.Errorf("Expected %v distinct cards dealt to %v players, found %v", expected, players, seen.Count())
+		}
+	}
+	if MaxPlayers(4) != 11 || MaxPlayers(5) != 9 || MaxPlayers(6) != 7 {
+		t.Errorf("Unexpected maximum player counts %v, %v, %v", MaxPlayers(4), MaxPlayers(5), MaxPlayers(6))
+	}
+}
+
+func TestClassify(t *testing.T) {
+	testCases := []struct {
+		tableCards, holeCards []poker.Card
+		expected              poker.HandLevel
+	}{
+		// Four to a flush on the table is no good with only one of the suit in hand
+		{h("2H", "5H", "8H", "JH", "KC"), h("AH", "AC", "7D", "3S"), poker.TestMakeHandLevel("OnePair", "A", "K", "J", "8")},
+		// Must use exactly two hole cards, so trips in hand is just a pair
+		{h("2H", "5D", "8C", "JS", "KC"), h("AH", "AC", "AD", "3S"), poker.TestMakeHandLevel("OnePair", "A", "K", "J", "8")},
+		// Nut flush using two hole cards
+		{h("2H", "5H", "8H", "JS", "KC"), h("AH", "QH", "7D", "3S"), poker.TestMakeHandLevel("Flush", "A", "Q", "8", "5", "2")},
+		// Straight using two from hand and three from the board
+		{h("9H", "10D", "JC", "2S", "2C"), h("QH", "KD", "AS", "AC"), poker.TestMakeHandLevel("Straight", "K")},
+		// Trips on the board only make a full house with a pair in hand
+		{h("8H", "8D", "8C", "4S", "5C"), h("4H", "2D", "3S", "7C"), poker.TestMakeHandLevel("ThreeOfAKind", "8", "7", "4")},
+		{h("8H", "8D", "8C", "4S", "5C"), h("4H", "4D", "3S", "7C"), poker.TestMakeHandLevel("FullHouse", "8", "4")},
+		// With more hole cards there are more ways of choosing the two to use
+		{h("9H", "10D", "JC", "2S", "2C"), h("QH", "KD", "AS", "AC", "8D"), poker.TestMakeHandLevel("Straight", "K")},
+		{h("2H", "5H", "8H", "JS", "KC"), h("AH", "QC", "7D", "3S", "KS", "6H"), poker.TestMakeHandLevel("Flush", "A", "8", "6", "5", "2")},
+	}
+	for _, tc := range testCases {
+		strength, cards := classify(tc.tableCards, tc.holeCards)
+		if level := strength.Level(); !reflect.DeepEqual(tc.expected, level) {
+			t.Errorf("Expected %v for %v on %v, found %v", tc.expected, tc.holeCards, tc.tableCards, level)
+		}
+		cardSet := poker.NewCardSet(cards...)
+		if len(cards) != 5 || cardSet.Intersection(poker.NewCardSet(tc.holeCards...)).Count() != 2 {
+			t.Errorf("Expected two hole cards in best hand %v", cards)
+		}
+	}
+}
+
+func TestPlayerOutcomes(t *testing.T) {
+	tableCards := h("2H", "5H", "8H", "JS", "KC")
+	playerCards := [][]poker.Card{
+		h("AH", "QH", "7D", "3S"), // Nut flush
+		h("KH", "QS", "7C", "3C"), // Lower flush
+		h("AD", "QD", "JD", "3D"), // Chops the pot with the next player
+		h("AS", "QC", "JC", "3H"),
+	}
+	outcomes := PlayerOutcomes(tableCards, playerCards)
+	expectedWon := []bool{true, false, false, false}
+	for i, outcome := range outcomes {
+		if outcome.Player != i+1 || outcome.Won != expectedWon[i] {
+			t.Errorf("Unexpected outcome for player %v: %v", i+1, outcome)
+		}
+	}
+	if outcomes[0].PotFractionWon != 1 {
+		t.Errorf("Expected player 1 to win the whole pot, found %v", outcomes[0].PotFractionWon)
+	}
+
+	// Without the flush draws, the last two players split the pot
+	outcomes = PlayerOutcomes(tableCards, playerCards[2:])
+	for _, outcome := range outcomes {
+		if !outcome.Won || outcome.PotFractionWon != 0.5 {
+			t.Errorf("Expected a split pot, found %v", outcome)
+		}
+	}
+}
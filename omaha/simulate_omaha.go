@@ -0,0 +1,60 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package omaha
+
+import (
+	"context"
+	"github.com/amdw/gopoker/poker"
+	"math/rand"
+)
+
+// Simulate hands of Omaha high with the given number of hole cards each (between MinHoleCards and MaxHoleCards)
+func SimulateOmaha(tableCards, yourCards []poker.Card, players, holeCards, handsToPlay int, randGen *rand.Rand) *poker.Simulator {
+	sim, _ := SimulateOmahaContext(context.Background(), tableCards, yourCards, players, holeCards, handsToPlay, randGen, nil)
+	return sim
+}
+
+// As SimulateOmaha, but stop early if ctx is cancelled, and send periodic progress reports to progress (if non-nil).
+// If the simulation is cut short, the results of the hands played so far are returned, along with ctx.Err().
+func SimulateOmahaContext(ctx context.Context, tableCards, yourCards []poker.Card, players, holeCards, handsToPlay int, randGen *rand.Rand, progress poker.ProgressFunc) (*poker.Simulator, error) {
+	return poker.SimulateGameContext(ctx, Game{holeCards}, tableCards, yourCards, players, handsToPlay, randGen, progress)
+}
+
+// As SimulateOmaha, but split the hands between several goroutines and merge the results.
+// If workers is not positive, one worker per CPU is used. The results do not depend on the number of workers.
+func SimulateOmahaParallel(tableCards, yourCards []poker.Card, players, holeCards, handsToPlay, workers int, randGen *rand.Rand) *poker.Simulator {
+	sim, _ := SimulateOmahaParallelContext(context.Background(), tableCards, yourCards, players, holeCards, handsToPlay, workers, randGen, nil)
+	return sim
+}
+
+// As SimulateOmahaParallel, but with cancellation and progress reporting as for SimulateOmahaContext.
+func SimulateOmahaParallelContext(ctx context.Context, tableCards, yourCards []poker.Card, players, holeCards, handsToPlay, workers int, randGen *rand.Rand, progress poker.ProgressFunc) (*poker.Simulator, error) {
+	return poker.SimulateGameParallelContext(ctx, Game{holeCards}, tableCards, yourCards, players, handsToPlay, workers, randGen, progress)
+}
+
+// Simulate Omaha hands in batches until the 95% confidence interval for our pot equity has a half-width of at most
+// targetHalfWidth, or maxHands hands have been played, whichever is first.
+func SimulateOmahaAdaptive(ctx context.Context, tableCards, yourCards []poker.Card, players, holeCards int, targetHalfWidth float64, maxHands, workers int, randGen *rand.Rand, progress poker.ProgressFunc) (*poker.Simulator, error) {
+	return poker.SimulateGameAdaptive(ctx, Game{holeCards}, tableCards, yourCards, players, targetHalfWidth, maxHands, workers, randGen, progress)
+}
+
+func shuffleFixing(pack *poker.Pack, tableCards, yourCards []poker.Card, holeCards int, randGen *rand.Rand) {
+	layout(holeCards).ShuffleFixing(pack, tableCards, yourCards, randGen)
+}
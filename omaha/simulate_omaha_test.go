@@ -0,0 +1,109 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package omaha
+
+import (
+	"context"
+	"github.com/amdw/gopoker/poker"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestFixedShuffle(t *testing.T) {
+	pack := poker.NewPack()
+	randGen := rand.New(rand.NewSource(1234))
+	tableCards := h("AD", "QC", "6S")
+	yourCards := h("3S", "4C", "5D", "6H")
+	opponentHands := map[string]bool{}
+	for i := 0; i < 1000; i++ {
+		shuffleFixing(&pack, tableCards, yourCards, 4, randGen)
+		poker.TestPackPermutation(&pack, t)
+		dealtTable, dealtPlayers := Deal(&pack, 3, 4)
+		if !poker.CardsEqual(tableCards, dealtTable[:3]) || !poker.CardsEqual(yourCards, dealtPlayers[0]) {
+			t.Errorf("Expected fixed cards in place, found %v and %v", dealtTable, dealtPlayers[0])
+		}
+		opponentHands[poker.NewCardSet(dealtPlayers[1]...).String()] = true
+	}
+	if len(opponentHands) < 10 {
+		t.Errorf("Suspicious lack of randomness - only %v opponent hands seen", len(opponentHands))
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	simCount := 10000
+	players := 4
+	yourCards := h("AS", "AC", "KS", "KC")
+	sim := SimulateOmaha([]poker.Card{}, yourCards, players, 4, simCount, rand.New(rand.NewSource(1234)))
+	poker.TestAssertSimSanity(sim, players, simCount, t)
+	// Aces double-suited are a favourite against three random hands, but only a modest one
+	if equity := sim.Equity().Mean; equity < 0.3 || equity > 0.5 {
+		t.Errorf("Unexpected equity %v for %v", equity, yourCards)
+	}
+}
+
+func TestSimulateHoleCards(t *testing.T) {
+	yourCards := h("AS", "AC", "KS", "KC")
+	for holeCards := MinHoleCards; holeCards <= MaxHoleCards; holeCards++ {
+		sim := SimulateOmaha(nil, yourCards, 3, holeCards, 5000, rand.New(rand.NewSource(1234)))
+		poker.TestAssertSimSanity(sim, 3, 5000, t)
+		// We get more random cards as the hole card count goes up, but so do our opponents
+		if equity := sim.Equity().Mean; equity < 0.25 || equity > 0.6 {
+			t.Errorf("Unexpected equity %v with %v hole cards", equity, holeCards)
+		}
+	}
+
+	// All six cards known
+	yourCards = h("AS", "AC", "KS", "KC", "QS", "JC")
+	sim := SimulateOmaha(h("10S", "2D", "7H"), yourCards, 2, 6, 2000, rand.New(rand.NewSource(1234)))
+	poker.TestAssertSimSanity(sim, 2, 2000, t)
+}
+
+func TestSimulateParallel(t *testing.T) {
+	tableCards := h("2H", "5H", "8H")
+	yourCards := h("AH", "QH", "7D", "3S")
+	sim := SimulateOmahaParallel(tableCards, yourCards, 3, 4, 5000, 4, rand.New(rand.NewSource(1234)))
+	poker.TestAssertSimSanity(sim, 3, 5000, t)
+	// The results should not depend on the number of workers
+	other := SimulateOmahaParallel(tableCards, yourCards, 3, 4, 5000, 1, rand.New(rand.NewSource(1234)))
+	if !reflect.DeepEqual(sim, other) {
+		t.Errorf("Expected identical results with different numbers of workers")
+	}
+}
+
+func TestSimulateAdaptive(t *testing.T) {
+	yourCards := h("AS", "AC", "KS", "KC")
+	sim, err := SimulateOmahaAdaptive(context.Background(), nil, yourCards, 2, 4, 0.02, 100000, 0, rand.New(rand.NewSource(1234)), nil)
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+	if halfWidth := sim.Equity().HalfWidth(poker.Z95); halfWidth > 0.02 || sim.HandCount >= 100000 {
+		t.Errorf("Expected precision 0.02 well within 100000 hands, found %v after %v", halfWidth, sim.HandCount)
+	}
+}
+
+func TestSimulateCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	sim, err := SimulateOmahaContext(ctx, nil, nil, 3, 4, 1000, rand.New(rand.NewSource(1234)), nil)
+	if err != context.Canceled || sim.HandCount >= 1000 {
+		t.Errorf("Expected cancellation, found %v after %v hands", err, sim.HandCount)
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package omaha8
+
+import (
+	"github.com/amdw/gopoker/omaha"
+	"github.com/amdw/gopoker/poker"
+)
+
+// Omaha/8 with the given number of hole cards as a poker.Game. Outcomes are ranked by their high hands, and also
+// give each player's low hand and their share of the low half of the pot.
+type Game struct {
+	HoleCards int
+}
+
+func init() {
+	poker.RegisterGame("omaha8", Game{4})
+	poker.RegisterGame("bigo", Game{5})
+	poker.RegisterGame("omaha8-6", Game{6})
+}
+
+func (g Game) Name() string {
+	switch g.HoleCards {
+	case 5:
+		return "Big O"
+	case 6:
+		return "Six-card Omaha/8"
+	}
+	return "Omaha/8"
+}
+
+func (g Game) NewPack() poker.Pack {
+	return poker.NewPack()
+}
+
+func (g Game) Layout() poker.DealLayout {
+	return omaha.Game{HoleCards: g.HoleCards}.Layout()
+}
+
+func (g Game) SettlementRules() poker.SettlementRules {
+	return SettlementRules
+}
+
+func (g Game) ValidateKnownCards(tableCards, yourCards []poker.Card, players int) error {
+	return poker.ValidateGameCards(g, tableCards, yourCards, players)
+}
+
+func (g Game) Outcomes(tableCards []poker.Card, playerCards [][]poker.Card) []poker.GameOutcome {
+	outcomes := PlayerOutcomes(tableCards, playerCards)
+	result := make([]poker.GameOutcome, len(outcomes))
+	for i, o := range outcomes {
+		result[i] = poker.GameOutcome{Player: o.Player, Level: o.Level.HighLevel, Cards: o.Level.HighHand, PotFractionWon: o.PotFractionWon(),
+			Low: o.Level.LowLevel, LowCards: o.Level.LowHand, HasLow: o.Level.LowLevelQualifies, LowPotFractionWon: o.LowPotFractionWon}
+	}
+	return result
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package omaha8
+
+import (
+	"github.com/amdw/gopoker/poker"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestGameRegistration(t *testing.T) {
+	expected := map[string]string{"omaha8": "Omaha/8", "bigo": "Big O", "omaha8-6": "Six-card Omaha/8"}
+	for key, name := range expected {
+		if g, ok := poker.LookupGame(key); !ok || g.Name() != name {
+			t.Errorf("Expected %v to be registered as %q, found %v", name, key, g)
+		}
+	}
+}
+
+func TestGameOutcomes(t *testing.T) {
+	tableCards := h("AS", "2D", "8C", "KH", "9S")
+	playerCards := hs(h("AH", "3H", "KC", "QD"), h("KS", "KD", "7C", "JH"), h("4C", "5C", "QH", "JD"))
+	expected := PlayerOutcomes(tableCards, playerCards)
+	outcomes := Game{4}.Outcomes(tableCards, playerCards)
+	for i, o := range outcomes {
+		e := expected[i]
+		if o.Player != e.Player || !reflect.DeepEqual(o.Level, e.Level.HighLevel) || !reflect.DeepEqual(o.Low, e.Level.LowLevel) || o.HasLow != e.Level.LowLevelQualifies {
+			t.Errorf("Expected levels %+v for player %v, found %+v", e, i+1, o)
+		}
+		if math.Abs(o.PotFractionWon-e.PotFractionWon()) > 1e-9 || o.LowPotFractionWon != e.LowPotFractionWon {
+			t.Errorf("Expected pot fractions %v (%v low) for player %v, found %v (%v low)", e.PotFractionWon(), e.LowPotFractionWon, i+1, o.PotFractionWon, o.LowPotFractionWon)
+		}
+	}
+	if outcomes[2].LowPotFractionWon != 0.5 || outcomes[1].PotFractionWon != 0.5 {
+		t.Errorf("Expected player 3 to scoop the low and player 2 the high, found %+v", outcomes)
+	}
+}
+
+func TestGameSimulation(t *testing.T) {
+	players := 3
+	simulations := 2000
+	sim := poker.SimulateGame(Game{4}, h("AS", "2D", "8C"), h("AH", "3H", "KC", "QD"), players, simulations, rand.New(rand.NewSource(1234)))
+	assertSimSanity(sim, players, simulations, t)
+	if sim.LowQualifyCount == 0 || sim.LowPotsWon == 0 {
+		t.Errorf("Expected to make and win some lows holding A3 on an A28 flop, found %v and %v", sim.LowQualifyCount, sim.LowPotsWon)
+	}
+}
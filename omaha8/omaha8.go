@@ -20,40 +20,13 @@ along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
 package omaha8
 
 import (
+	"github.com/amdw/gopoker/omaha"
 	"github.com/amdw/gopoker/poker"
 )
 
-func Deal(pack *poker.Pack, players int) (tableCards []poker.Card, playerCards [][]poker.Card) {
-	tableCards = pack.Cards[0:5]
-	playerCards = make([][]poker.Card, players)
-	for i := 0; i < players; i++ {
-		playerCards[i] = pack.Cards[5+(i*4) : 9+(i*4)]
-	}
-	return tableCards, playerCards
-}
-
-func makePossibleCombinations(tableCards, holeCards []poker.Card) [][]poker.Card {
-	// We need exactly two cards from the four hole cards and exactly three from the table
-	possibleTableCards := poker.AllCardCombinations(tableCards, 3)
-	possibleHoleCards := poker.AllCardCombinations(holeCards, 2)
-
-	result := make([][]poker.Card, 0, len(possibleTableCards)*len(possibleHoleCards))
-
-	for _, table := range possibleTableCards {
-		for _, hole := range possibleHoleCards {
-			combination := make([]poker.Card, 5)
-			copy(combination, table)
-			copy(combination[3:], hole)
-			result = append(result, combination)
-		}
-	}
-
-	return result
-}
-
-// Only low hands which are 8-high or better qualify for consideration as the best low hand
-func lowLevelQualifies(level poker.HandLevel) bool {
-	return level.Class == poker.HighCard && poker.IsRankLess(level.Tiebreaks[0], poker.Nine, true)
+// Deal the table cards, followed by holeCards cards for each player in turn (four for Omaha/8, five for Big O)
+func Deal(pack *poker.Pack, players, holeCards int) (tableCards []poker.Card, playerCards [][]poker.Card) {
+	return omaha.Deal(pack, players, holeCards)
 }
 
 type Omaha8Level struct {
@@ -64,18 +37,18 @@ type Omaha8Level struct {
 
 // Identify an Omaha/8 hand
 func classify(tableCards, holeCards []poker.Card) Omaha8Level {
-	possibleCombinations := makePossibleCombinations(tableCards, holeCards)
+	possibleCombinations := poker.OmahaCombinations(tableCards, holeCards)
 
 	bestHighHand := possibleCombinations[0]
-	bestHighLevel := poker.ClassifyHand(bestHighHand)
+	bestHighStrength := poker.Evaluate5(bestHighHand)
 	bestLowHand := bestHighHand
 	bestLowLevel := poker.ClassifyAceToFiveLow(bestLowHand)
 
 	for i := 1; i < len(possibleCombinations); i++ {
-		highLevel := poker.ClassifyHand(possibleCombinations[i])
-		if poker.Beats(highLevel, bestHighLevel) {
+		highStrength := poker.Evaluate5(possibleCombinations[i])
+		if highStrength > bestHighStrength {
 			bestHighHand = possibleCombinations[i]
-			bestHighLevel = highLevel
+			bestHighStrength = highStrength
 		}
 
 		lowLevel := poker.ClassifyAceToFiveLow(possibleCombinations[i])
@@ -85,7 +58,7 @@ func classify(tableCards, holeCards []poker.Card) Omaha8Level {
 		}
 	}
 
-	return Omaha8Level{bestHighLevel, bestLowLevel, bestHighHand, bestLowHand, lowLevelQualifies(bestLowLevel)}
+	return Omaha8Level{bestHighStrength.Level(), bestLowLevel, bestHighHand, bestLowHand, poker.IsEightOrBetter(bestLowLevel)}
 }
 
 type PlayerOutcome struct {
@@ -119,7 +92,7 @@ func PlayerOutcomes(tableCards []poker.Card, playerCards [][]poker.Card) []Playe
 
 	highWinners := make([]int, 0, len(levels))
 	lowWinners := make([]int, 0, len(levels))
-	lowQualified := lowLevelQualifies(bestLowLevel)
+	lowQualified := poker.IsEightOrBetter(bestLowLevel)
 	result := make([]PlayerOutcome, len(levels))
 	for i, level := range levels {
 		outcome := PlayerOutcome{i + 1, level, false, false, 0, 0}
@@ -148,3 +121,19 @@ func PlayerOutcomes(tableCards []poker.Card, playerCards [][]poker.Card) []Playe
 
 	return result
 }
+
+// How the pot is divided in Omaha/8: each pot is split between the best high hand and the best eight-or-better low
+var SettlementRules = poker.SettlementRules{Ranking: poker.HighRanking, HiLo: true, LowRanking: poker.AceToFiveLowRanking}
+
+// Divide the main pot and any side pots between the players who did not fold, given how many chips each put in
+func Settle(tableCards []poker.Card, playerCards [][]poker.Card, contributions []int, folded []bool, button int) poker.Settlement {
+	stakes := make([]poker.Stake, len(playerCards))
+	for i, cards := range playerCards {
+		stakes[i] = poker.Stake{Contribution: contributions[i], Folded: folded[i], Cards: cards}
+		if !folded[i] {
+			level := classify(tableCards, cards)
+			stakes[i].High, stakes[i].Low, stakes[i].HasLow = level.HighLevel, level.LowLevel, level.LowLevelQualifies
+		}
+	}
+	return poker.Settle(SettlementRules, stakes, button)
+}
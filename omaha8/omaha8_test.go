@@ -40,7 +40,7 @@ func TestDeal(t *testing.T) {
 	pack.Shuffle(randGen)
 
 	playerCount := 6
-	tableCards, playerCards := Deal(&pack, playerCount)
+	tableCards, playerCards := Deal(&pack, playerCount, 4)
 	if len(tableCards) != 5 {
 		t.Errorf("Expected five table cards, found %v", len(tableCards))
 	}
@@ -80,6 +80,9 @@ var classifyTests = []classifyTest{
 	{board, h("AD", "3D", "6D", "9H"), hl("Straight", "10"), hl("HighCard", "7", "5", "3", "2", "A"), true},
 	{h("6S", "7S", "8C", "JD", "QH"), h("AS", "3S", "KS", "KC"), hl("OnePair", "K", "Q", "J", "8"), hl("HighCard", "8", "7", "6", "3", "A"), true},
 	{h("AS", "2S", "3S", "5S", "5C"), h("5D", "5H", "6C", "7D"), hl("FourOfAKind", "5", "A"), hl("HighCard", "6", "5", "3", "2", "A"), true},
+	// Big O: the high and low hands can use different pairs from the five hole cards
+	{board, h("AS", "4S", "KC", "KD", "10S"), hl("OnePair", "K", "10", "8", "7"), hl("HighCard", "7", "5", "4", "2", "A"), true},
+	{board, h("AS", "JS", "9C", "6D", "QH"), hl("Straight", "J"), hl("HighCard", "7", "6", "5", "2", "A"), true},
 }
 
 func TestClassify(t *testing.T) {
@@ -106,6 +109,26 @@ func TestClassify(t *testing.T) {
 	}
 }
 
+func TestClassifyCardOrder(t *testing.T) {
+	// Classifying each combination as a low hand sorts it ace-low in place, so both hands come out in descending
+	// ace-low order, just as they did when the high hands were found with ClassifyHand
+	tests := []struct {
+		tableCards, holeCards, expectedHighHand, expectedLowHand []poker.Card
+	}{
+		{board, h("AS", "4S", "5H", "KC"), h("10H", "8C", "5H", "5C", "AS"), h("7D", "5C", "4S", "2S", "AS")},
+		{h("AC", "KD", "7H", "3S", "JC"), h("AH", "QS", "2D", "8D"), h("KD", "QS", "JC", "AH", "AC"), h("8D", "7H", "3S", "2D", "AC")},
+	}
+	for _, test := range tests {
+		level := classify(test.tableCards, test.holeCards)
+		if !reflect.DeepEqual(level.HighHand, test.expectedHighHand) {
+			t.Errorf("Expected high hand %v with board %v and hole cards %v, found %v", test.expectedHighHand, test.tableCards, test.holeCards, level.HighHand)
+		}
+		if !reflect.DeepEqual(level.LowHand, test.expectedLowHand) {
+			t.Errorf("Expected low hand %v with board %v and hole cards %v, found %v", test.expectedLowHand, test.tableCards, test.holeCards, level.LowHand)
+		}
+	}
+}
+
 type outcomeTest struct {
 	tableCards          []poker.Card
 	playerCards         [][]poker.Card
@@ -197,3 +220,27 @@ func TestPlayerOutcomes(t *testing.T) {
 		}
 	}
 }
+
+func TestSettle(t *testing.T) {
+	tableCards := h("AS", "2H", "7C", "KD", "JC")
+	playerCards := hs(h("AH", "AD", "KS", "QS"), h("3C", "4D", "9H", "9S"), h("3H", "5D", "JH", "10S"), h("2C", "3S", "8D", "8H"))
+	// Seat 1 is all in for less, and seat 3 has folded
+	settlement := Settle(tableCards, playerCards, []int{100, 40, 100, 20}, []bool{false, false, false, true}, 0)
+	// Main pot of 140: 70 to the aces for high, and 70 to 7-4-3-2-A for low.
+	// Side pot of 120 between seats 0 and 2: 60 to the aces for high, and 60 to 7-5-3-2-A for low.
+	if expected := []int{130, 70, 60, 0}; !reflect.DeepEqual(expected, settlement.Winnings) {
+		t.Errorf("Expected %v, found %v", expected, settlement.Winnings)
+	}
+	if len(settlement.Pots) != 2 || settlement.Pots[0].Amount != 140 || settlement.Pots[1].Amount != 120 {
+		t.Errorf("Expected pots of 140 and 120, found %+v", settlement.Pots)
+	}
+
+	// With equal contributions, the division matches PlayerOutcomes
+	contributions := []int{100, 100, 100, 100}
+	settlement = Settle(tableCards, playerCards, contributions, make([]bool, 4), 0)
+	for i, o := range PlayerOutcomes(tableCards, playerCards) {
+		if expected := int(o.PotFractionWon() * 400); settlement.Winnings[i] != expected {
+			t.Errorf("Expected %v for player %v, found %v", expected, o.Player, settlement.Winnings[i])
+		}
+	}
+}
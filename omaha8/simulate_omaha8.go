@@ -20,111 +20,38 @@ along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
 package omaha8
 
 import (
+	"context"
 	"github.com/amdw/gopoker/poker"
 	"math/rand"
 )
 
-type Omaha8Simulator struct {
-	HighSimulator poker.Simulator
-	LowSimulator  Omaha8LowSimulator
+// Simulate hands of Omaha/8 with the given number of hole cards each: four for standard Omaha/8, five for Big O.
+// Levels are our high hands; the simulator's low statistics cover the low half of the pot.
+func SimulateOmaha8(tableCards, yourCards []poker.Card, players, holeCards, handsToPlay int, randGen *rand.Rand) *poker.Simulator {
+	sim, _ := SimulateOmaha8Context(context.Background(), tableCards, yourCards, players, holeCards, handsToPlay, randGen, nil)
+	return sim
 }
 
-func (s *Omaha8Simulator) reset(players, handsToPlay int) {
-	s.HighSimulator.Reset(players, handsToPlay)
-	s.LowSimulator.reset(handsToPlay)
+// As SimulateOmaha8, but stop early if ctx is cancelled, and send periodic progress reports to progress (if non-nil).
+// If the simulation is cut short, the results of the hands played so far are returned, along with ctx.Err().
+func SimulateOmaha8Context(ctx context.Context, tableCards, yourCards []poker.Card, players, holeCards, handsToPlay int, randGen *rand.Rand, progress poker.ProgressFunc) (*poker.Simulator, error) {
+	return poker.SimulateGameContext(ctx, Game{holeCards}, tableCards, yourCards, players, handsToPlay, randGen, progress)
 }
 
-func (s *Omaha8Simulator) processHand(playerOutcomes []PlayerOutcome, randGen *rand.Rand) {
-	randomOpponentIdx := 1 + randGen.Intn(len(playerOutcomes)-1)
-	highOutcome := calcHighOutcome(playerOutcomes, randomOpponentIdx)
-	lowOutcome := calcLowOutcome(playerOutcomes, randomOpponentIdx)
-	s.HighSimulator.ProcessHand(highOutcome)
-	s.LowSimulator.processHand(lowOutcome)
+// As SimulateOmaha8, but split the hands between several goroutines and merge the results.
+// If workers is not positive, one worker per CPU is used. The results do not depend on the number of workers.
+func SimulateOmaha8Parallel(tableCards, yourCards []poker.Card, players, holeCards, handsToPlay, workers int, randGen *rand.Rand) *poker.Simulator {
+	sim, _ := SimulateOmaha8ParallelContext(context.Background(), tableCards, yourCards, players, holeCards, handsToPlay, workers, randGen, nil)
+	return sim
 }
 
-func (s *Omaha8Simulator) PotsWon() float64 {
-	return s.HighSimulator.PotsWon + s.LowSimulator.PotsWon
+// As SimulateOmaha8Parallel, but with cancellation and progress reporting as for SimulateOmaha8Context.
+func SimulateOmaha8ParallelContext(ctx context.Context, tableCards, yourCards []poker.Card, players, holeCards, handsToPlay, workers int, randGen *rand.Rand, progress poker.ProgressFunc) (*poker.Simulator, error) {
+	return poker.SimulateGameParallelContext(ctx, Game{holeCards}, tableCards, yourCards, players, handsToPlay, workers, randGen, progress)
 }
 
-func (s *Omaha8Simulator) PotOddsBreakEven() float64 {
-	return poker.PotOddsBreakEven(s.PotsWon(), s.HighSimulator.HandCount)
-}
-
-// Over time we can expand this to track the low-hand class counts etc
-type Omaha8LowSimulator struct {
-	HandCount int
-	WinCount  int
-	PotsWon   float64
-}
-
-func (s *Omaha8LowSimulator) reset(handsToPlay int) {
-	s.HandCount = handsToPlay
-	s.WinCount = 0
-	s.PotsWon = 0
-}
-
-func (s *Omaha8LowSimulator) processHand(outcome *poker.HandOutcome) {
-	if outcome.Won {
-		s.WinCount++
-	}
-	s.PotsWon += outcome.PotFractionWon
-}
-
-func SimulateOmaha8(tableCards, yourCards []poker.Card, players, handsToPlay int, randGen *rand.Rand) *Omaha8Simulator {
-	sim := Omaha8Simulator{}
-	sim.reset(players, handsToPlay)
-
-	p := poker.NewPack()
-	for i := 0; i < handsToPlay; i++ {
-		shuffleFixing(&p, tableCards, yourCards, randGen)
-		tableCards, playerCards := Deal(&p, players)
-		playerOutcomes := PlayerOutcomes(tableCards, playerCards)
-		sim.processHand(playerOutcomes, randGen)
-	}
-
-	return &sim
-}
-
-func shuffleFixing(pack *poker.Pack, tableCards, yourCards []poker.Card, randGen *rand.Rand) {
-	// Do a regular shuffle and swap the target cards into place
-	pack.Shuffle(randGen)
-	for i, c := range tableCards {
-		swapIdx := pack.IndexOf(c)
-		pack.Cards[i], pack.Cards[swapIdx] = pack.Cards[swapIdx], pack.Cards[i]
-	}
-	for i, c := range yourCards {
-		swapIdx := pack.IndexOf(c)
-		targetIdx := 5 + i
-		pack.Cards[targetIdx], pack.Cards[swapIdx] = pack.Cards[swapIdx], pack.Cards[targetIdx]
-	}
-}
-
-func calcHighOutcome(playerOutcomes []PlayerOutcome, randomOpponentIdx int) *poker.HandOutcome {
-	ourOutcome := playerOutcomes[0]
-	var bestOpponentOutcome PlayerOutcome
-	for i := 1; i < len(playerOutcomes); i++ {
-		if i == 1 || poker.Beats(playerOutcomes[i].Level.HighLevel, bestOpponentOutcome.Level.HighLevel) {
-			bestOpponentOutcome = playerOutcomes[i]
-		}
-	}
-	randomOpponentOutcome := playerOutcomes[randomOpponentIdx]
-
-	return &poker.HandOutcome{ourOutcome.IsHighWinner, bestOpponentOutcome.IsHighWinner, randomOpponentOutcome.IsHighWinner,
-		ourOutcome.HighPotFractionWon, bestOpponentOutcome.HighPotFractionWon, randomOpponentOutcome.HighPotFractionWon,
-		ourOutcome.Level.HighLevel, bestOpponentOutcome.Level.HighLevel, randomOpponentOutcome.Level.HighLevel}
-}
-
-func calcLowOutcome(playerOutcomes []PlayerOutcome, randomOpponentIdx int) *poker.HandOutcome {
-	ourOutcome := playerOutcomes[0]
-	var bestOpponentOutcome PlayerOutcome
-	for i := 1; i < len(playerOutcomes); i++ {
-		if i == 1 || poker.BeatsAceToFiveLow(playerOutcomes[i].Level.LowLevel, bestOpponentOutcome.Level.LowLevel) {
-			bestOpponentOutcome = playerOutcomes[i]
-		}
-	}
-	randomOpponentOutcome := playerOutcomes[randomOpponentIdx]
-
-	return &poker.HandOutcome{ourOutcome.IsLowWinner, bestOpponentOutcome.IsLowWinner, randomOpponentOutcome.IsLowWinner,
-		ourOutcome.LowPotFractionWon, bestOpponentOutcome.LowPotFractionWon, randomOpponentOutcome.LowPotFractionWon,
-		ourOutcome.Level.LowLevel, bestOpponentOutcome.Level.LowLevel, randomOpponentOutcome.Level.LowLevel}
+// Simulate Omaha/8 hands in batches until the 95% confidence interval for our pot equity has a half-width of at most
+// targetHalfWidth, or maxHands hands have been played, whichever is first.
+func SimulateOmaha8Adaptive(ctx context.Context, tableCards, yourCards []poker.Card, players, holeCards int, targetHalfWidth float64, maxHands, workers int, randGen *rand.Rand, progress poker.ProgressFunc) (*poker.Simulator, error) {
+	return poker.SimulateGameAdaptive(ctx, Game{holeCards}, tableCards, yourCards, players, targetHalfWidth, maxHands, workers, randGen, progress)
 }
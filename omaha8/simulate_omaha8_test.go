@@ -20,10 +20,12 @@ along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
 package omaha8
 
 import (
+	"context"
 	"fmt"
 	"github.com/amdw/gopoker/poker"
 	"math"
 	"math/rand"
+	"reflect"
 	"testing"
 )
 
@@ -44,9 +46,9 @@ func TestFixedShuffle(t *testing.T) {
 		trackingLimit := 10
 
 		for i := 0; i < tests; i++ {
-			shuffleFixing(&pack, tcPrefix, yourCards, randGen)
+			Game{4}.Layout().ShuffleFixing(&pack, tcPrefix, yourCards, randGen)
 			poker.TestPackPermutation(&pack, t)
-			dealtTable, dealtPlayers := Deal(&pack, players)
+			dealtTable, dealtPlayers := Deal(&pack, players, 4)
 			for j := 0; j < len(tcPrefix); j++ {
 				if dealtTable[j] != tcPrefix[j] {
 					t.Errorf("Expected %v at position %v, found %v", tcPrefix[j], j, dealtTable[j])
@@ -66,10 +68,12 @@ func TestFixedShuffle(t *testing.T) {
 	}
 }
 
-func assertSimSanity(sim *Omaha8Simulator, players, simCount int, t *testing.T) {
-	poker.TestAssertSimSanity(&sim.HighSimulator, players, simCount, t)
-	totalPotsWon := sim.PotsWon()
-	poker.TestAssertPotsWonSanity(sim.HighSimulator.WinCount+sim.LowSimulator.WinCount, totalPotsWon, "us (total)", t)
+func assertSimSanity(sim *poker.Simulator, players, simCount int, t *testing.T) {
+	poker.TestAssertSimSanity(sim, players, simCount, t)
+	if sim.LowWinCount > sim.WinCount || sim.LowWinCount > sim.LowQualifyCount || sim.LowPotsWon > sim.PotsWon {
+		t.Errorf("Illogical low results %v wins, %v qualifying and %v pots, out of %v wins and %v pots", sim.LowWinCount, sim.LowQualifyCount, sim.LowPotsWon, sim.WinCount, sim.PotsWon)
+	}
+	poker.TestAssertPotsWonSanity(sim.LowWinCount, 2*sim.LowPotsWon, "us (low)", t)
 }
 
 func TestSimulate(t *testing.T) {
@@ -77,27 +81,91 @@ func TestSimulate(t *testing.T) {
 	players := 4
 	yourCards := h("AS", "QC", "3D", "4H")
 	randGen := rand.New(rand.NewSource(1234))
-	sim := SimulateOmaha8([]poker.Card{}, yourCards, players, simCount, randGen)
+	sim := SimulateOmaha8([]poker.Card{}, yourCards, players, 4, simCount, randGen)
 	assertSimSanity(sim, players, simCount, t)
 }
 
-func TestPotOdds(t *testing.T) {
-	sim := Omaha8Simulator{}
-	sim.reset(2, 2)
+func TestSimulateParallel(t *testing.T) {
+	simCount := 10001
+	players := 4
+	yourCards := h("AS", "QC", "3D", "4H")
+	randGen := rand.New(rand.NewSource(1234))
+	for _, workers := range []int{0, 1, 3} {
+		sim := SimulateOmaha8Parallel([]poker.Card{}, yourCards, players, 4, simCount, workers, randGen)
+		assertSimSanity(sim, players, simCount, t)
+	}
+}
+
+func TestSimulateCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var last poker.Progress
+	progress := func(p poker.Progress) {
+		last = p
+		if p.HandsPlayed >= 2000 {
+			cancel()
+		}
+	}
+	randGen := rand.New(rand.NewSource(1234))
+	sim, err := SimulateOmaha8ParallelContext(ctx, []poker.Card{}, h("AS", "2C", "3D", "KH"), 3, 4, 1000000, 2, randGen, progress)
+	if err != context.Canceled {
+		t.Errorf("Expected cancellation error, found %v", err)
+	}
+	if sim.HandCount >= 1000000 {
+		t.Errorf("Expected a partial hand count, found %v", sim.HandCount)
+	}
+	assertSimSanity(sim, 3, sim.HandCount, t)
+	if last.HandsPlayed < 2000 || last.PotShare() <= 0 || last.PotShare() > 1 {
+		t.Errorf("Unexpected final progress report %+v", last)
+	}
+}
+
+func TestParallelReproducibility(t *testing.T) {
+	yourCards := h("AS", "2C", "3D", "KH")
+	expected := SimulateOmaha8Parallel([]poker.Card{}, yourCards, 3, 4, 2000, 1, rand.New(rand.NewSource(1234)))
+	sim := SimulateOmaha8Parallel([]poker.Card{}, yourCards, 3, 4, 2000, 4, rand.New(rand.NewSource(1234)))
+	if !reflect.DeepEqual(expected, sim) {
+		t.Errorf("Expected identical results from identical seeds regardless of worker count")
+	}
+}
+
+func TestSimulateAdaptive(t *testing.T) {
+	target := 0.01
 	randGen := rand.New(rand.NewSource(1234))
-	highWins := Omaha8Level{hl("StraightFlush", "A"), hl("HighCard", "8", "7", "6", "5", "4"),
-		h("AS", "KS", "QS", "JS", "10S"), h("8C", "7D", "6S", "5H", "4C"), true}
-	lowWins := Omaha8Level{hl("TwoPair", "A", "K", "Q"), hl("HighCard", "6", "4", "3", "2", "A"),
-		h("AS", "AC", "KS", "KC", "QH"), h("6D", "4D", "3D", "2C", "AD"), true}
-	outcome1 := PlayerOutcome{1, highWins, true, false, 0.5, 0.0}
-	outcome2 := PlayerOutcome{2, lowWins, false, true, 0.0, 0.5}
-	sim.processHand([]PlayerOutcome{outcome1, outcome2}, randGen)
-	outcome1.Player = 2
-	outcome2.Player = 1
-	sim.processHand([]PlayerOutcome{outcome2, outcome1}, randGen)
+	sim, err := SimulateOmaha8Adaptive(context.Background(), []poker.Card{}, h("AS", "2C", "3D", "KH"), 3, 4, target, 1000000, 0, randGen, nil)
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+	assertSimSanity(sim, 3, sim.HandCount, t)
+	if hw := sim.Equity().HalfWidth(poker.Z95); hw > target || sim.HandCount >= 1000000 {
+		t.Errorf("Expected to stop once half-width was below %v, found %v after %v hands", target, hw, sim.HandCount)
+	}
+}
+
+func TestSimulateBigO(t *testing.T) {
+	yourCards := h("AS", "2C", "3D", "KH", "KS")
+	sim := SimulateOmaha8Parallel([]poker.Card{}, yourCards, 4, 5, 5000, 0, rand.New(rand.NewSource(1234)))
+	assertSimSanity(sim, 4, 5000, t)
+	if sim.LowWinCount == 0 {
+		t.Errorf("Expected some low wins in 5000 hands, found %v in %v", sim.LowWinCount, sim.HandCount)
+	}
+}
+
+func TestPotOdds(t *testing.T) {
+	sim := poker.Simulator{Ranking: poker.HighRanking}
+	sim.Reset(2, 2)
+	highWins := poker.GameOutcome{Player: 1, Level: hl("StraightFlush", "A"), Cards: h("AS", "KS", "QS", "JS", "10S"), PotFractionWon: 0.5,
+		Low: hl("HighCard", "8", "7", "6", "5", "4"), LowCards: h("8C", "7D", "6S", "5H", "4C"), HasLow: true}
+	lowWins := poker.GameOutcome{Player: 2, Level: hl("TwoPair", "A", "K", "Q"), Cards: h("AS", "AC", "KS", "KC", "QH"), PotFractionWon: 0.5,
+		Low: hl("HighCard", "6", "4", "3", "2", "A"), LowCards: h("6D", "4D", "3D", "2C", "AD"), HasLow: true, LowPotFractionWon: 0.5}
+	sim.ProcessHand(poker.NewHandOutcome([]poker.GameOutcome{highWins, lowWins}, 1, poker.HighRanking))
+	highWins.Player, lowWins.Player = 2, 1
+	sim.ProcessHand(poker.NewHandOutcome([]poker.GameOutcome{lowWins, highWins}, 1, poker.HighRanking))
 
 	breakEven := sim.PotOddsBreakEven()
 	if math.Abs(breakEven-1.0) > 1e-6 {
 		t.Errorf("Expected even pot odds, found %v", breakEven)
 	}
+	if sim.LowQualifyCount != 2 || sim.LowWinCount != 1 || sim.LowPotsWon != 0.5 {
+		t.Errorf("Expected one low win out of two qualifying lows, found %v of %v for %v pots", sim.LowWinCount, sim.LowQualifyCount, sim.LowPotsWon)
+	}
 }
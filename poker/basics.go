@@ -34,6 +34,7 @@ const (
 	Diamond
 	Spade
 	Club
+	JokerSuit // Not a real suit: jokers are represented as cards of this suit, told apart by their rank
 )
 
 func (s Suit) String() string {
@@ -46,6 +47,8 @@ func (s Suit) String() string {
 		return "S"
 	case Club:
 		return "C"
+	case JokerSuit:
+		return "JK"
 	default:
 		return fmt.Sprintf("Unknown[%v]", int(s))
 	}
@@ -62,6 +65,8 @@ func (s Suit) HTML() string {
 		return "&#9824;"
 	case Club:
 		return "&#9827;"
+	case JokerSuit:
+		return "&#127183;"
 	default:
 		return fmt.Sprintf("Unknown[%v]", int(s))
 	}
@@ -123,11 +128,24 @@ type Card struct {
 	Suit
 }
 
+// The jokers which can be added to a pack
+var Jokers = [2]Card{{Two, JokerSuit}, {Three, JokerSuit}}
+
+func (c Card) IsJoker() bool {
+	return c.Suit == JokerSuit
+}
+
 func (c Card) String() string {
+	if c.IsJoker() {
+		return fmt.Sprintf("JK%v", int(c.Rank)+1)
+	}
 	return fmt.Sprint(c.Rank.String(), c.Suit.String())
 }
 
 func (c Card) HTML() string {
+	if c.IsJoker() {
+		return fmt.Sprintf("%v%v", c.Suit.HTML(), int(c.Rank)+1)
+	}
 	return fmt.Sprintf("%v%v", c.Rank.String(), c.Suit.HTML())
 }
 
@@ -151,7 +169,7 @@ func MakeRank(r string) (Rank, error) {
 		rank = Eight
 	case "9":
 		rank = Nine
-	case "10":
+	case "10", "T":
 		rank = Ten
 	case "J":
 		rank = Jack
@@ -167,9 +185,15 @@ func MakeRank(r string) (Rank, error) {
 	return rank, nil
 }
 
-// Construct a card from text, e.g. "QD" for queen of diamonds
+// Construct a card from text, e.g. "QD" for queen of diamonds, or "JK1" and "JK2" for the jokers
 func MakeCard(c string) (Card, error) {
-	re := regexp.MustCompile("^([0123456789AJQK]+)([CDHS])$")
+	switch strings.ToUpper(c) {
+	case "JK", "JK1":
+		return Jokers[0], nil
+	case "JK2":
+		return Jokers[1], nil
+	}
+	re := regexp.MustCompile("^([0123456789AJQKT]+)([CDHS])$")
 	match := re.FindStringSubmatch(strings.ToUpper(c))
 	if match == nil {
 		return Card{}, errors.New(fmt.Sprintf("Illegally formatted card %q", c))
@@ -204,21 +228,8 @@ func C(c string) Card {
 	return card
 }
 
-// Simple linear search
 func containsAllCards(cards, testSubset []Card) bool {
-	for _, tc := range testSubset {
-		found := false
-		for _, c := range cards {
-			if c == tc {
-				found = true
-				break
-			}
-		}
-		if !found {
-			return false
-		}
-	}
-	return true
+	return NewCardSet(cards...).ContainsAll(NewCardSet(testSubset...))
 }
 
 func IsRankLess(rank1, rank2 Rank, aceLow bool) bool {
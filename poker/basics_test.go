@@ -42,6 +42,20 @@ func TestCardBasics(t *testing.T) {
 	if C("JS") != C("js") {
 		t.Errorf("Should be able to accept lower-case cards, but found %v vs %v", C("JS"), C("js"))
 	}
+	// Test the alternative notation for tens
+	if C("TS") != C("10S") || C("td") != C("10D") {
+		t.Errorf("Should be able to accept T for ten, but found %v and %v", C("TS"), C("td"))
+	}
+	// Test jokers
+	for i, j := range Jokers {
+		js := fmt.Sprintf("JK%v", i+1)
+		if C(js) != j || j.String() != js || !j.IsJoker() {
+			t.Errorf("Expected joker %q, found %q", js, C(js))
+		}
+	}
+	if C("jk") != Jokers[0] || C("AS").IsJoker() {
+		t.Errorf("Unexpected joker parsing %v", C("jk"))
+	}
 }
 
 type rankOrderTest struct {
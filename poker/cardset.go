@@ -0,0 +1,141 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package poker
+
+import (
+	"math/bits"
+	"strings"
+)
+
+// A set of cards, represented as a bitmask with one bit per card in the pack.
+// Bit i corresponds to the card with index i (see Card.Index), so all set operations are constant time.
+type CardSet uint64
+
+// The set containing every card in a standard pack
+const AllCards CardSet = 1<<52 - 1
+
+// The set containing every card in a standard pack plus both jokers
+const AllCardsAndJokers CardSet = 1<<54 - 1
+
+// The position of this card in a freshly-initialised pack, between 0 and 51 inclusive (52 and 53 for the jokers)
+func (c Card) Index() int {
+	return int(c.Suit)*13 + int(c.Rank)
+}
+
+// Inverse of Card.Index
+func CardFromIndex(i int) Card {
+	return Card{Rank(i % 13), Suit(i / 13)}
+}
+
+func (c Card) bit() CardSet {
+	return CardSet(1) << uint(c.Index())
+}
+
+// Construct a set from the given cards. Duplicates are ignored.
+func NewCardSet(cards ...Card) CardSet {
+	var result CardSet
+	for _, c := range cards {
+		result |= c.bit()
+	}
+	return result
+}
+
+func (s CardSet) Contains(c Card) bool {
+	return s&c.bit() != 0
+}
+
+// Whether every card in other is also in this set
+func (s CardSet) ContainsAll(other CardSet) bool {
+	return s&other == other
+}
+
+// Whether the two sets have any cards in common
+func (s CardSet) Intersects(other CardSet) bool {
+	return s&other != 0
+}
+
+func (s CardSet) Add(c Card) CardSet {
+	return s | c.bit()
+}
+
+func (s CardSet) Remove(c Card) CardSet {
+	return s &^ c.bit()
+}
+
+func (s CardSet) Union(other CardSet) CardSet {
+	return s | other
+}
+
+func (s CardSet) Intersection(other CardSet) CardSet {
+	return s & other
+}
+
+// All the cards in this set which are not in other
+func (s CardSet) Difference(other CardSet) CardSet {
+	return s &^ other
+}
+
+// All the cards in the pack which are not in this set
+func (s CardSet) Complement() CardSet {
+	return AllCards &^ s
+}
+
+func (s CardSet) Count() int {
+	return bits.OnesCount64(uint64(s))
+}
+
+func (s CardSet) IsEmpty() bool {
+	return s == 0
+}
+
+// Call f on each card in the set, in ascending order of index.
+// Iteration stops early if f returns false.
+func (s CardSet) ForEach(f func(Card) bool) {
+	for s != 0 {
+		i := bits.TrailingZeros64(uint64(s))
+		if !f(CardFromIndex(i)) {
+			return
+		}
+		s &= s - 1
+	}
+}
+
+// Convert the set to a slice of cards, in ascending order of index
+func (s CardSet) Cards() []Card {
+	return s.AppendTo(make([]Card, 0, s.Count()))
+}
+
+// Append the cards in the set to dst, in ascending order of index, and return the extended slice
+func (s CardSet) AppendTo(dst []Card) []Card {
+	for s != 0 {
+		dst = append(dst, CardFromIndex(bits.TrailingZeros64(uint64(s))))
+		s &= s - 1
+	}
+	return dst
+}
+
+func (s CardSet) String() string {
+	cards := s.Cards()
+	cardStrings := make([]string, len(cards))
+	for i, c := range cards {
+		cardStrings[i] = c.String()
+	}
+	return "{" + strings.Join(cardStrings, ", ") + "}"
+}
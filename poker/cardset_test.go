@@ -0,0 +1,115 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package poker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCardIndex(t *testing.T) {
+	seen := make(map[int]bool)
+	for _, c := range NewPack().Cards {
+		i := c.Index()
+		if i < 0 || i >= 52 {
+			t.Errorf("Index %v of %v out of range", i, c)
+		}
+		if seen[i] {
+			t.Errorf("Duplicate index %v for %v", i, c)
+		}
+		seen[i] = true
+		if CardFromIndex(i) != c {
+			t.Errorf("Expected %v from index %v, found %v", c, i, CardFromIndex(i))
+		}
+	}
+}
+
+func TestCardSetConversion(t *testing.T) {
+	cards := h("AS", "2C", "10H", "QD")
+	set := NewCardSet(cards...)
+	if set.Count() != len(cards) {
+		t.Errorf("Expected %v cards in set, found %v", len(cards), set.Count())
+	}
+	for _, c := range cards {
+		if !set.Contains(c) {
+			t.Errorf("Expected set %v to contain %v", set, c)
+		}
+	}
+	if set.Contains(C("AH")) {
+		t.Errorf("Set %v should not contain AH", set)
+	}
+	roundTrip := set.Cards()
+	if !CardsEqual(cards, roundTrip) {
+		t.Errorf("Expected %v after round trip, found %v", cards, roundTrip)
+	}
+	if NewCardSet(roundTrip...) != set {
+		t.Errorf("Expected %v after second round trip, found %v", set, NewCardSet(roundTrip...))
+	}
+	if NewCardSet(h("AS", "AS")...).Count() != 1 {
+		t.Errorf("Duplicates should be ignored")
+	}
+	pack := NewPack()
+	if len(AllCards.Cards()) != 52 || !CardsEqual(AllCards.Cards(), pack.Cards[:]) {
+		t.Errorf("AllCards should convert to a full pack, found %v", AllCards)
+	}
+}
+
+func TestCardSetOperations(t *testing.T) {
+	s1 := NewCardSet(h("AS", "KS", "QS")...)
+	s2 := NewCardSet(h("QS", "JS", "10S")...)
+
+	if s1.Union(s2) != NewCardSet(h("AS", "KS", "QS", "JS", "10S")...) {
+		t.Errorf("Unexpected union %v", s1.Union(s2))
+	}
+	if s1.Intersection(s2) != NewCardSet(C("QS")) {
+		t.Errorf("Unexpected intersection %v", s1.Intersection(s2))
+	}
+	if s1.Difference(s2) != NewCardSet(h("AS", "KS")...) {
+		t.Errorf("Unexpected difference %v", s1.Difference(s2))
+	}
+	if !s1.Intersects(s2) || s1.Intersects(NewCardSet(C("2C"))) {
+		t.Errorf("Intersects gave wrong answer for %v", s1)
+	}
+	if !s1.ContainsAll(NewCardSet(h("AS", "QS")...)) || s1.ContainsAll(s2) {
+		t.Errorf("ContainsAll gave wrong answer for %v", s1)
+	}
+	if s1.Add(C("JS")).Remove(C("AS")) != NewCardSet(h("KS", "QS", "JS")...) {
+		t.Errorf("Unexpected result of add and remove: %v", s1.Add(C("JS")).Remove(C("AS")))
+	}
+	complement := s1.Complement()
+	if complement.Count() != 49 || complement.Intersects(s1) || complement.Union(s1) != AllCards {
+		t.Errorf("Unexpected complement %v", complement)
+	}
+	if !CardSet(0).IsEmpty() || s1.IsEmpty() {
+		t.Errorf("IsEmpty gave wrong answer")
+	}
+}
+
+func TestCardSetForEach(t *testing.T) {
+	set := NewCardSet(h("2H", "3H", "4H", "5H")...)
+	visited := []Card{}
+	set.ForEach(func(c Card) bool {
+		visited = append(visited, c)
+		return len(visited) < 2
+	})
+	if !reflect.DeepEqual(visited, h("2H", "3H")) {
+		t.Errorf("Expected iteration to stop after two cards, found %v", visited)
+	}
+}
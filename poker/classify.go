@@ -39,6 +39,7 @@ const (
 	FullHouse
 	FourOfAKind
 	StraightFlush
+	FiveOfAKind   // Only possible with wild cards
 	MAX_HANDCLASS // Just a convenience value for iteration
 )
 
@@ -62,6 +63,8 @@ func (hc HandClass) String() string {
 		return "Four of a Kind"
 	case StraightFlush:
 		return "Straight Flush"
+	case FiveOfAKind:
+		return "Five of a Kind"
 	default:
 		return fmt.Sprintf("Unknown (%v)", int(hc))
 	}
@@ -95,6 +98,8 @@ func (hl HandLevel) String() string {
 
 func (hl HandLevel) PrettyPrint() string {
 	switch hl.Class {
+	case FiveOfAKind:
+		return fmt.Sprintf("Five %vs", hl.Tiebreaks[0])
 	case StraightFlush:
 		return fmt.Sprintf("Straight Flush: %v high", hl.Tiebreaks[0])
 	case FourOfAKind:
@@ -113,6 +118,9 @@ func (hl HandLevel) PrettyPrint() string {
 		return fmt.Sprintf("Pair %vs (plus %v, %v, %v)", hl.Tiebreaks[0], hl.Tiebreaks[1], hl.Tiebreaks[2], hl.Tiebreaks[3])
 	case HighCard:
 		return fmt.Sprintf("High card: %v", hl.PrettyTiebreaks())
+	case MAX_HANDCLASS:
+		// The minimum level of the low rankings, which no hand has
+		return "None"
 	default:
 		panic(fmt.Sprintf("Unknown class %v", hl.Class))
 	}
@@ -408,3 +416,85 @@ func BeatsAceToFiveLow(l1, l2 HandLevel) bool {
 	}
 	return false
 }
+
+// Deuce-to-seven low classification is standard classification, except that aces are always high, so A-2-3-4-5 is
+// not a straight. Straights and flushes count against you, and the lowest hand wins.
+func ClassifyDeuceToSevenLow(cards []Card) HandLevel {
+	level := ClassifyHand(cards)
+	if (level.Class == Straight || level.Class == StraightFlush) && level.Tiebreaks[0] == Five {
+		// ClassifyHand has already sorted the cards ace-high
+		if level.Class == StraightFlush {
+			result, _ := classifyFlush(cards)
+			return result
+		}
+		return classifyHighCard(cards)
+	}
+	return level
+}
+
+// Determine whether one hand beats another using the deuce-to-seven low system.
+func BeatsDeuceToSevenLow(l1, l2 HandLevel) bool {
+	return Beats(l2, l1)
+}
+
+// A way of ranking hands against each other, for games where the best hand is not necessarily the highest
+type Ranking int
+
+const (
+	HighRanking            Ranking = iota // The standard ranking, as used by Beats
+	AceToFiveLowRanking                   // The lowest hand wins, ignoring straights and flushes, as used by BeatsAceToFiveLow
+	DeuceToSevenLowRanking                // The lowest hand wins, with aces high, as used by BeatsDeuceToSevenLow
+	ShortDeckRanking                      // Short-deck poker where a straight beats three of a kind (see ShortDeckRules)
+	ShortDeckTripsRanking                 // Short-deck poker where three of a kind beats a straight
+)
+
+// Whether one hand beats another under this ranking
+func (r Ranking) Beats(l1, l2 HandLevel) bool {
+	switch r {
+	case HighRanking:
+		return Beats(l1, l2)
+	case AceToFiveLowRanking:
+		return BeatsAceToFiveLow(l1, l2)
+	case DeuceToSevenLowRanking:
+		return BeatsDeuceToSevenLow(l1, l2)
+	case ShortDeckRanking:
+		return ShortDeckRules{}.Beats(l1, l2)
+	case ShortDeckTripsRanking:
+		return ShortDeckRules{TripsBeatStraight: true}.Beats(l1, l2)
+	default:
+		panic(fmt.Sprintf("Unknown ranking %v", int(r)))
+	}
+}
+
+// A hand level beaten by any legitimate level under this ranking
+func (r Ranking) MinLevel() HandLevel {
+	switch r {
+	case HighRanking, ShortDeckRanking, ShortDeckTripsRanking:
+		return MinLevel()
+	case AceToFiveLowRanking, DeuceToSevenLowRanking:
+		// Lower classes win at low, so a class above every real one loses to every real hand, including a royal
+		// flush at deuce-to-seven
+		return HandLevel{MAX_HANDCLASS, []Rank{}}
+	default:
+		panic(fmt.Sprintf("Unknown ranking %v", int(r)))
+	}
+}
+
+// Whether a low hand qualifies under the eight-or-better rule used in hi-lo split games
+func IsEightOrBetter(level HandLevel) bool {
+	return level.Class == HighCard && IsRankLess(level.Tiebreaks[0], Nine, true)
+}
+
+// Find the best ace-to-five low hand which can be made from five of the given cards
+func BestAceToFiveLow(cards []Card) (HandLevel, []Card) {
+	combinations := AllCardCombinations(cards, 5)
+	bestHand := combinations[0]
+	bestLevel := ClassifyAceToFiveLow(bestHand)
+	for _, combination := range combinations[1:] {
+		level := ClassifyAceToFiveLow(combination)
+		if BeatsAceToFiveLow(level, bestLevel) {
+			bestHand, bestLevel = combination, level
+		}
+	}
+	return bestLevel, bestHand
+}
@@ -113,3 +113,96 @@ func TestBeatsAceToFiveLow(t *testing.T) {
 		}
 	}
 }
+
+var deuceToSevenLowClassTests = []classificationTest{
+	{h("7S", "5C", "4D", "3H", "2S"), hl("HighCard", "7", "5", "4", "3", "2")},
+	{h("5D", "4H", "3H", "2H", "AH"), hl("HighCard", "A", "5", "4", "3", "2")},
+	{h("5H", "4H", "3H", "2H", "AH"), hl("Flush", "A", "5", "4", "3", "2")},
+	{h("6D", "4H", "3H", "2H", "5H"), hl("Straight", "6")},
+	{h("7S", "5S", "4S", "3S", "2S"), hl("Flush", "7", "5", "4", "3", "2")},
+	{h("AS", "AH", "9S", "5S", "3S"), hl("OnePair", "A", "9", "5", "3")},
+	{h("KS", "AS", "QS", "JS", "10S"), hl("StraightFlush", "A")},
+}
+
+func TestDeuceToSevenLowClassification(t *testing.T) {
+	for _, test := range deuceToSevenLowClassTests {
+		level := ClassifyDeuceToSevenLow(test.cards)
+		if !reflect.DeepEqual(level, test.expectedLevel) {
+			t.Errorf("Expected %v for %v at deuce-to-seven low, found %v", test.expectedLevel, test.cards, level)
+		}
+	}
+}
+
+func TestBeatsDeuceToSevenLow(t *testing.T) {
+	testCases := []struct {
+		l1, l2   HandLevel
+		expected bool
+	}{
+		{hl("HighCard", "7", "5", "4", "3", "2"), hl("HighCard", "7", "6", "4", "3", "2"), true},
+		{hl("HighCard", "A", "5", "4", "3", "2"), hl("HighCard", "K", "Q", "J", "10", "8"), false},
+		{hl("HighCard", "K", "Q", "J", "10", "8"), hl("OnePair", "2", "5", "4", "3"), true},
+		{hl("OnePair", "2", "5", "4", "3"), hl("Straight", "6"), true},
+		{hl("Straight", "6"), hl("Straight", "6"), false},
+	}
+	for _, tc := range testCases {
+		if result := BeatsDeuceToSevenLow(tc.l1, tc.l2); result != tc.expected {
+			t.Errorf("Expected %v beats %v = %v at deuce-to-seven low, found %v", tc.l1, tc.l2, tc.expected, result)
+		}
+	}
+}
+
+func TestIsEightOrBetter(t *testing.T) {
+	testCases := []struct {
+		level    HandLevel
+		expected bool
+	}{
+		{hl("HighCard", "8", "7", "6", "5", "4"), true},
+		{hl("HighCard", "5", "4", "3", "2", "A"), true},
+		{hl("HighCard", "9", "4", "3", "2", "A"), false},
+		{hl("OnePair", "A", "4", "3", "2"), false},
+	}
+	for _, tc := range testCases {
+		if result := IsEightOrBetter(tc.level); result != tc.expected {
+			t.Errorf("Expected %v for %v, found %v", tc.expected, tc.level, result)
+		}
+	}
+}
+
+func TestBestAceToFiveLow(t *testing.T) {
+	testCases := []struct {
+		cards    []Card
+		expected HandLevel
+	}{
+		{h("KS", "AC", "2D", "2H", "4C", "QD", "8S"), hl("HighCard", "Q", "8", "4", "2", "A")},
+		{h("KS", "AC", "2D", "3H", "4C", "5D", "8S"), hl("HighCard", "5", "4", "3", "2", "A")},
+		{h("KS", "KC", "2D", "2H", "4C", "4D", "KH"), hl("TwoPair", "4", "2", "K")},
+	}
+	for _, tc := range testCases {
+		level, cards := BestAceToFiveLow(tc.cards)
+		if !reflect.DeepEqual(tc.expected, level) {
+			t.Errorf("Expected %v for %v, found %v", tc.expected, tc.cards, level)
+		}
+		if len(cards) != 5 || !NewCardSet(tc.cards...).ContainsAll(NewCardSet(cards...)) {
+			t.Errorf("Expected five of %v, found %v", tc.cards, cards)
+		}
+	}
+}
+
+func TestRanking(t *testing.T) {
+	for _, test := range aceToFiveLowBeatsTests {
+		if result := AceToFiveLowRanking.Beats(test.l1, test.l2); result != test.isGreater {
+			t.Errorf("Expected %q > %q = %v at ace-to-five low, found %v", test.l1, test.l2, test.isGreater, result)
+		}
+	}
+	if !HighRanking.Beats(hl("Flush", "A", "J", "9", "5", "3"), hl("Straight", "A")) {
+		t.Errorf("Expected flush to beat straight at high")
+	}
+	for _, r := range []Ranking{HighRanking, AceToFiveLowRanking, DeuceToSevenLowRanking} {
+		worst := r.MinLevel()
+		for _, l := range []HandLevel{hl("HighCard", "K", "Q", "J", "9", "7"), hl("HighCard", "5", "4", "3", "2", "A"), hl("FourOfAKind", "K", "Q"), hl("StraightFlush", "A")} {
+			if r.Beats(worst, l) || !r.Beats(l, worst) {
+				t.Errorf("Expected %v to beat minimum level %v under ranking %v", l, worst, r)
+			}
+		}
+	}
+}
@@ -47,12 +47,19 @@ func binomial(n, r int) int {
 	return result
 }
 
+// The number of ways of choosing r items from n
+func CombinationCount(n, r int) int {
+	if r < 0 || r > n {
+		return 0
+	}
+	return binomial(n, r)
+}
+
 // Compute all unique subsets of a set of cards, of a given size.
 func AllCardCombinations(pack []Card, numRequired int) [][]Card {
 	result := make([][]Card, 0, binomial(len(pack), numRequired))
-	indices := make([]int, numRequired)
-	// Standard algorithm to enumerate k-combinations
 	// Start with the first numRequired elements of the array
+	indices := make([]int, numRequired)
 	for i := 0; i < numRequired; i++ {
 		indices[i] = i
 	}
@@ -64,25 +71,72 @@ func AllCardCombinations(pack []Card, numRequired int) [][]Card {
 		}
 		result = append(result, combination)
 
-		// Advance to the next combination.
-		// First, find the first index, starting from the right,
-		// that's not part of a block pointing to the end of the array.
-		i := numRequired - 1
-		for i >= 0 && indices[i] == i+len(pack)-numRequired {
-			i--
-		}
-		if i < 0 {
-			// There is no such index: all indexes are at the end of the array.
-			// We've finished.
+		if !nextCombination(indices, len(pack)) {
 			break
 		}
-		// Advance that index.
-		indices[i]++
-		// Reset all indexes to the right to be the immediate successors of
-		// the index we just advanced.
-		for j := i + 1; j < numRequired; j++ {
-			indices[j] = indices[j-1] + 1
+	}
+	return result
+}
+
+// All the five-card hands which can be made in Omaha, using exactly two of the hole cards and exactly three from the table
+func OmahaCombinations(tableCards, holeCards []Card) [][]Card {
+	possibleTableCards := AllCardCombinations(tableCards, 3)
+	possibleHoleCards := AllCardCombinations(holeCards, 2)
+
+	result := make([][]Card, 0, len(possibleTableCards)*len(possibleHoleCards))
+
+	for _, table := range possibleTableCards {
+		for _, hole := range possibleHoleCards {
+			combination := make([]Card, 5)
+			copy(combination, table)
+			copy(combination[3:], hole)
+			result = append(result, combination)
 		}
 	}
+
 	return result
 }
+
+// Call f with every combination of k of the given cards in turn, stopping early if f returns false.
+// The slice passed to f is reused between calls, so must not be retained. Returns false if stopped early.
+func ForEachCombination(cards []Card, k int, f func([]Card) bool) bool {
+	if k > len(cards) {
+		return true
+	}
+	indices := make([]int, k)
+	combination := make([]Card, k)
+	for i := range indices {
+		indices[i] = i
+	}
+	for {
+		for i, idx := range indices {
+			combination[i] = cards[idx]
+		}
+		if !f(combination) {
+			return false
+		}
+		if !nextCombination(indices, len(cards)) {
+			return true
+		}
+	}
+}
+
+// Advance a k-combination of the positions 0..n-1, represented by k ascending indices, to the next
+// combination in lexicographic order. Returns false (leaving indices alone) if it was already the last.
+func nextCombination(indices []int, n int) bool {
+	k := len(indices)
+	// Find the first index, starting from the right, that's not part of a block pointing to the end of the array.
+	i := k - 1
+	for i >= 0 && indices[i] == i+n-k {
+		i--
+	}
+	if i < 0 {
+		return false
+	}
+	// Advance that index, and reset all indexes to the right to be its immediate successors.
+	indices[i]++
+	for j := i + 1; j < k; j++ {
+		indices[j] = indices[j-1] + 1
+	}
+	return true
+}
@@ -20,6 +20,7 @@ along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
 package poker
 
 import (
+	"reflect"
 	"sort"
 	"testing"
 )
@@ -101,3 +102,46 @@ func TestCardCombinations(t *testing.T) {
 		}
 	}
 }
+
+func TestForEachCombination(t *testing.T) {
+	cards := h("AS", "QD", "JC", "3C", "2H")
+	expected := AllCardCombinations(cards, 3)
+	found := [][]Card{}
+	completed := ForEachCombination(cards, 3, func(combination []Card) bool {
+		found = append(found, append([]Card{}, combination...))
+		return true
+	})
+	if !completed || !reflect.DeepEqual(expected, found) {
+		t.Errorf("Expected %v, found %v", expected, found)
+	}
+
+	count := 0
+	completed = ForEachCombination(cards, 2, func(combination []Card) bool {
+		count++
+		return count < 4
+	})
+	if completed || count != 4 {
+		t.Errorf("Expected to stop after 4 combinations, found %v (completed %v)", count, completed)
+	}
+}
+
+func TestOmahaCombinations(t *testing.T) {
+	tableCards := h("AS", "QD", "JC", "3C", "2H")
+	holeCards := h("KS", "KD", "7C", "6C")
+	combinations := OmahaCombinations(tableCards, holeCards)
+	if len(combinations) != 60 {
+		t.Errorf("Expected 60 combinations, found %v", len(combinations))
+	}
+	tableSet, holeSet := NewCardSet(tableCards...), NewCardSet(holeCards...)
+	seen := map[CardSet]bool{}
+	for _, combination := range combinations {
+		cards := NewCardSet(combination...)
+		if cards.Count() != 5 || cards.Intersection(tableSet).Count() != 3 || cards.Intersection(holeSet).Count() != 2 {
+			t.Errorf("Expected three table cards and two hole cards, found %v", combination)
+		}
+		if seen[cards] {
+			t.Errorf("Found %v twice", combination)
+		}
+		seen[cards] = true
+	}
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package poker
+
+import (
+	"fmt"
+)
+
+// The number of distinct deals in which tableSize cards are on the table and each player holds holeSize cards,
+// given that tableCards and knownPlayerCards (one entry per player) are already known.
+// This is the amount of work exhaustive enumeration would involve. A float64 is used as the number can be enormous.
+func EnumerationCost(tableCards []Card, tableSize int, knownPlayerCards [][]Card, holeSize int) float64 {
+	remaining := 52 - len(tableCards)
+	for _, cards := range knownPlayerCards {
+		remaining -= len(cards)
+	}
+	cost := 1.0
+	deal := func(cards int) {
+		cost *= float64(binomial(remaining, cards))
+		remaining -= cards
+	}
+	deal(tableSize - len(tableCards))
+	for _, cards := range knownPlayerCards {
+		deal(holeSize - len(cards))
+	}
+	return cost
+}
+
+// Every possible way of completing the table cards to tableSize cards, given that the dead cards cannot appear
+func BoardCompletions(tableCards []Card, tableSize int, dead CardSet) [][]Card {
+	available := dead.Union(NewCardSet(tableCards...)).Complement().Cards()
+	return AllCardCombinations(available, tableSize-len(tableCards))
+}
+
+// Walks through every way of completing a partially-known deal, in which some of the table cards and
+// some of each player's hole cards are known. Each complete deal is visited exactly once, in a fixed order,
+// so results obtained by enumeration are exact and reproducible.
+type DealEnumerator struct {
+	boards      [][]Card // Completions of the table cards to visit
+	board       int      // Index into boards of the current completion
+	knownTable  int      // Number of known table cards
+	tableCards  []Card   // Known table cards followed by the current completion
+	known       []int    // Number of known cards for each player
+	playerCards [][]Card // Each player's known cards followed by the current completion
+	boardFree   CardSet  // Cards neither known nor on the table in the current completion
+	pools       [][]Card // Cards available to each player, given the table and earlier players' cards
+	indices     [][]int  // Positions in pools of each player's unknown cards
+	started     bool
+}
+
+// Create an enumerator for deals in which each player holds holeSize cards, the first of which are given by
+// knownPlayerCards (which has one entry per player). Only the given completions of tableCards are visited
+// (see BoardCompletions), which allows the work to be divided up by board.
+func NewDealEnumerator(tableCards []Card, boards [][]Card, knownPlayerCards [][]Card, holeSize int) *DealEnumerator {
+	players := len(knownPlayerCards)
+	e := DealEnumerator{
+		boards:      boards,
+		knownTable:  len(tableCards),
+		known:       make([]int, players),
+		playerCards: make([][]Card, players),
+		pools:       make([][]Card, players),
+		indices:     make([][]int, players),
+	}
+	e.tableCards = append(make([]Card, 0, len(tableCards)+5), tableCards...)
+	known := NewCardSet(tableCards...)
+	unknownCount := 0
+	for p, cards := range knownPlayerCards {
+		if len(cards) > holeSize {
+			panic(fmt.Sprintf("Player %v has %v known cards but only holds %v", p+1, len(cards), holeSize))
+		}
+		e.known[p] = len(cards)
+		e.playerCards[p] = append(make([]Card, 0, holeSize), cards...)
+		e.indices[p] = make([]int, holeSize-len(cards))
+		unknownCount += holeSize - len(cards)
+		if known.Intersects(NewCardSet(cards...)) {
+			panic(fmt.Sprintf("Duplicate cards in deal: %v", cards))
+		}
+		known = known.Union(NewCardSet(cards...))
+	}
+	for _, board := range boards {
+		if NewCardSet(board...).Intersects(known) {
+			panic(fmt.Sprintf("Board completion %v contains known cards", board))
+		}
+		if 52-known.Count()-len(board) < unknownCount {
+			panic(fmt.Sprintf("Not enough cards to deal %v unknown hole cards", unknownCount))
+		}
+	}
+	e.boardFree = known.Complement()
+	return &e
+}
+
+// The number of deals the enumerator visits in total
+func (e *DealEnumerator) Size() int {
+	if len(e.boards) == 0 {
+		return 0
+	}
+	remaining := 52 - e.knownTable - len(e.boards[0])
+	for p := range e.playerCards {
+		remaining -= e.known[p]
+	}
+	perBoard := 1
+	for _, indices := range e.indices {
+		perBoard *= binomial(remaining, len(indices))
+		remaining -= len(indices)
+	}
+	return len(e.boards) * perBoard
+}
+
+// Move on to the next deal, which must be done before the first. Returns false when there are no more deals.
+func (e *DealEnumerator) Next() bool {
+	if !e.started {
+		e.started = true
+		return e.startBoard(0)
+	}
+	for p := len(e.indices) - 1; p >= 0; p-- {
+		if nextCombination(e.indices[p], len(e.pools[p])) {
+			e.fillPlayer(p)
+			e.resetPlayers(p + 1)
+			return true
+		}
+	}
+	return e.startBoard(e.board + 1)
+}
+
+// The table cards in the current deal. The slice is reused, so must not be retained after the next call to Next.
+func (e *DealEnumerator) TableCards() []Card {
+	return e.tableCards
+}
+
+// Each player's cards in the current deal. The slices are reused, so must not be retained after the next call to Next.
+func (e *DealEnumerator) PlayerCards() [][]Card {
+	return e.playerCards
+}
+
+func (e *DealEnumerator) startBoard(board int) bool {
+	if board >= len(e.boards) {
+		return false
+	}
+	if board > 0 {
+		e.boardFree = e.boardFree.Union(NewCardSet(e.boards[e.board]...))
+	}
+	e.board = board
+	e.tableCards = append(e.tableCards[:e.knownTable], e.boards[board]...)
+	e.boardFree = e.boardFree.Difference(NewCardSet(e.boards[board]...))
+	e.resetPlayers(0)
+	return true
+}
+
+// Give each player from the given one onwards their first possible holding, given the cards of earlier players
+func (e *DealEnumerator) resetPlayers(from int) {
+	available := e.boardFree
+	for p := 0; p < from; p++ {
+		available = available.Difference(NewCardSet(e.playerCards[p][e.known[p]:]...))
+	}
+	for p := from; p < len(e.indices); p++ {
+		e.pools[p] = available.AppendTo(e.pools[p][:0])
+		for i := range e.indices[p] {
+			e.indices[p][i] = i
+		}
+		e.fillPlayer(p)
+		available = available.Difference(NewCardSet(e.playerCards[p][e.known[p]:]...))
+	}
+}
+
+func (e *DealEnumerator) fillPlayer(p int) {
+	cards := e.playerCards[p][:e.known[p]]
+	for _, i := range e.indices[p] {
+		cards = append(cards, e.pools[p][i])
+	}
+	e.playerCards[p] = cards
+}
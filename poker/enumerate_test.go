@@ -0,0 +1,133 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package poker
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestEnumerationCost(t *testing.T) {
+	cases := []struct {
+		tableCards       []Card
+		knownPlayerCards [][]Card
+		expected         float64
+	}{
+		{h("KS", "7D", "AH", "8C", "8D"), [][]Card{h("9D", "7C"), nil}, 990},
+		{h("KS", "7D", "AH", "8C"), [][]Card{h("9D", "7C"), nil}, 46 * 990},
+		{h("KS", "7D", "AH", "8C", "8D"), [][]Card{h("9D", "7C"), nil, nil}, 990 * 903},
+		{h("KS", "7D", "AH", "8C", "8D"), [][]Card{h("9D"), nil}, 46 * 990},
+		{[]Card{}, [][]Card{h("AS", "AD"), nil}, 2118760 * 990},
+	}
+	for _, c := range cases {
+		actual := EnumerationCost(c.tableCards, 5, c.knownPlayerCards, 2)
+		if actual != c.expected {
+			t.Errorf("Expected cost %v for %v/%v, found %v", c.expected, c.tableCards, c.knownPlayerCards, actual)
+		}
+	}
+}
+
+// Visit every deal, checking that each is valid and distinct from all the others, and return the number of deals
+func checkDeals(e *DealEnumerator, tableCards []Card, knownPlayerCards [][]Card, t *testing.T) int {
+	seen := make(map[string]bool)
+	count := 0
+	for e.Next() {
+		count++
+		table := e.TableCards()
+		if len(table) != 5 || !CardsEqual(append([]Card{}, table[:len(tableCards)]...), append([]Card{}, tableCards...)) {
+			t.Fatalf("Bad table cards %v", table)
+		}
+		all := NewCardSet(table...)
+		key := NewCardSet(table...).String()
+		for p, cards := range e.PlayerCards() {
+			if len(cards) != 2 || !CardsEqual(append([]Card{}, cards[:len(knownPlayerCards[p])]...), append([]Card{}, knownPlayerCards[p]...)) {
+				t.Fatalf("Bad cards %v for player %v", cards, p+1)
+			}
+			hand := NewCardSet(cards...)
+			if hand.Intersects(all) {
+				t.Fatalf("Duplicate cards in deal %v / %v", table, e.PlayerCards())
+			}
+			all = all.Union(hand)
+			key += fmt.Sprintf("/%v", hand)
+		}
+		if seen[key] {
+			t.Fatalf("Deal %v visited twice", key)
+		}
+		seen[key] = true
+	}
+	return count
+}
+
+func TestDealEnumerator(t *testing.T) {
+	tableCards := h("KS", "7D", "AH", "8C")
+	knownPlayerCards := [][]Card{h("9D", "7C"), nil}
+	boards := BoardCompletions(tableCards, 5, NewCardSet(knownPlayerCards[0]...))
+	if len(boards) != 46 {
+		t.Errorf("Expected 46 board completions, found %v", len(boards))
+	}
+	e := NewDealEnumerator(tableCards, boards, knownPlayerCards, 2)
+	expected := int(EnumerationCost(tableCards, 5, knownPlayerCards, 2))
+	if e.Size() != expected {
+		t.Errorf("Expected size %v, found %v", expected, e.Size())
+	}
+	if count := checkDeals(e, tableCards, knownPlayerCards, t); count != expected {
+		t.Errorf("Expected %v deals, found %v", expected, count)
+	}
+	if e.Next() {
+		t.Errorf("Expected enumerator to stay exhausted")
+	}
+
+	// Splitting up the boards should split up the deals
+	first := NewDealEnumerator(tableCards, boards[:20], knownPlayerCards, 2)
+	second := NewDealEnumerator(tableCards, boards[20:], knownPlayerCards, 2)
+	if first.Size()+second.Size() != expected {
+		t.Errorf("Expected split sizes to add up to %v, found %v + %v", expected, first.Size(), second.Size())
+	}
+	if count := checkDeals(first, tableCards, knownPlayerCards, t) + checkDeals(second, tableCards, knownPlayerCards, t); count != expected {
+		t.Errorf("Expected %v deals in total, found %v", expected, count)
+	}
+}
+
+func TestDealEnumeratorUnknownCards(t *testing.T) {
+	// Our own cards are partly unknown, and there are two opponents
+	tableCards := h("KS", "7D", "AH", "8C", "8D")
+	knownPlayerCards := [][]Card{h("9D"), nil, nil}
+	e := NewDealEnumerator(tableCards, BoardCompletions(tableCards, 5, NewCardSet(knownPlayerCards[0]...)), knownPlayerCards, 2)
+	expected := 46 * 990 * 903
+	if e.Size() != expected || int(EnumerationCost(tableCards, 5, knownPlayerCards, 2)) != expected {
+		t.Errorf("Expected size %v, found %v", expected, e.Size())
+	}
+	count := 0
+	for e.Next() {
+		count++
+	}
+	if count != expected {
+		t.Errorf("Expected %v deals, found %v", expected, count)
+	}
+}
+
+func TestDealEnumeratorFullDeal(t *testing.T) {
+	tableCards := h("KS", "7D", "AH", "8C", "8D")
+	knownPlayerCards := [][]Card{h("9D", "7C"), h("AS", "AD")}
+	e := NewDealEnumerator(tableCards, BoardCompletions(tableCards, 5, 0), knownPlayerCards, 2)
+	if e.Size() != 1 || checkDeals(e, tableCards, knownPlayerCards, t) != 1 {
+		t.Errorf("Expected exactly one deal when all cards are known")
+	}
+}
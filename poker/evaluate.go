@@ -0,0 +1,353 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package poker
+
+import (
+	"fmt"
+	"math/bits"
+	"sort"
+)
+
+// A dense measure of the strength of a five-card poker hand. Stronger hands have higher values,
+// so two hands can be compared simply using < and ==. Values range from 1 (seven-high with no
+// straight or flush) to MaxHandStrength (a royal flush).
+type HandStrength int16
+
+// The number of distinct five-card hand strengths
+const MaxHandStrength HandStrength = 7462
+
+// One prime per rank, so that the product of the primes for a set of ranks uniquely identifies the multiset
+var rankPrimes = [13]uint32{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37, 41}
+
+// Lookup tables for hand evaluation, populated in init().
+// Hands with five distinct ranks are looked up by their 13-bit rank mask, flushes and non-flushes separately;
+// all others (those with at least one pair) are looked up by the product of their rank primes.
+var flushStrengths [1 << 13]HandStrength
+var uniqueRankStrengths [1 << 13]HandStrength
+var pairedStrengths = make(map[uint32]HandStrength, 4888)
+var strengthLevels [MaxHandStrength + 1]HandLevel
+
+func init() {
+	// Construct one representative hand for each distinct hand strength, classify them all
+	// using the regular classifier, and then sort them to find their strengths.
+	representatives := make([][]Card, 0, MaxHandStrength)
+	ranks := make([]Rank, 5)
+	var addRankCombinations func(pos int, minRank Rank)
+	addRankCombinations = func(pos int, minRank Rank) {
+		if pos == 5 {
+			if ranks[0] == ranks[4] {
+				return // Five of a kind is impossible
+			}
+			// Sorted ranks mean that equal ranks are adjacent, so they always get different suits
+			hand := make([]Card, 5)
+			for i, r := range ranks {
+				hand[i] = Card{r, Suit(i % 4)}
+			}
+			representatives = append(representatives, hand)
+			if rankMask(hand) == uniqueRankMask(hand) {
+				flush := make([]Card, 5)
+				for i, r := range ranks {
+					flush[i] = Card{r, Heart}
+				}
+				representatives = append(representatives, flush)
+			}
+			return
+		}
+		for r := minRank; r <= Ace; r++ {
+			ranks[pos] = r
+			addRankCombinations(pos+1, r)
+		}
+	}
+	addRankCombinations(0, Two)
+	if len(representatives) != int(MaxHandStrength) {
+		panic(fmt.Sprintf("Expected %v representative hands, found %v", MaxHandStrength, len(representatives)))
+	}
+
+	levels := make([]HandLevel, len(representatives))
+	for i, hand := range representatives {
+		levels[i] = ClassifyHand(hand)
+	}
+	order := make([]int, len(representatives))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		return Beats(levels[order[j]], levels[order[i]])
+	})
+
+	for i, idx := range order {
+		strength := HandStrength(i + 1)
+		hand := representatives[idx]
+		strengthLevels[strength] = levels[idx]
+		if isFlush(hand) {
+			flushStrengths[rankMask(hand)] = strength
+		} else if rankMask(hand) == uniqueRankMask(hand) {
+			uniqueRankStrengths[rankMask(hand)] = strength
+		} else {
+			pairedStrengths[rankPrimeProduct(hand)] = strength
+		}
+	}
+}
+
+func rankMask(cards []Card) uint16 {
+	var mask uint16
+	for _, c := range cards {
+		mask |= 1 << uint(c.Rank)
+	}
+	return mask
+}
+
+// The rank mask of the cards if all their ranks are distinct, otherwise zero
+func uniqueRankMask(cards []Card) uint16 {
+	var mask uint16
+	for _, c := range cards {
+		bit := uint16(1) << uint(c.Rank)
+		if mask&bit != 0 {
+			return 0
+		}
+		mask |= bit
+	}
+	return mask
+}
+
+func rankPrimeProduct(cards []Card) uint32 {
+	result := uint32(1)
+	for _, c := range cards {
+		result *= rankPrimes[c.Rank]
+	}
+	return result
+}
+
+func isFlush(cards []Card) bool {
+	for _, c := range cards[1:] {
+		if c.Suit != cards[0].Suit {
+			return false
+		}
+	}
+	return true
+}
+
+func evaluate5(c0, c1, c2, c3, c4 Card) HandStrength {
+	mask := uint16(1)<<uint(c0.Rank) | uint16(1)<<uint(c1.Rank) | uint16(1)<<uint(c2.Rank) | uint16(1)<<uint(c3.Rank) | uint16(1)<<uint(c4.Rank)
+	if c0.Suit == c1.Suit && c0.Suit == c2.Suit && c0.Suit == c3.Suit && c0.Suit == c4.Suit {
+		return flushStrengths[mask]
+	}
+	if s := uniqueRankStrengths[mask]; s != 0 {
+		return s
+	}
+	return pairedStrengths[rankPrimes[c0.Rank]*rankPrimes[c1.Rank]*rankPrimes[c2.Rank]*rankPrimes[c3.Rank]*rankPrimes[c4.Rank]]
+}
+
+// Evaluate the strength of a hand of five cards using precomputed lookup tables.
+// This gives the same ordering as ClassifyHand and Beats, but is much faster, and does not reorder the cards.
+func Evaluate5(cards []Card) HandStrength {
+	if len(cards) != 5 {
+		panic(fmt.Sprintf("Expected exactly five cards, found %v", len(cards)))
+	}
+	return evaluate5(cards[0], cards[1], cards[2], cards[3], cards[4])
+}
+
+// The full hand level corresponding to this strength, as would be returned by ClassifyHand.
+// The result is shared and must not be modified.
+func (s HandStrength) Level() HandLevel {
+	if s < 1 || s > MaxHandStrength {
+		panic(fmt.Sprintf("Illegal hand strength %v", int(s)))
+	}
+	return strengthLevels[s]
+}
+
+func (s HandStrength) Class() HandClass {
+	return s.Level().Class
+}
+
+func (s HandStrength) String() string {
+	return s.Level().String()
+}
+
+// Bit masks (indexed by rank) for all the straights, from highest to lowest
+var straightMasks = [10]uint16{
+	0x1F00, 0x0F80, 0x07C0, 0x03E0, 0x01F0, 0x00F8, 0x007C, 0x003E, 0x001F,
+	0x100F, // Ace-low
+}
+
+// Find the best straight among a set of ranks, returning its mask, or zero if there is none
+func bestStraight(rankMask uint16) uint16 {
+	for _, m := range straightMasks {
+		if rankMask&m == m {
+			return m
+		}
+	}
+	return 0
+}
+
+// Append the highest n ranks in the mask to ranks, in descending order
+func appendTopRanks(ranks []Rank, mask uint16, n int) []Rank {
+	for r := Ace; r >= Two && n > 0; r-- {
+		if mask&(1<<uint(r)) != 0 {
+			ranks = append(ranks, r)
+			n--
+		}
+	}
+	return ranks
+}
+
+// The highest rank in a non-empty mask
+func topRank(mask uint16) Rank {
+	return Rank(bits.Len16(mask) - 1)
+}
+
+// Append all the ranks in the mask to ranks, in descending order
+func appendMaskRanks(ranks []Rank, mask uint16) []Rank {
+	return appendTopRanks(ranks, mask, 13)
+}
+
+// Evaluate the strength of the best five-card hand which can be made from between five and seven cards.
+// This works directly from the rank and suit counts rather than trying every five-card subset.
+func Evaluate7(cards []Card) HandStrength {
+	return evaluate7(cards, nil)
+}
+
+// As Evaluate7, but also store a best five-card hand in best, which must have length 5.
+// The best hand is ordered with the most significant cards first (e.g. the set before the pair in a full house).
+func Evaluate7Best(cards []Card, best []Card) HandStrength {
+	if len(best) != 5 {
+		panic(fmt.Sprintf("Expected space for exactly five cards, found %v", len(best)))
+	}
+	return evaluate7(cards, best)
+}
+
+func evaluate7(cards []Card, best []Card) HandStrength {
+	if len(cards) < 5 || len(cards) > 7 {
+		panic(fmt.Sprintf("Expected between five and seven cards, found %v", len(cards)))
+	}
+	var suitMasks [4]uint16
+	var rankCounts [13]int8
+	var rankMask uint16
+	for _, c := range cards {
+		bit := uint16(1) << uint(c.Rank)
+		suitMasks[c.Suit] |= bit
+		rankMask |= bit
+		rankCounts[c.Rank]++
+	}
+
+	var chosenBuf [5]Rank
+	chosen := chosenBuf[:0]
+
+	// With at most seven cards, a flush rules out full houses and quads, so it must be the best hand
+	for suit, suitMask := range suitMasks {
+		if bits.OnesCount16(suitMask) < 5 {
+			continue
+		}
+		handMask := bestStraight(suitMask)
+		if handMask == 0 {
+			chosen = appendTopRanks(chosen, suitMask, 5)
+			for _, r := range chosen {
+				handMask |= 1 << uint(r)
+			}
+		} else {
+			chosen = appendStraightRanks(chosen, handMask)
+		}
+		if best != nil {
+			pickCards(cards, chosen, Suit(suit), true, best)
+		}
+		return flushStrengths[handMask]
+	}
+
+	var quadMask, tripMask, pairMask uint16
+	for r, count := range rankCounts {
+		switch count {
+		case 4:
+			quadMask |= 1 << uint(r)
+		case 3:
+			tripMask |= 1 << uint(r)
+		case 2:
+			pairMask |= 1 << uint(r)
+		}
+	}
+
+	unique := false
+	switch {
+	case quadMask != 0:
+		quadRank := topRank(quadMask)
+		chosen = append(chosen, quadRank, quadRank, quadRank, quadRank)
+		chosen = appendTopRanks(chosen, rankMask&^quadMask, 1)
+	case tripMask != 0 && (bits.OnesCount16(tripMask) > 1 || pairMask != 0):
+		tripRank := topRank(tripMask)
+		pairRank := topRank((tripMask | pairMask) &^ (1 << uint(tripRank)))
+		chosen = append(chosen, tripRank, tripRank, tripRank, pairRank, pairRank)
+	case bestStraight(rankMask) != 0:
+		chosen = appendStraightRanks(chosen, bestStraight(rankMask))
+		unique = true
+	case tripMask != 0:
+		tripRank := topRank(tripMask)
+		chosen = append(chosen, tripRank, tripRank, tripRank)
+		chosen = appendTopRanks(chosen, rankMask&^tripMask, 2)
+	case bits.OnesCount16(pairMask) >= 2:
+		highPair := topRank(pairMask)
+		lowPair := topRank(pairMask &^ (1 << uint(highPair)))
+		chosen = append(chosen, highPair, highPair, lowPair, lowPair)
+		chosen = appendTopRanks(chosen, rankMask&^(1<<uint(highPair))&^(1<<uint(lowPair)), 1)
+	case pairMask != 0:
+		pairRank := topRank(pairMask)
+		chosen = append(chosen, pairRank, pairRank)
+		chosen = appendTopRanks(chosen, rankMask&^pairMask, 3)
+	default:
+		chosen = appendTopRanks(chosen, rankMask, 5)
+		unique = true
+	}
+
+	if best != nil {
+		pickCards(cards, chosen, 0, false, best)
+	}
+	if unique {
+		var handMask uint16
+		for _, r := range chosen {
+			handMask |= 1 << uint(r)
+		}
+		return uniqueRankStrengths[handMask]
+	}
+	product := uint32(1)
+	for _, r := range chosen {
+		product *= rankPrimes[r]
+	}
+	return pairedStrengths[product]
+}
+
+// Append the ranks of a straight given by its mask, from highest to lowest
+func appendStraightRanks(ranks []Rank, straightMask uint16) []Rank {
+	if straightMask == straightMasks[len(straightMasks)-1] {
+		return append(ranks, Five, Four, Three, Two, Ace)
+	}
+	return appendMaskRanks(ranks, straightMask)
+}
+
+// Fill best with one distinct card from cards for each of the chosen ranks, optionally restricted to a suit
+func pickCards(cards []Card, chosen []Rank, suit Suit, suitOnly bool, best []Card) {
+	used := 0
+	for i, r := range chosen {
+		for j, c := range cards {
+			if c.Rank == r && (!suitOnly || c.Suit == suit) && used&(1<<uint(j)) == 0 {
+				best[i] = c
+				used |= 1 << uint(j)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package poker
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestEvaluateAllHands(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping exhaustive evaluation test in short mode")
+	}
+	pack := NewPack()
+	classCounts := make([]int, MAX_HANDCLASS)
+	seen := make(map[HandStrength]bool)
+	for _, hand := range AllCardCombinations(pack.Cards[:], 5) {
+		strength := Evaluate5(hand)
+		level := ClassifyHand(hand)
+		if !reflect.DeepEqual(level, strength.Level()) {
+			t.Fatalf("Expected level %v for %v, found %v (strength %v)", level, hand, strength.Level(), int(strength))
+		}
+		classCounts[strength.Class()]++
+		seen[strength] = true
+	}
+	if len(seen) != int(MaxHandStrength) {
+		t.Errorf("Expected %v distinct strengths, found %v", MaxHandStrength, len(seen))
+	}
+	expectedCounts := []int{1302540, 1098240, 123552, 54912, 10200, 5108, 3744, 624, 40, 0}
+	if !reflect.DeepEqual(expectedCounts, classCounts) {
+		t.Errorf("Expected class counts %v, found %v", expectedCounts, classCounts)
+	}
+}
+
+func TestStrengthOrdering(t *testing.T) {
+	for s := HandStrength(1); s < MaxHandStrength; s++ {
+		if !Beats((s + 1).Level(), s.Level()) {
+			t.Errorf("Expected %v to beat %v", (s + 1).Level(), s.Level())
+		}
+	}
+	if MaxHandStrength.Level().Class != StraightFlush || MaxHandStrength.Level().Tiebreaks[0] != Ace {
+		t.Errorf("Expected royal flush to be the strongest hand, found %v", MaxHandStrength)
+	}
+	if !reflect.DeepEqual(HandStrength(1).Level(), hl("HighCard", "7", "5", "4", "3", "2")) {
+		t.Errorf("Unexpected weakest hand %v", HandStrength(1))
+	}
+}
+
+func TestEvaluateDoesNotReorder(t *testing.T) {
+	hand := h("2S", "AS", "QD", "3C", "AH")
+	orig := h("2S", "AS", "QD", "3C", "AH")
+	Evaluate5(hand)
+	if !reflect.DeepEqual(hand, orig) {
+		t.Errorf("Evaluation should not reorder cards, found %v", hand)
+	}
+}
+
+// Find the best hand the slow way, by classifying every five-card subset
+func bestHandByEnumeration(cards []Card) HandLevel {
+	best := MinLevel()
+	for _, hand := range AllCardCombinations(cards, 5) {
+		level := ClassifyHand(hand)
+		if Beats(level, best) {
+			best = level
+		}
+	}
+	return best
+}
+
+func TestEvaluate7(t *testing.T) {
+	pack := NewPack()
+	randGen := rand.New(rand.NewSource(1234)) // Deterministic for repeatable tests
+	tests := 100000
+	if testing.Short() {
+		tests = 10000
+	}
+	for i := 0; i < tests; i++ {
+		pack.Shuffle(randGen)
+		cardCount := 7
+		if i%10 == 0 {
+			cardCount = 5 + i%3
+		}
+		cards := make([]Card, cardCount)
+		copy(cards, pack.Cards[:cardCount])
+
+		best := make([]Card, 5)
+		strength := Evaluate7Best(cards, best)
+		if Evaluate7(cards) != strength {
+			t.Fatalf("Evaluate7 and Evaluate7Best disagree for %v: %v vs %v", cards, Evaluate7(cards), strength)
+		}
+		expected := bestHandByEnumeration(cards)
+		if !reflect.DeepEqual(expected, strength.Level()) {
+			t.Fatalf("Expected %v for %v, found %v", expected, cards, strength.Level())
+		}
+		if !NewCardSet(cards...).ContainsAll(NewCardSet(best...)) || NewCardSet(best...).Count() != 5 {
+			t.Fatalf("Best hand %v is not five distinct cards from %v", best, cards)
+		}
+		if Evaluate5(best) != strength {
+			t.Fatalf("Best hand %v for %v evaluates to %v, expected %v", best, cards, Evaluate5(best), strength)
+		}
+	}
+}
+
+var evaluate7Tests = []classificationTest{
+	{h("2H", "3H", "4H", "5H", "AH", "6D", "7D"), hl("StraightFlush", "5")},
+	{h("2H", "3H", "4H", "5H", "AH", "6H", "7D"), hl("StraightFlush", "6")},
+	{h("AS", "AC", "AD", "AH", "KS", "KD", "KC"), hl("FourOfAKind", "A", "K")},
+	{h("QS", "QC", "QD", "JH", "JS", "JD", "2C"), hl("FullHouse", "Q", "J")},
+	{h("QS", "QC", "QD", "JH", "JS", "3D", "3C"), hl("FullHouse", "Q", "J")},
+	{h("2S", "4S", "6S", "8S", "10S", "QS", "AH"), hl("Flush", "Q", "10", "8", "6", "4")},
+	{h("AS", "2C", "3D", "4H", "5S", "9C", "9D"), hl("Straight", "5")},
+	{h("KS", "KC", "QD", "QH", "JS", "JC", "2D"), hl("TwoPair", "K", "Q", "J")},
+	{h("KS", "KC", "9D", "8H", "4S", "3C", "2D"), hl("OnePair", "K", "9", "8", "4")},
+}
+
+func TestEvaluate7Examples(t *testing.T) {
+	for _, test := range evaluate7Tests {
+		level := Evaluate7(test.cards).Level()
+		if !reflect.DeepEqual(level, test.expectedLevel) {
+			t.Errorf("Expected %v for %v, found %v", test.expectedLevel, test.cards, level)
+		}
+	}
+}
+
+func BenchmarkClassifyHand(b *testing.B) {
+	hand := h("2S", "AS", "QD", "3C", "AH")
+	for i := 0; i < b.N; i++ {
+		ClassifyHand(hand)
+	}
+}
+
+func BenchmarkEvaluate5(b *testing.B) {
+	hand := h("2S", "AS", "QD", "3C", "AH")
+	for i := 0; i < b.N; i++ {
+		Evaluate5(hand)
+	}
+}
+
+func BenchmarkEvaluate7(b *testing.B) {
+	hand := h("2S", "AS", "QD", "3C", "AH", "4H", "5S")
+	for i := 0; i < b.N; i++ {
+		Evaluate7(hand)
+	}
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package poker
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+)
+
+// How one player fared at the showdown of a hand of some game
+type GameOutcome struct {
+	Player         int       // Starting from 1
+	Level          HandLevel // The hand the player is ranked by (the high hand, in games where the pot is split hi-lo)
+	Cards          []Card    // The cards making up Level, if known
+	PotFractionWon float64   // The player's share of the whole pot, including any part of the low half
+
+	// Only used in hi-lo games
+	Low               HandLevel // The player's best low hand
+	LowCards          []Card    // The cards making up Low, if known
+	HasLow            bool      // Whether Low qualifies for the low half of the pot
+	LowPotFractionWon float64   // The part of PotFractionWon the player won with their low hand
+}
+
+// A variant of poker in which each player is dealt hole cards and shares a number of cards on the table.
+// This describes a game fully enough for it to be dealt, simulated and served over HTTP without game-specific code.
+type Game interface {
+	// The name of the game, for display
+	Name() string
+	// A pack containing the cards in play
+	NewPack() Pack
+	// Where the cards are dealt from the pack
+	Layout() DealLayout
+	// How the pot is divided at the showdown, including how the levels of the game's outcomes (and their low hands,
+	// in hi-lo games) are ranked against each other
+	SettlementRules() SettlementRules
+	// Check that the known table cards and your cards could have been dealt with the given number of players
+	ValidateKnownCards(tableCards, yourCards []Card, players int) error
+	// Assess the hand each player holds and work out how the pot is divided between them.
+	// Outcomes are in the same order as playerCards.
+	Outcomes(tableCards []Card, playerCards [][]Card) []GameOutcome
+}
+
+// Where cards are dealt from the pack: the table cards first, followed by each player's hole cards in turn
+type DealLayout struct {
+	TableCards, HoleCards int
+}
+
+// The most players who can be dealt in from the given pack
+func (l DealLayout) MaxPlayers(p *Pack) int {
+	return (p.Size() - l.TableCards) / l.HoleCards
+}
+
+// Deal the table cards and each player's hole cards from the pack
+func (l DealLayout) Deal(p *Pack, players int) (tableCards []Card, playerCards [][]Card) {
+	if players < 1 || players > l.MaxPlayers(p) {
+		panic(fmt.Sprintf("Between 1 and %v players supported, found %v", l.MaxPlayers(p), players))
+	}
+	tableCards = p.Cards[0:l.TableCards]
+	playerCards = make([][]Card, players)
+	for i := 0; i < players; i++ {
+		start := l.HoleCardsStart(i)
+		playerCards[i] = p.Cards[start : start+l.HoleCards]
+	}
+	return tableCards, playerCards
+}
+
+// Where the known table cards and the first player's known hole cards will be dealt from.
+// It is assumed that there are no duplicates among them.
+func (l DealLayout) Fixing(tableCards, yourCards []Card) Fixing {
+	if len(tableCards) > l.TableCards || len(yourCards) > l.HoleCards {
+		panic(fmt.Sprintf("Maximum of %v table cards and %v hole cards supported, found %v and %v", l.TableCards, l.HoleCards, len(tableCards), len(yourCards)))
+	}
+	result := Fixing{}
+	result.Place(0, tableCards...)
+	result.Place(l.TableCards, yourCards...)
+	return result
+}
+
+// Where the given player's hole cards are dealt from, counting players from zero
+func (l DealLayout) HoleCardsStart(player int) int {
+	return l.TableCards + player*l.HoleCards
+}
+
+// Shuffle the pack, but fix the known table cards and the first player's known hole cards where they will be dealt
+func (l DealLayout) ShuffleFixing(p *Pack, tableCards, yourCards []Card, randGen *rand.Rand) {
+	f := l.Fixing(tableCards, yourCards)
+	p.ShuffleFixed(randGen, &f)
+}
+
+// The checks every game needs on its known cards: that there are not too many of them, that they are distinct and
+// in the game's pack, and that there are between two players and as many as can be dealt in.
+func ValidateGameCards(g Game, tableCards, yourCards []Card, players int) error {
+	layout := g.Layout()
+	pack := g.NewPack()
+	if players < 2 || players > layout.MaxPlayers(&pack) {
+		return errors.New(fmt.Sprintf("Between 2 and %v players can play %v, found %v", layout.MaxPlayers(&pack), g.Name(), players))
+	}
+	if len(tableCards) > layout.TableCards {
+		return errors.New(fmt.Sprintf("Maximum of %v table cards allowed, found %v", layout.TableCards, len(tableCards)))
+	}
+	if len(yourCards) > layout.HoleCards {
+		return errors.New(fmt.Sprintf("Maximum of %v player cards allowed, found %v", layout.HoleCards, len(yourCards)))
+	}
+	seen := CardSet(0)
+	for _, cards := range [][]Card{tableCards, yourCards} {
+		for _, c := range cards {
+			if seen.Contains(c) {
+				return errors.New(fmt.Sprintf("Found duplicate card %v", c))
+			}
+			if !pack.cardSet().Contains(c) {
+				return errors.New(fmt.Sprintf("Card %v is not in the pack for %v", c, g.Name()))
+			}
+			seen = seen.Add(c)
+		}
+	}
+	return nil
+}
+
+var gameRegistry = struct {
+	sync.RWMutex
+	games map[string]Game
+}{games: make(map[string]Game)}
+
+// Make a game available under the given key, e.g. for selection over HTTP. Games normally register themselves when
+// their package is initialised. Registering the same key twice is an error.
+func RegisterGame(key string, g Game) {
+	gameRegistry.Lock()
+	defer gameRegistry.Unlock()
+	if _, ok := gameRegistry.games[key]; ok {
+		panic(fmt.Sprintf("Game %q registered twice", key))
+	}
+	gameRegistry.games[key] = g
+}
+
+// The game registered under the given key, if any
+func LookupGame(key string) (Game, bool) {
+	gameRegistry.RLock()
+	defer gameRegistry.RUnlock()
+	g, ok := gameRegistry.games[key]
+	return g, ok
+}
+
+// The keys of all registered games, in alphabetical order
+func GameKeys() []string {
+	gameRegistry.RLock()
+	defer gameRegistry.RUnlock()
+	keys := make([]string, 0, len(gameRegistry.games))
+	for key := range gameRegistry.games {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
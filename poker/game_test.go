@@ -0,0 +1,182 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package poker
+
+import (
+	"context"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Five-card showdown: no table cards and no drawing, so the best five-card hand wins
+type showdownGame struct{}
+
+func (g showdownGame) Name() string       { return "Five-card showdown" }
+func (g showdownGame) NewPack() Pack      { return NewPack() }
+func (g showdownGame) Layout() DealLayout { return DealLayout{TableCards: 0, HoleCards: 5} }
+func (g showdownGame) SettlementRules() SettlementRules {
+	return SettlementRules{Ranking: HighRanking}
+}
+func (g showdownGame) ValidateKnownCards(tableCards, yourCards []Card, players int) error {
+	return ValidateGameCards(g, tableCards, yourCards, players)
+}
+func (g showdownGame) Outcomes(tableCards []Card, playerCards [][]Card) []GameOutcome {
+	outcomes := make([]GameOutcome, len(playerCards))
+	var best HandStrength
+	for i, hand := range playerCards {
+		strength := Evaluate5(hand)
+		outcomes[i] = GameOutcome{Player: i + 1, Level: strength.Level(), Cards: hand}
+		if strength > best {
+			best = strength
+		}
+	}
+	winners := 0
+	for _, hand := range playerCards {
+		if Evaluate5(hand) == best {
+			winners++
+		}
+	}
+	for i, hand := range playerCards {
+		if Evaluate5(hand) == best {
+			outcomes[i].PotFractionWon = 1.0 / float64(winners)
+		}
+	}
+	return outcomes
+}
+
+func TestDealLayout(t *testing.T) {
+	layout := DealLayout{TableCards: 5, HoleCards: 4}
+	pack := NewPack()
+	if layout.MaxPlayers(&pack) != 11 {
+		t.Errorf("Expected 11 players maximum, found %v", layout.MaxPlayers(&pack))
+	}
+	shortPack := NewShortPack()
+	if layout.MaxPlayers(&shortPack) != 7 {
+		t.Errorf("Expected 7 players maximum from a short pack, found %v", layout.MaxPlayers(&shortPack))
+	}
+
+	randGen := rand.New(rand.NewSource(1234))
+	tableCards, yourCards := h("AS", "KD", "2C"), h("7H", "8H")
+	for i := 0; i < 100; i++ {
+		layout.ShuffleFixing(&pack, tableCards, yourCards, randGen)
+		TestPackPermutation(&pack, t)
+		onTable, playerCards := layout.Deal(&pack, 3)
+		if len(onTable) != 5 || len(playerCards) != 3 {
+			t.Fatalf("Expected 5 table cards and 3 hands, found %v and %v", onTable, playerCards)
+		}
+		if !reflect.DeepEqual(tableCards, onTable[:3]) || !reflect.DeepEqual(yourCards, playerCards[0][:2]) {
+			t.Errorf("Expected known cards to be dealt, found %v and %v", onTable, playerCards[0])
+		}
+		for i, hand := range playerCards {
+			if !reflect.DeepEqual(hand, pack.Cards[5+4*i:9+4*i]) {
+				t.Errorf("Expected player %v to be dealt %v, found %v", i+1, pack.Cards[5+4*i:9+4*i], hand)
+			}
+		}
+	}
+}
+
+func TestValidateGameCards(t *testing.T) {
+	g := showdownGame{}
+	if err := g.ValidateKnownCards(nil, h("AS", "KS"), 10); err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+	testCases := []struct {
+		tableCards, yourCards []Card
+		players               int
+		expectedError         string
+	}{
+		{nil, nil, 1, "Between 2 and 10 players"},
+		{nil, nil, 11, "Between 2 and 10 players"},
+		{h("2C"), nil, 2, "Maximum of 0 table cards"},
+		{nil, h("2C", "3C", "4C", "5C", "6C", "7C"), 2, "Maximum of 5 player cards"},
+		{nil, h("2C", "3C", "2C"), 2, "Found duplicate card 2C"},
+	}
+	for _, tc := range testCases {
+		err := g.ValidateKnownCards(tc.tableCards, tc.yourCards, tc.players)
+		if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+			t.Errorf("Expected error %q for %v, %v, %v players, found %v", tc.expectedError, tc.tableCards, tc.yourCards, tc.players, err)
+		}
+	}
+}
+
+func TestGameRegistry(t *testing.T) {
+	RegisterGame("test-showdown", showdownGame{})
+	if g, ok := LookupGame("test-showdown"); !ok || g.Name() != "Five-card showdown" {
+		t.Errorf("Expected to find registered game, found %v", g)
+	}
+	if _, ok := LookupGame("wibble"); ok {
+		t.Errorf("Expected unknown game not to be found")
+	}
+	found := false
+	for _, key := range GameKeys() {
+		found = found || key == "test-showdown"
+	}
+	if !found {
+		t.Errorf("Expected registered game in %v", GameKeys())
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected registering a game twice to panic")
+		}
+	}()
+	RegisterGame("test-showdown", showdownGame{})
+}
+
+func TestSimulateGame(t *testing.T) {
+	g := showdownGame{}
+	players := 4
+	simulations := 5000
+	sim := SimulateGame(g, nil, h("AS", "AD"), players, simulations, rand.New(rand.NewSource(1234)))
+	TestAssertSimSanity(sim, players, simulations, t)
+	if sim.OurClassCounts[HighCard] != 0 {
+		t.Errorf("Expected a pair of aces always to make at least a pair, found %v high-card hands", sim.OurClassCounts[HighCard])
+	}
+
+	expected, _ := SimulateGameParallelContext(context.Background(), g, nil, h("AS", "AD"), players, simulations, 1, rand.New(rand.NewSource(1234)), nil)
+	TestAssertSimSanity(expected, players, simulations, t)
+	for _, workers := range []int{2, 5} {
+		sim, _ := SimulateGameParallelContext(context.Background(), g, nil, h("AS", "AD"), players, simulations, workers, rand.New(rand.NewSource(1234)), nil)
+		if !reflect.DeepEqual(expected, sim) {
+			t.Errorf("Expected identical results with %v workers", workers)
+		}
+	}
+
+	target := 0.01
+	sim, err := SimulateGameAdaptive(context.Background(), g, nil, h("AS", "AD"), 2, target, 1000000, 0, rand.New(rand.NewSource(1234)), nil)
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+	TestAssertSimSanity(sim, 2, sim.HandCount, t)
+	if sim.Equity().HalfWidth(Z95) > target {
+		t.Errorf("Expected to stop once half-width was below %v, found %v after %v hands", target, sim.Equity().HalfWidth(Z95), sim.HandCount)
+	}
+}
+
+func TestSimulateGameCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	sim, err := SimulateGameParallelContext(ctx, showdownGame{}, nil, nil, 3, 100000, 0, rand.New(rand.NewSource(1234)), nil)
+	if err != context.Canceled || sim.HandCount >= 100000 {
+		t.Errorf("Expected cancelled simulation, found %v hands with error %v", sim.HandCount, err)
+	}
+}
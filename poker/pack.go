@@ -20,31 +20,113 @@ along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
 package poker
 
 import (
+	"errors"
+	"fmt"
 	"math/rand"
 )
 
+// A pack of cards. Cards holds the 52 standard cards, followed by any jokers in play. Packs share their cards when
+// copied, so each goroutine should make its own.
 type Pack struct {
-	Cards [52]Card
+	Cards  []Card
+	inPlay CardSet // The cards in play, which are always in the first positions of Cards
 }
 
-func (p *Pack) initialise() {
-	i := 0
-	for s := 0; s < 4; s++ {
-		for r := 0; r < 13; r++ {
-			p.Cards[i] = Card{Rank(r), Suit(s)}
-			i++
-		}
-	}
+// The number of cards in play, which are always at the start of Cards
+func (p *Pack) Size() int {
+	return p.inPlay.Count()
+}
+
+// The cards in play
+func (p *Pack) cardSet() CardSet {
+	return p.inPlay
 }
 
-// Shuffle the pack
+// Shuffle the cards in play
 func (p *Pack) Shuffle(randGen *rand.Rand) {
-	for i := 0; i < 52; i++ {
-		j := randGen.Intn(52-i) + i
+	size := p.Size()
+	for i := 0; i < size; i++ {
+		j := randGen.Intn(size-i) + i
 		p.Cards[i], p.Cards[j] = p.Cards[j], p.Cards[i]
 	}
 }
 
+// Shuffle the pack, but fix certain cards in place: fixed[i] will end up at positions[i],
+// and all other cards will be randomly distributed among the remaining positions.
+func (p *Pack) ShuffleFixing(randGen *rand.Rand, positions []int, fixed []Card) {
+	if len(positions) != len(fixed) {
+		panic(fmt.Sprintf("Expected one position per fixed card, found %v positions for %v cards", len(positions), len(fixed)))
+	}
+	fixedSet := NewCardSet(fixed...)
+	if fixedSet.Count() != len(fixed) {
+		panic(fmt.Sprintf("Duplicate fixed cards found in %v", fixed))
+	}
+	if !p.cardSet().ContainsAll(fixedSet) {
+		panic(fmt.Sprintf("Fixed cards %v are not all in play", fixed))
+	}
+
+	size := p.Size()
+	var isFixed [54]bool
+	for i, pos := range positions {
+		if pos >= size {
+			panic(fmt.Sprintf("Position %v is beyond the %v cards in play", pos, size))
+		}
+		if isFixed[pos] {
+			panic(fmt.Sprintf("Position %v fixed more than once", pos))
+		}
+		p.Cards[pos] = fixed[i]
+		isFixed[pos] = true
+	}
+
+	var buf [54]Card
+	remaining := p.cardSet().Difference(fixedSet).AppendTo(buf[:0])
+	for i := 0; i < len(remaining); i++ {
+		j := randGen.Intn(len(remaining)-i) + i
+		remaining[i], remaining[j] = remaining[j], remaining[i]
+	}
+	j := 0
+	for i := 0; i < size; i++ {
+		if !isFixed[i] {
+			p.Cards[i] = remaining[j]
+			j++
+		}
+	}
+}
+
+// Known cards to be fixed in place whenever a pack is shuffled for a simulation, as for Pack.ShuffleFixing:
+// Cards[i] goes at Positions[i]. Games work out where their known cards will be dealt from once, before simulating.
+type Fixing struct {
+	Positions []int
+	Cards     []Card
+}
+
+// Fix the cards at consecutive positions, starting from start
+func (f *Fixing) Place(start int, cards ...Card) {
+	for i, c := range cards {
+		f.Positions = append(f.Positions, start+i)
+		f.Cards = append(f.Cards, c)
+	}
+}
+
+// Fix the cards at the bottom of a pack of the given size, the first card last, out of the way of the deal.
+// This is where cards known to be out of play, such as the up cards of players who have folded, are put.
+func (f *Fixing) PlaceAtBottom(packSize int, cards ...Card) {
+	for i, c := range cards {
+		f.Positions = append(f.Positions, packSize-1-i)
+		f.Cards = append(f.Cards, c)
+	}
+}
+
+// Remove all but the first n fixed cards, keeping the storage for fixing others in their place
+func (f *Fixing) Truncate(n int) {
+	f.Positions, f.Cards = f.Positions[:n], f.Cards[:n]
+}
+
+// Shuffle the pack, keeping the cards of the fixing in place
+func (p *Pack) ShuffleFixed(randGen *rand.Rand, f *Fixing) {
+	p.ShuffleFixing(randGen, f.Positions, f.Cards)
+}
+
 func (p *Pack) IndexOf(card Card) int {
 	for i, c := range p.Cards {
 		if c == card {
@@ -54,8 +136,39 @@ func (p *Pack) IndexOf(card Card) int {
 	return -1
 }
 
-func NewPack() Pack {
-	var result Pack
-	result.initialise()
+// A pack with the given cards in play at the start of Cards. Any standard cards not in play are kept at the end, out
+// of the way of shuffling and dealing.
+func newPackOf(inPlay CardSet) Pack {
+	result := Pack{Cards: make([]Card, 0, AllCards.Union(inPlay).Count()), inPlay: inPlay}
+	result.Cards = AllCards.Difference(inPlay).AppendTo(inPlay.AppendTo(result.Cards))
 	return result
 }
+
+func NewPack() Pack {
+	return newPackOf(AllCards)
+}
+
+// A pack for short-deck poker, with the 36 cards from six to ace in play
+func NewShortPack() Pack {
+	return newPackOf(ShortDeckCards)
+}
+
+// A standard pack with between zero and two jokers added at the end
+func NewPackWithJokers(jokers int) Pack {
+	if jokers < 0 || jokers > len(Jokers) {
+		panic(fmt.Sprintf("Between 0 and %v jokers supported, found %v", len(Jokers), jokers))
+	}
+	return newPackOf(AllCards.Union(NewCardSet(Jokers[:jokers]...)))
+}
+
+// Check that the cards all come from a standard pack, so that none of them is a joker
+func ValidateStandardCards(cards ...[]Card) error {
+	for _, cs := range cards {
+		for _, c := range cs {
+			if !AllCards.Contains(c) {
+				return errors.New(fmt.Sprintf("Card %v is not in a standard pack", c))
+			}
+		}
+	}
+	return nil
+}
@@ -21,6 +21,7 @@ package poker
 
 import (
 	"math/rand"
+	"reflect"
 	"testing"
 )
 
@@ -53,3 +54,117 @@ func TestIndexOf(t *testing.T) {
 		t.Errorf("Suspicious lack of randomness - only indices found: %q", randCheck)
 	}
 }
+
+func TestShuffleFixing(t *testing.T) {
+	pack := NewPack()
+	randGen := rand.New(rand.NewSource(1234))
+	positions := []int{0, 1, 5, 51}
+	fixed := h("AS", "2C", "10H", "QD")
+	randCheck := make(map[Card]int)
+	for i := 0; i < 1000; i++ {
+		pack.ShuffleFixing(randGen, positions, fixed)
+		TestPackPermutation(&pack, t)
+		for j, pos := range positions {
+			if pack.Cards[pos] != fixed[j] {
+				t.Errorf("Expected %v at %v, found %v", fixed[j], pos, pack.Cards[pos])
+			}
+		}
+		randCheck[pack.Cards[2]]++
+	}
+	if len(randCheck) != 48 {
+		t.Errorf("Expected all 48 unfixed cards to appear at position 2, found %v", len(randCheck))
+	}
+}
+
+func TestFixing(t *testing.T) {
+	f := Fixing{}
+	f.Place(2, h("AS", "2C")...)
+	f.PlaceAtBottom(52, h("10H", "QD")...)
+	expected := Fixing{Positions: []int{2, 3, 51, 50}, Cards: h("AS", "2C", "10H", "QD")}
+	if !reflect.DeepEqual(expected, f) {
+		t.Errorf("Expected %v, found %v", expected, f)
+	}
+
+	pack := NewPack()
+	pack.ShuffleFixed(rand.New(rand.NewSource(1234)), &f)
+	TestPackPermutation(&pack, t)
+	for i, pos := range f.Positions {
+		if pack.Cards[pos] != f.Cards[i] {
+			t.Errorf("Expected %v at %v, found %v", f.Cards[i], pos, pack.Cards[pos])
+		}
+	}
+
+	f.Truncate(1)
+	f.Place(7, C("KS"))
+	expected = Fixing{Positions: []int{2, 7}, Cards: h("AS", "KS")}
+	if !reflect.DeepEqual(expected, f) {
+		t.Errorf("Expected %v after truncating, found %v", expected, f)
+	}
+}
+
+func TestShortPack(t *testing.T) {
+	pack := NewShortPack()
+	if pack.Size() != 36 || NewCardSet(pack.Cards[:36]...) != ShortDeckCards {
+		t.Fatalf("Expected 36 cards from six to ace in play, found %v", pack.Cards[:pack.Size()])
+	}
+	randGen := rand.New(rand.NewSource(1234))
+	positions := []int{0, 7, 35}
+	fixed := h("AS", "6C", "10H")
+	randCheck := make(map[Card]int)
+	for i := 0; i < 1000; i++ {
+		pack.Shuffle(randGen)
+		TestPackPermutation(&pack, t)
+		pack.ShuffleFixing(randGen, positions, fixed)
+		TestPackPermutation(&pack, t)
+		if inPlay := NewCardSet(pack.Cards[:36]...); inPlay != ShortDeckCards {
+			t.Fatalf("Expected only short pack cards in play, found %v", inPlay)
+		}
+		for j, pos := range positions {
+			if pack.Cards[pos] != fixed[j] {
+				t.Errorf("Expected %v at %v, found %v", fixed[j], pos, pack.Cards[pos])
+			}
+		}
+		randCheck[pack.Cards[1]]++
+	}
+	if len(randCheck) != 33 {
+		t.Errorf("Expected all 33 unfixed cards to appear at position 1, found %v", len(randCheck))
+	}
+}
+
+func TestPackWithJokers(t *testing.T) {
+	pack := NewPackWithJokers(2)
+	if pack.Size() != 54 || NewCardSet(pack.Cards[:]...) != AllCardsAndJokers {
+		t.Fatalf("Expected all 54 cards in play, found %v", pack.Cards[:pack.Size()])
+	}
+	pack = NewPackWithJokers(1)
+	if pack.Size() != 53 || len(pack.Cards) != 53 || pack.Cards[52] != Jokers[0] {
+		t.Fatalf("Expected 53 cards in play with the first joker last, found %v", pack.Cards)
+	}
+	if standard := NewPack(); len(standard.Cards) != 52 {
+		t.Errorf("Expected no jokers in a standard pack, found %v", standard.Cards)
+	}
+	randGen := rand.New(rand.NewSource(1234))
+	jokerSeen := false
+	for i := 0; i < 100; i++ {
+		pack.ShuffleFixing(randGen, []int{0}, h("JK1"))
+		TestPackPermutation(&pack, t)
+		if pack.Cards[0] != Jokers[0] {
+			t.Fatalf("Expected joker at position 0, found %v", pack.Cards[0])
+		}
+		pack.Shuffle(randGen)
+		TestPackPermutation(&pack, t)
+		jokerSeen = jokerSeen || pack.Cards[52] == Jokers[0]
+	}
+	if !jokerSeen {
+		t.Errorf("Expected joker to be shuffled to the last position in play at least once")
+	}
+}
+
+func TestValidateStandardCards(t *testing.T) {
+	if err := ValidateStandardCards(h("AS", "KD"), nil, h("2C")); err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+	if err := ValidateStandardCards(h("AS"), h("KD", "JK2")); err == nil {
+		t.Errorf("Expected error for joker")
+	}
+}
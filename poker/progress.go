@@ -0,0 +1,127 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package poker
+
+import (
+	"context"
+	"sync"
+)
+
+// How often (in hands) simulations check for cancellation
+const CancelCheckInterval = 100
+
+// How often (in hands) simulations report their progress
+const ProgressInterval = 1000
+
+// A snapshot of how far a simulation has got, and what its results look like so far
+type Progress struct {
+	HandsPlayed int
+	HandsToPlay int
+	WinCount    int     // Hands in which we won at least part of the pot
+	PotsWon     float64 // Total number of pots won, counting partial pots fractionally
+}
+
+// The fraction of the hands played so far in which we won at least part of the pot
+func (p Progress) WinRate() float64 {
+	if p.HandsPlayed == 0 {
+		return 0
+	}
+	return float64(p.WinCount) / float64(p.HandsPlayed)
+}
+
+// The average fraction of the pot we have won so far
+func (p Progress) PotShare() float64 {
+	if p.HandsPlayed == 0 {
+		return 0
+	}
+	return p.PotsWon / float64(p.HandsPlayed)
+}
+
+// The fraction of the simulation which has been completed
+func (p Progress) Fraction() float64 {
+	if p.HandsToPlay == 0 {
+		return 1
+	}
+	return float64(p.HandsPlayed) / float64(p.HandsToPlay)
+}
+
+// A function which receives progress reports from a simulation. A nil ProgressFunc means no reports are wanted.
+type ProgressFunc func(Progress)
+
+// Play up to handsToPlay hands by calling playHand repeatedly, stopping early if ctx is cancelled.
+// Every ProgressInterval hands, and once at the end, report is called (if non-nil) with the number of hands played.
+// Returns the number of hands actually played, and ctx.Err() if the simulation was cut short.
+func RunHands(ctx context.Context, handsToPlay int, playHand func(), report func(handsPlayed int)) (int, error) {
+	for i := 0; i < handsToPlay; i++ {
+		if i%CancelCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				if report != nil {
+					report(i)
+				}
+				return i, err
+			}
+		}
+		if report != nil && i > 0 && i%ProgressInterval == 0 {
+			report(i)
+		}
+		playHand()
+	}
+	if report != nil {
+		report(handsToPlay)
+	}
+	return handsToPlay, nil
+}
+
+// Combines progress reports from several simulations running concurrently (e.g. the chunks of RunParallel),
+// passing the overall totals on to a single ProgressFunc. Reports are passed on one at a time.
+type ProgressAggregator struct {
+	mutex       sync.Mutex
+	report      ProgressFunc
+	handsToPlay int
+	parts       []Progress
+}
+
+// Make an aggregator for the given number of parts, playing handsToPlay hands between them,
+// which will pass the totals on to report. Returns nil if report is nil.
+func NewProgressAggregator(parts, handsToPlay int, report ProgressFunc) *ProgressAggregator {
+	if report == nil {
+		return nil
+	}
+	return &ProgressAggregator{report: report, handsToPlay: handsToPlay, parts: make([]Progress, parts)}
+}
+
+// A ProgressFunc for one of the parts being aggregated. Returns nil if the aggregator is nil.
+func (a *ProgressAggregator) Part(part int) ProgressFunc {
+	if a == nil {
+		return nil
+	}
+	return func(p Progress) {
+		a.mutex.Lock()
+		defer a.mutex.Unlock()
+		a.parts[part] = p
+		total := Progress{HandsToPlay: a.handsToPlay}
+		for _, partProgress := range a.parts {
+			total.HandsPlayed += partProgress.HandsPlayed
+			total.WinCount += partProgress.WinCount
+			total.PotsWon += partProgress.PotsWon
+		}
+		a.report(total)
+	}
+}
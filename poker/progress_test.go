@@ -0,0 +1,82 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package poker
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestRunHands(t *testing.T) {
+	played := 0
+	reports := []int{}
+	n, err := RunHands(context.Background(), 2500, func() { played++ }, func(handsPlayed int) {
+		if handsPlayed != played {
+			t.Errorf("Reported %v hands played but actually played %v", handsPlayed, played)
+		}
+		reports = append(reports, handsPlayed)
+	})
+	if err != nil || n != 2500 || played != 2500 {
+		t.Errorf("Expected 2500 hands with no error, found %v (%v played) and %v", n, played, err)
+	}
+	if !reflect.DeepEqual(reports, []int{1000, 2000, 2500}) {
+		t.Errorf("Unexpected progress reports %v", reports)
+	}
+}
+
+func TestRunHandsCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	played := 0
+	lastReport := -1
+	n, err := RunHands(ctx, 100000, func() {
+		played++
+		if played == 1234 {
+			cancel()
+		}
+	}, func(handsPlayed int) { lastReport = handsPlayed })
+	if err != context.Canceled {
+		t.Errorf("Expected cancellation error, found %v", err)
+	}
+	if n != played || n < 1234 || n > 1234+CancelCheckInterval {
+		t.Errorf("Expected to stop promptly after cancellation, but played %v (reported %v)", played, n)
+	}
+	if lastReport != n {
+		t.Errorf("Expected final progress report of %v, found %v", n, lastReport)
+	}
+}
+
+func TestProgressAggregator(t *testing.T) {
+	if NewProgressAggregator(2, 100, nil).Part(0) != nil {
+		t.Errorf("Expected nil progress function when no reports are wanted")
+	}
+	var last Progress
+	aggregator := NewProgressAggregator(2, 100, func(p Progress) { last = p })
+	aggregator.Part(0)(Progress{10, 50, 4, 3.5})
+	aggregator.Part(1)(Progress{20, 50, 6, 5.5})
+	aggregator.Part(0)(Progress{30, 50, 8, 7.0})
+	expected := Progress{50, 100, 14, 12.5}
+	if last != expected {
+		t.Errorf("Expected aggregate progress %v, found %v", expected, last)
+	}
+	if last.WinRate() != 14.0/50 || last.PotShare() != 12.5/50 || last.Fraction() != 0.5 {
+		t.Errorf("Unexpected derived figures %v, %v, %v", last.WinRate(), last.PotShare(), last.Fraction())
+	}
+}
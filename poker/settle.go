@@ -0,0 +1,183 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package poker
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Who gets the chips left over when a pot does not divide evenly between its winners
+type OddChipRule int8
+
+const (
+	OddChipsLeftOfButton OddChipRule = iota // One each to the winners first after the button
+	OddChipsHighCard                        // One each to the winners holding the highest cards, by rank and then suit
+)
+
+// How the pot is divided at the end of a hand
+type SettlementRules struct {
+	Ranking    Ranking // How high hands are compared
+	HiLo       bool    // Whether each pot is split between the best high hand and the best qualifying low hand
+	LowRanking Ranking // How low hands are compared, in hi-lo games
+	OddChips   OddChipRule
+}
+
+// A player's part in the settlement of a hand. The hands of players who folded are ignored.
+type Stake struct {
+	Contribution int // All the chips the player put into the pot
+	Folded       bool
+	High         HandLevel
+	Low          HandLevel
+	HasLow       bool   // Whether the player has a qualifying low hand, in hi-lo games
+	Cards        []Card // The player's cards, for OddChipsHighCard
+}
+
+// The main pot or a side pot. Seats are indices into the stakes the pot was settled from.
+type Pot struct {
+	Amount      int
+	Eligible    []int // The seats of the players contesting this pot
+	HighWinners []int
+	LowWinners  []int // Empty if there was no qualifying low hand, or the game is not hi-lo
+	Won         []int // The chips each seat won from this pot
+}
+
+// The division of the pot at the end of a hand
+type Settlement struct {
+	Pots     []Pot // The main pot first, then the side pots in order
+	Winnings []int // The chips each seat won, over all the pots
+}
+
+// Suits in ascending order for breaking ties between cards of the same rank, as is usual in stud games
+var suitOrder = map[Suit]int{Club: 0, Diamond: 1, Heart: 2, Spade: 3}
+
+// Divide the pot between the players who did not fold. Each player who is all in for less than the others can only
+// win a share of the chips matching what they put in (the main pot); the rest goes into side pots contested by the
+// players who put in more. In hi-lo games, any odd chip from halving a pot goes to the high hand.
+func Settle(rules SettlementRules, stakes []Stake, button int) Settlement {
+	var caps []int
+	for _, s := range stakes {
+		if !s.Folded {
+			caps = append(caps, s.Contribution)
+		}
+	}
+	if len(caps) == 0 {
+		panic(fmt.Sprintf("No players left in to settle %v", stakes))
+	}
+	sort.Ints(caps)
+	distinct := 1
+	for _, c := range caps[1:] {
+		if c != caps[distinct-1] {
+			caps[distinct] = c
+			distinct++
+		}
+	}
+	caps = caps[:distinct]
+
+	result := Settlement{Winnings: make([]int, len(stakes))}
+	prevCap := 0
+	for i, potCap := range caps {
+		pot := Pot{Won: make([]int, len(stakes))}
+		for seat, s := range stakes {
+			if s.Contribution > prevCap {
+				pot.Amount += min(s.Contribution, potCap) - prevCap
+			}
+			if i == len(caps)-1 && s.Contribution > potCap {
+				pot.Amount += s.Contribution - potCap // Chips from folded players beyond what anyone still in put in
+			}
+			if !s.Folded && s.Contribution >= potCap {
+				pot.Eligible = append(pot.Eligible, seat)
+			}
+		}
+		prevCap = potCap
+		if pot.Amount == 0 {
+			continue
+		}
+
+		pot.HighWinners = bestStakes(pot.Eligible, stakes, rules.Ranking, func(s Stake) (HandLevel, bool) { return s.High, true })
+		if rules.HiLo {
+			pot.LowWinners = bestStakes(pot.Eligible, stakes, rules.LowRanking, func(s Stake) (HandLevel, bool) { return s.Low, s.HasLow })
+		}
+		if len(pot.LowWinners) == 0 {
+			rules.shareOut(pot.Amount, pot.HighWinners, stakes, button, pot.Won)
+		} else {
+			lowHalf := pot.Amount / 2
+			rules.shareOut(pot.Amount-lowHalf, pot.HighWinners, stakes, button, pot.Won)
+			rules.shareOut(lowHalf, pot.LowWinners, stakes, button, pot.Won)
+		}
+		for seat, won := range pot.Won {
+			result.Winnings[seat] += won
+		}
+		result.Pots = append(result.Pots, pot)
+	}
+	return result
+}
+
+// The seats with the best of the given hands, in seat order. Seats without a hand are ignored.
+func bestStakes(seats []int, stakes []Stake, ranking Ranking, hand func(Stake) (HandLevel, bool)) []int {
+	var result []int
+	var best HandLevel
+	for _, seat := range seats {
+		level, ok := hand(stakes[seat])
+		if !ok {
+			continue
+		}
+		if len(result) > 0 && ranking.Beats(level, best) {
+			result = result[:0]
+		}
+		if len(result) == 0 || !ranking.Beats(best, level) {
+			result = append(result, seat)
+			best = level
+		}
+	}
+	return result
+}
+
+// Divide the chips equally between the winners, adding them to won, and give out any odd chips under these rules
+func (rules SettlementRules) shareOut(chips int, winners []int, stakes []Stake, button int, won []int) {
+	ordered := append([]int{}, winners...)
+	players := len(stakes)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if rules.OddChips == OddChipsHighCard {
+			ci, cj := highCard(stakes[ordered[i]].Cards), highCard(stakes[ordered[j]].Cards)
+			if ci != cj {
+				return ci > cj
+			}
+		}
+		return (ordered[i]-button+players-1)%players < (ordered[j]-button+players-1)%players
+	})
+	for i, seat := range ordered {
+		won[seat] += chips / len(ordered)
+		if i < chips%len(ordered) {
+			won[seat]++
+		}
+	}
+}
+
+// A number which orders cards by rank and then suit, or -1 if there are no cards
+func highCard(cards []Card) int {
+	result := -1
+	for _, c := range cards {
+		if value := int(c.Rank)*len(suitOrder) + suitOrder[c.Suit]; value > result {
+			result = value
+		}
+	}
+	return result
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package poker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSettleSidePots(t *testing.T) {
+	stakes := []Stake{
+		{Contribution: 50, High: hl("OnePair", "A", "9", "7", "2")},
+		{Contribution: 100, High: hl("OnePair", "K", "9", "7", "2")},
+		{Contribution: 100, High: hl("OnePair", "Q", "9", "7", "2")},
+		{Contribution: 30, Folded: true, High: hl("StraightFlush", "A")},
+	}
+	s := Settle(SettlementRules{}, stakes, 0)
+	if !reflect.DeepEqual([]int{180, 100, 0, 0}, s.Winnings) {
+		t.Errorf("Expected aces to win the main pot and kings the side pot, found %v", s.Winnings)
+	}
+	if len(s.Pots) != 2 || s.Pots[0].Amount != 180 || s.Pots[1].Amount != 100 {
+		t.Fatalf("Expected pots of 180 and 100, found %+v", s.Pots)
+	}
+	if !reflect.DeepEqual([]int{0, 1, 2}, s.Pots[0].Eligible) || !reflect.DeepEqual([]int{1, 2}, s.Pots[1].Eligible) {
+		t.Errorf("Unexpected eligibility %v and %v", s.Pots[0].Eligible, s.Pots[1].Eligible)
+	}
+	if !reflect.DeepEqual([]int{0}, s.Pots[0].HighWinners) || !reflect.DeepEqual([]int{1}, s.Pots[1].HighWinners) || s.Pots[0].LowWinners != nil {
+		t.Errorf("Unexpected winners %+v", s.Pots)
+	}
+
+	// A folded player's chips beyond what anyone still in put in go to the last pot
+	stakes = []Stake{
+		{Contribution: 40, Folded: true},
+		{Contribution: 20, High: hl("HighCard", "A", "9", "7", "4", "2")},
+		{Contribution: 20, High: hl("HighCard", "K", "9", "7", "4", "2")},
+	}
+	if s := Settle(SettlementRules{}, stakes, 0); !reflect.DeepEqual([]int{0, 80, 0}, s.Winnings) || len(s.Pots) != 1 {
+		t.Errorf("Expected seat 1 to win everything, found %+v", s)
+	}
+}
+
+func TestSettleOddChips(t *testing.T) {
+	stakes := []Stake{
+		{Contribution: 2, High: hl("Straight", "A"), Cards: h("2C", "3C")},
+		{Contribution: 1, Folded: true},
+		{Contribution: 2, High: hl("Straight", "A"), Cards: h("2S", "3D")},
+	}
+	testCases := []struct {
+		rules    SettlementRules
+		button   int
+		expected []int
+	}{
+		{SettlementRules{}, 0, []int{2, 0, 3}},
+		{SettlementRules{}, 2, []int{3, 0, 2}},
+		{SettlementRules{OddChips: OddChipsHighCard}, 0, []int{2, 0, 3}},
+		{SettlementRules{OddChips: OddChipsHighCard}, 2, []int{2, 0, 3}},
+	}
+	for _, tc := range testCases {
+		if s := Settle(tc.rules, stakes, tc.button); !reflect.DeepEqual(tc.expected, s.Winnings) {
+			t.Errorf("Expected %v for %+v with button %v, found %v", tc.expected, tc.rules, tc.button, s.Winnings)
+		}
+	}
+}
+
+func TestSettleHiLo(t *testing.T) {
+	rules := SettlementRules{HiLo: true, LowRanking: AceToFiveLowRanking}
+	low := ClassifyAceToFiveLow(h("AS", "2D", "3C", "4H", "6S"))
+	stakes := []Stake{
+		{Contribution: 51, High: hl("Flush", "A", "K", "9", "7", "2")},
+		{Contribution: 100, High: hl("OnePair", "K", "9", "7", "2"), Low: low, HasLow: true},
+		{Contribution: 100, High: hl("OnePair", "Q", "9", "7", "2"), Low: low, HasLow: false},
+	}
+	s := Settle(rules, stakes, 0)
+	// The main pot of 153 is split 77 high and 76 low; the side pot of 98 is scooped by seat 1
+	if !reflect.DeepEqual([]int{77, 76 + 98, 0}, s.Winnings) {
+		t.Errorf("Unexpected winnings %v", s.Winnings)
+	}
+	if !reflect.DeepEqual([]int{1}, s.Pots[0].LowWinners) || !reflect.DeepEqual([]int{1}, s.Pots[1].LowWinners) {
+		t.Errorf("Unexpected low winners %+v", s.Pots)
+	}
+
+	// Two equal lows are quartered, and with no qualifying low the high hand scoops
+	stakes[0].Contribution, stakes[2].HasLow = 100, true
+	if s := Settle(rules, stakes, 0); !reflect.DeepEqual([]int{150, 75, 75}, s.Winnings) {
+		t.Errorf("Expected the low half to be quartered, found %v", s.Winnings)
+	}
+	stakes[1].HasLow, stakes[2].HasLow = false, false
+	if s := Settle(rules, stakes, 0); !reflect.DeepEqual([]int{300, 0, 0}, s.Winnings) || s.Pots[0].LowWinners != nil {
+		t.Errorf("Expected the high hand to scoop, found %v", s.Winnings)
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package poker
+
+// The cards in a short (six-plus) pack: a standard pack without the twos, threes, fours and fives
+const ShortDeckCards CardSet = shortDeckSuit | shortDeckSuit<<13 | shortDeckSuit<<26 | shortDeckSuit<<39
+
+// The cards from six to ace of a single suit
+const shortDeckSuit CardSet = 1<<13 - 1<<Six
+
+// How hands rank in short-deck poker. A-6-7-8-9 is the lowest straight, and a flush beats a full house, as it is
+// harder to make with only nine cards of each suit.
+type ShortDeckRules struct {
+	TripsBeatStraight bool // Whether three of a kind beats a straight, as in many games, rather than the other way round
+}
+
+// The Ranking corresponding to these rules
+func (r ShortDeckRules) Ranking() Ranking {
+	if r.TripsBeatStraight {
+		return ShortDeckTripsRanking
+	}
+	return ShortDeckRanking
+}
+
+// Where a class of hand comes in the order of classes under these rules
+func (r ShortDeckRules) classOrder(class HandClass) HandClass {
+	switch class {
+	case Flush:
+		return FullHouse
+	case FullHouse:
+		return Flush
+	case ThreeOfAKind:
+		if r.TripsBeatStraight {
+			return Straight
+		}
+	case Straight:
+		if r.TripsBeatStraight {
+			return ThreeOfAKind
+		}
+	}
+	return class
+}
+
+// Whether one hand beats another under these rules
+func (r ShortDeckRules) Beats(l1, l2 HandLevel) bool {
+	if l1.Class != l2.Class {
+		return r.classOrder(l1.Class) > r.classOrder(l2.Class)
+	}
+	return Beats(l1, l2)
+}
+
+// The best hand under these rules which can be made from five or more cards from a short pack.
+// If best is not nil, the five cards making up the hand are copied into it.
+func (r ShortDeckRules) BestHand(cards []Card, best []Card) HandLevel {
+	var bestLevel HandLevel
+	first := true
+	ForEachCombination(cards, 5, func(combination []Card) bool {
+		level := ClassifyShortDeckHand(combination)
+		if first || r.Beats(level, bestLevel) {
+			bestLevel = level
+			first = false
+			if best != nil {
+				copy(best, combination)
+			}
+		}
+		return true
+	})
+	return bestLevel
+}
+
+var shortDeckLowStraightRanks = []Rank{Ace, Nine, Eight, Seven, Six}
+var shortDeckLowStraight = HandLevel{Straight, []Rank{Nine}}
+var shortDeckLowStraightFlush = HandLevel{StraightFlush, []Rank{Nine}}
+
+// Classify five cards from a short pack, where the ace plays low in the straight A-6-7-8-9. Unlike ClassifyHand, this
+// leaves the cards in their original order. The result may be shared, so must not be modified.
+func ClassifyShortDeckHand(cards []Card) HandLevel {
+	level := Evaluate5(cards).Level()
+	if level.Class != HighCard && level.Class != Flush {
+		return level
+	}
+	for i, r := range shortDeckLowStraightRanks {
+		if level.Tiebreaks[i] != r {
+			return level
+		}
+	}
+	if level.Class == Flush {
+		return shortDeckLowStraightFlush
+	}
+	return shortDeckLowStraight
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package poker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestShortDeckCards(t *testing.T) {
+	if ShortDeckCards.Count() != 36 || ShortDeckCards.Contains(C("5S")) || !ShortDeckCards.Contains(C("6S")) || !ShortDeckCards.Contains(C("AC")) {
+		t.Errorf("Unexpected short pack %v", ShortDeckCards)
+	}
+}
+
+func TestClassifyShortDeckHand(t *testing.T) {
+	testCases := []struct {
+		cards    []Card
+		expected HandLevel
+	}{
+		{h("AS", "6C", "7D", "8H", "9S"), hl("Straight", "9")},
+		{h("AS", "6S", "7S", "8S", "9S"), hl("StraightFlush", "9")},
+		{h("AS", "10C", "7D", "8H", "9S"), hl("HighCard", "A", "10", "9", "8", "7")},
+		{h("10S", "6C", "7D", "8H", "9S"), hl("Straight", "10")},
+		{h("AS", "AC", "AD", "8H", "8S"), hl("FullHouse", "A", "8")},
+	}
+	for _, tc := range testCases {
+		cards := make([]Card, len(tc.cards))
+		copy(cards, tc.cards)
+		if level := ClassifyShortDeckHand(cards); !reflect.DeepEqual(tc.expected, level) {
+			t.Errorf("Expected %v for %v, found %v", tc.expected, tc.cards, level)
+		}
+		if !reflect.DeepEqual(tc.cards, cards) {
+			t.Errorf("Expected cards to be left in order, found %v", cards)
+		}
+	}
+}
+
+func TestShortDeckBeats(t *testing.T) {
+	testCases := []struct {
+		l1, l2                HandLevel
+		expected, expectedTBS bool
+	}{
+		{hl("Flush", "9", "8", "7", "6", "A"), hl("FullHouse", "A", "K"), true, true},
+		{hl("FullHouse", "A", "K"), hl("Flush", "9", "8", "7", "6", "A"), false, false},
+		{hl("Straight", "9"), hl("ThreeOfAKind", "A", "K", "Q"), true, false},
+		{hl("ThreeOfAKind", "6", "8", "7"), hl("Straight", "A"), false, true},
+		{hl("FourOfAKind", "6", "7"), hl("Flush", "A", "K", "Q", "J", "9"), true, true},
+		{hl("Straight", "10"), hl("Straight", "9"), true, true},
+		{hl("TwoPair", "A", "K", "Q"), hl("ThreeOfAKind", "6", "8", "7"), false, false},
+	}
+	for _, tc := range testCases {
+		if result := (ShortDeckRules{}).Beats(tc.l1, tc.l2); result != tc.expected {
+			t.Errorf("Expected %v beats %v = %v, found %v", tc.l1, tc.l2, tc.expected, result)
+		}
+		if result := (ShortDeckRules{TripsBeatStraight: true}).Beats(tc.l1, tc.l2); result != tc.expectedTBS {
+			t.Errorf("Expected %v beats %v = %v when trips beat a straight, found %v", tc.l1, tc.l2, tc.expectedTBS, result)
+		}
+		if result := ShortDeckTripsRanking.Beats(tc.l1, tc.l2); result != tc.expectedTBS {
+			t.Errorf("Expected ranking to agree with rules for %v vs %v, found %v", tc.l1, tc.l2, result)
+		}
+	}
+}
+
+func TestShortDeckBestHand(t *testing.T) {
+	// A flush and a full house both available: the flush plays
+	cards := h("AH", "AD", "AS", "KH", "KD", "9H", "7H", "6H")
+	best := make([]Card, 5)
+	level := ShortDeckRules{}.BestHand(cards, best)
+	if !reflect.DeepEqual(hl("Flush", "A", "K", "9", "7", "6"), level) || !CardsEqual(best, h("AH", "KH", "9H", "7H", "6H")) {
+		t.Errorf("Expected ace-high flush, found %v with %v", level, best)
+	}
+	// Trips or a straight, depending on the rules
+	cards = h("AH", "6D", "7S", "8H", "9D", "9S", "9C")
+	if level := (ShortDeckRules{}).BestHand(cards, nil); !reflect.DeepEqual(hl("Straight", "9"), level) {
+		t.Errorf("Expected nine-high straight, found %v", level)
+	}
+	if level := (ShortDeckRules{TripsBeatStraight: true}).BestHand(cards, nil); !reflect.DeepEqual(hl("ThreeOfAKind", "9", "A", "8"), level) {
+		t.Errorf("Expected trip nines, found %v", level)
+	}
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package poker
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+)
+
+// Deals one hand for a simulation and works out how it ends, returning each player's outcome with ours first.
+// Each dealer is only used by one goroutine, so it can keep a pack and other storage to reuse from hand to hand.
+// An error stops the simulation.
+type Dealer func(randGen *rand.Rand) ([]GameOutcome, error)
+
+// Simulate a number of hands of some game from the point of view of the first player, dealing each hand with deal.
+// ranking is how the levels of the outcomes compare, for finding the best hands. All randomness comes from randGen,
+// so the results are reproducible given its seed. Stop early if ctx is cancelled or deal fails, returning the results
+// of the hands played so far along with the error, and send periodic progress reports to progress (if non-nil).
+func Simulate(ctx context.Context, ranking Ranking, players, handsToPlay int, deal Dealer, randGen *rand.Rand, progress ProgressFunc) (*Simulator, error) {
+	s := Simulator{Ranking: ranking}
+	s.Reset(players, handsToPlay)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var dealErr error
+
+	dealt := 0
+	_, err := RunHands(ctx, handsToPlay, func() {
+		if dealErr != nil {
+			return
+		}
+		outcomes, err := deal(randGen)
+		if err != nil {
+			dealErr = err
+			cancel()
+			return
+		}
+		s.ProcessHand(NewHandOutcome(outcomes, 1+randGen.Intn(players-1), ranking))
+		dealt++
+	}, s.Reporter(progress))
+	s.HandCount = dealt
+	if dealErr != nil {
+		return &s, dealErr
+	}
+	return &s, err
+}
+
+// As Simulate, but split the hands between several goroutines and merge the results, calling newDealer for a dealer
+// for each share of the hands. If workers is not positive, one worker per CPU is used. The results do not depend on
+// the number of workers.
+func SimulateParallel(ctx context.Context, ranking Ranking, players, handsToPlay, workers int, newDealer func() Dealer, randGen *rand.Rand, progress ProgressFunc) (*Simulator, error) {
+	chunks := ChunkCount(handsToPlay)
+	results := make([]*Simulator, chunks)
+	errs := make([]error, chunks)
+	aggregator := NewProgressAggregator(chunks, handsToPlay, progress)
+	RunParallel(handsToPlay, workers, randGen, func(chunk, hands int, chunkRandGen *rand.Rand) {
+		results[chunk], errs[chunk] = Simulate(ctx, ranking, players, hands, newDealer(), chunkRandGen, aggregator.Part(chunk))
+	})
+	return MergeResults(results, errs)
+}
+
+// Simulate hands in batches as for SimulateParallel until the 95% confidence interval for our pot equity has a
+// half-width of at most targetHalfWidth (e.g. 0.005 for plus or minus half a percent), or maxHands hands have been
+// played, whichever is first. The results depend only on the seed of randGen.
+func SimulateAdaptive(ctx context.Context, ranking Ranking, players int, targetHalfWidth float64, maxHands, workers int, newDealer func() Dealer, randGen *rand.Rand, progress ProgressFunc) (*Simulator, error) {
+	total := &Simulator{Ranking: ranking}
+	total.Reset(players, 0)
+	for {
+		batch := NextAdaptiveBatch(total.HandCount, maxHands, total.Equity(), targetHalfWidth)
+		if batch == 0 {
+			return total, nil
+		}
+		before := Progress{HandsPlayed: total.HandCount, HandsToPlay: maxHands, WinCount: total.WinCount, PotsWon: total.PotsWon}
+		sim, err := SimulateParallel(ctx, ranking, players, batch, workers, newDealer, randGen, BatchProgress(progress, before))
+		total.Merge(sim)
+		if err != nil {
+			return total, err
+		}
+	}
+}
+
+// A dealer for a game which deals the given known cards where they belong, and shuffles the rest
+func gameDealer(g Game, tableCards, yourCards []Card, players int) func() Dealer {
+	layout := g.Layout()
+	fixing := layout.Fixing(tableCards, yourCards)
+	return func() Dealer {
+		p := g.NewPack()
+		return func(randGen *rand.Rand) ([]GameOutcome, error) {
+			p.ShuffleFixed(randGen, &fixing)
+			onTable, playerCards := layout.Deal(&p, players)
+			return g.Outcomes(onTable, playerCards), nil
+		}
+	}
+}
+
+// Simulate a number of hands of any game, in which the given table cards and your cards are known.
+// The known cards are assumed to have been validated with the game's ValidateKnownCards.
+// All randomness comes from randGen, so the results are reproducible given its seed.
+func SimulateGame(g Game, tableCards, yourCards []Card, players, handsToPlay int, randGen *rand.Rand) *Simulator {
+	sim, _ := SimulateGameContext(context.Background(), g, tableCards, yourCards, players, handsToPlay, randGen, nil)
+	return sim
+}
+
+// As SimulateGame, but stop early if ctx is cancelled, and send periodic progress reports to progress (if non-nil).
+// If the simulation is cut short, the results of the hands played so far are returned, along with ctx.Err().
+func SimulateGameContext(ctx context.Context, g Game, tableCards, yourCards []Card, players, handsToPlay int, randGen *rand.Rand, progress ProgressFunc) (*Simulator, error) {
+	return Simulate(ctx, g.SettlementRules().Ranking, players, handsToPlay, gameDealer(g, tableCards, yourCards, players)(), randGen, progress)
+}
+
+// As SimulateGameContext, but split the hands between several goroutines and merge the results.
+// If workers is not positive, one worker per CPU is used. The results do not depend on the number of workers.
+func SimulateGameParallelContext(ctx context.Context, g Game, tableCards, yourCards []Card, players, handsToPlay, workers int, randGen *rand.Rand, progress ProgressFunc) (*Simulator, error) {
+	return SimulateParallel(ctx, g.SettlementRules().Ranking, players, handsToPlay, workers, gameDealer(g, tableCards, yourCards, players), randGen, progress)
+}
+
+// Simulate hands of any game in batches until the 95% confidence interval for our pot equity has a half-width of at
+// most targetHalfWidth, or maxHands hands have been played, whichever is first.
+// Batches are run as for SimulateGameParallelContext, so the results depend only on the seed of randGen.
+func SimulateGameAdaptive(ctx context.Context, g Game, tableCards, yourCards []Card, players int, targetHalfWidth float64, maxHands, workers int, randGen *rand.Rand, progress ProgressFunc) (*Simulator, error) {
+	return SimulateAdaptive(ctx, g.SettlementRules().Ranking, players, targetHalfWidth, maxHands, workers, gameDealer(g, tableCards, yourCards, players), randGen, progress)
+}
+
+// Summarise the outcomes of a hand from the point of view of player 1, whose outcome must come first, for a
+// Simulator. The best opponent is the one with the best level under ranking. A player counts as winning if they
+// win any part of the pot.
+func NewHandOutcome(outcomes []GameOutcome, randomOpponentIdx int, ranking Ranking) *HandOutcome {
+	if len(outcomes) < 2 {
+		panic(fmt.Sprintf("Expected at least two players, found %v", len(outcomes)))
+	}
+	ourOutcome := outcomes[0]
+	if ourOutcome.Player != 1 {
+		panic(fmt.Sprintf("Expected player 1 outcome first, found %v", ourOutcome.Player))
+	}
+	bestOpponentOutcome := outcomes[1]
+	for i := 2; i < len(outcomes); i++ {
+		if ranking.Beats(outcomes[i].Level, bestOpponentOutcome.Level) {
+			bestOpponentOutcome = outcomes[i]
+		}
+	}
+	randomOpponentOutcome := outcomes[randomOpponentIdx]
+
+	return &HandOutcome{
+		Won: ourOutcome.PotFractionWon > 0, OpponentWon: bestOpponentOutcome.PotFractionWon > 0, RandomOpponentWon: randomOpponentOutcome.PotFractionWon > 0,
+		PotFractionWon: ourOutcome.PotFractionWon, BestOpponentPotFractionWon: bestOpponentOutcome.PotFractionWon, RandomOpponentPotFractionWon: randomOpponentOutcome.PotFractionWon,
+		OurLevel: ourOutcome.Level, BestOpponentLevel: bestOpponentOutcome.Level, RandomOpponentLevel: randomOpponentOutcome.Level,
+		HasLow: ourOutcome.HasLow, LowWon: ourOutcome.LowPotFractionWon > 0, LowPotFractionWon: ourOutcome.LowPotFractionWon}
+}
@@ -20,7 +20,11 @@ along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
 package poker
 
 import (
+	"fmt"
 	"math"
+	"math/rand"
+	"runtime"
+	"sync"
 )
 
 type HandOutcome struct {
@@ -28,6 +32,8 @@ type HandOutcome struct {
 	PotFractionWon                                           float64
 	BestOpponentPotFractionWon, RandomOpponentPotFractionWon float64
 	OurLevel, BestOpponentLevel, RandomOpponentLevel         HandLevel
+	HasLow, LowWon                                           bool    // Whether we had a qualifying low and won with it, in hi-lo games
+	LowPotFractionWon                                        float64 // The part of PotFractionWon we won with our low
 }
 
 type Simulator struct {
@@ -38,8 +44,14 @@ type Simulator struct {
 	BestOpponentWinCount   int
 	RandomOpponentWinCount int
 	PotsWon                float64
+	PotsWonSquared         float64 // Sum of squares of the pot fraction won in each hand, for variance estimation
 	BestOpponentPotsWon    float64
 	RandomOpponentPotsWon  float64
+	LowQualifyCount        int     // Hands in which we had a qualifying low, in hi-lo games
+	LowWinCount            int     // Hands in which we won at least part of the low half of the pot
+	LowPotsWon             float64 // The part of PotsWon we won with our low hands
+	Exhaustive             bool    // Whether every possible outcome was enumerated, making the results exact
+	Ranking                Ranking // How hands are ranked when finding the best hands; set this before calling Reset
 
 	OurClassCounts            []int
 	BestOpponentClassCounts   []int
@@ -64,8 +76,13 @@ func (s *Simulator) Reset(players, handsToPlay int) {
 	s.BestOpponentWinCount = 0
 	s.RandomOpponentWinCount = 0
 	s.PotsWon = 0
+	s.PotsWonSquared = 0
 	s.BestOpponentPotsWon = 0
 	s.RandomOpponentPotsWon = 0
+	s.LowQualifyCount = 0
+	s.LowWinCount = 0
+	s.LowPotsWon = 0
+	s.Exhaustive = false
 
 	s.OurClassCounts = make([]int, MAX_HANDCLASS)
 	s.BestOpponentClassCounts = make([]int, MAX_HANDCLASS)
@@ -76,15 +93,15 @@ func (s *Simulator) Reset(players, handsToPlay int) {
 	s.ClassBestOppWinCounts = make([]int, MAX_HANDCLASS)
 	s.ClassRandOppWinCounts = make([]int, MAX_HANDCLASS)
 
-	s.BestHand = MinLevel()
-	s.BestOppHand = MinLevel()
+	s.BestHand = s.Ranking.MinLevel()
+	s.BestOppHand = s.Ranking.MinLevel()
 	s.ClassBestHands = make([]HandLevel, MAX_HANDCLASS)
 	for i := range s.ClassBestHands {
-		s.ClassBestHands[i] = MinLevel()
+		s.ClassBestHands[i] = s.Ranking.MinLevel()
 	}
 	s.ClassBestOppHands = make([]HandLevel, MAX_HANDCLASS)
 	for i := range s.ClassBestOppHands {
-		s.ClassBestOppHands[i] = MinLevel()
+		s.ClassBestOppHands[i] = s.Ranking.MinLevel()
 	}
 }
 
@@ -106,26 +123,249 @@ func (s *Simulator) ProcessHand(outcome *HandOutcome) {
 		s.ClassRandOppWinCounts[outcome.RandomOpponentLevel.Class]++
 	}
 	s.PotsWon += outcome.PotFractionWon
+	s.PotsWonSquared += outcome.PotFractionWon * outcome.PotFractionWon
 	s.BestOpponentPotsWon += outcome.BestOpponentPotFractionWon
 	s.RandomOpponentPotsWon += outcome.RandomOpponentPotFractionWon
 	s.OurClassCounts[outcome.OurLevel.Class]++
 	s.BestOpponentClassCounts[outcome.BestOpponentLevel.Class]++
 	s.RandomOpponentClassCounts[outcome.RandomOpponentLevel.Class]++
+	if outcome.HasLow {
+		s.LowQualifyCount++
+	}
+	if outcome.LowWon {
+		s.LowWinCount++
+	}
+	s.LowPotsWon += outcome.LowPotFractionWon
 
-	if Beats(outcome.OurLevel, s.BestHand) {
+	if s.Ranking.Beats(outcome.OurLevel, s.BestHand) {
 		s.BestHand = outcome.OurLevel
 	}
-	if Beats(outcome.BestOpponentLevel, s.BestOppHand) {
+	if s.Ranking.Beats(outcome.BestOpponentLevel, s.BestOppHand) {
 		s.BestOppHand = outcome.BestOpponentLevel
 	}
-	if Beats(outcome.OurLevel, s.ClassBestHands[outcome.OurLevel.Class]) {
+	if s.Ranking.Beats(outcome.OurLevel, s.ClassBestHands[outcome.OurLevel.Class]) {
 		s.ClassBestHands[outcome.OurLevel.Class] = outcome.OurLevel
 	}
-	if Beats(outcome.BestOpponentLevel, s.ClassBestOppHands[outcome.BestOpponentLevel.Class]) {
+	if s.Ranking.Beats(outcome.BestOpponentLevel, s.ClassBestOppHands[outcome.BestOpponentLevel.Class]) {
 		s.ClassBestOppHands[outcome.BestOpponentLevel.Class] = outcome.BestOpponentLevel
 	}
 }
 
+// A report function for RunHands, which passes this simulator's results so far on to progress.
+// Returns nil if progress is nil.
+func (s *Simulator) Reporter(progress ProgressFunc) func(handsPlayed int) {
+	if progress == nil {
+		return nil
+	}
+	return func(handsPlayed int) {
+		progress(Progress{handsPlayed, s.HandCount, s.WinCount, s.PotsWon})
+	}
+}
+
+// Combine the results of another simulation into this one, as if all its hands had been played by this simulator.
+// Both simulators must have been reset with the same number of players.
+func (s *Simulator) Merge(other *Simulator) {
+	if s.Players != other.Players {
+		panic(fmt.Sprintf("Cannot merge simulations with different player counts %v and %v", s.Players, other.Players))
+	}
+	s.HandCount += other.HandCount
+	s.WinCount += other.WinCount
+	s.JointWinCount += other.JointWinCount
+	s.BestOpponentWinCount += other.BestOpponentWinCount
+	s.RandomOpponentWinCount += other.RandomOpponentWinCount
+	s.PotsWon += other.PotsWon
+	s.PotsWonSquared += other.PotsWonSquared
+	s.BestOpponentPotsWon += other.BestOpponentPotsWon
+	s.RandomOpponentPotsWon += other.RandomOpponentPotsWon
+	s.LowQualifyCount += other.LowQualifyCount
+	s.LowWinCount += other.LowWinCount
+	s.LowPotsWon += other.LowPotsWon
+	s.Exhaustive = s.Exhaustive && other.Exhaustive
+
+	addCounts := func(counts, otherCounts []int) {
+		for i := range counts {
+			counts[i] += otherCounts[i]
+		}
+	}
+	addCounts(s.OurClassCounts, other.OurClassCounts)
+	addCounts(s.BestOpponentClassCounts, other.BestOpponentClassCounts)
+	addCounts(s.RandomOpponentClassCounts, other.RandomOpponentClassCounts)
+	addCounts(s.ClassWinCounts, other.ClassWinCounts)
+	addCounts(s.ClassJointWinCounts, other.ClassJointWinCounts)
+	addCounts(s.ClassBestOppWinCounts, other.ClassBestOppWinCounts)
+	addCounts(s.ClassRandOppWinCounts, other.ClassRandOppWinCounts)
+
+	if s.Ranking.Beats(other.BestHand, s.BestHand) {
+		s.BestHand = other.BestHand
+	}
+	if s.Ranking.Beats(other.BestOppHand, s.BestOppHand) {
+		s.BestOppHand = other.BestOppHand
+	}
+	for i := range s.ClassBestHands {
+		if s.Ranking.Beats(other.ClassBestHands[i], s.ClassBestHands[i]) {
+			s.ClassBestHands[i] = other.ClassBestHands[i]
+		}
+	}
+	for i := range s.ClassBestOppHands {
+		if s.Ranking.Beats(other.ClassBestOppHands[i], s.ClassBestOppHands[i]) {
+			s.ClassBestOppHands[i] = other.ClassBestOppHands[i]
+		}
+	}
+}
+
+// Merge the results of the chunks of a parallel simulation into the first, returning it along with the first error
+// any chunk reported
+func MergeResults(results []*Simulator, errs []error) (*Simulator, error) {
+	var err error
+	for i := range results {
+		if i > 0 {
+			results[0].Merge(results[i])
+		}
+		if err == nil {
+			err = errs[i]
+		}
+	}
+	return results[0], err
+}
+
+// Parallel simulations are divided into this many chunks (or one per hand, if there are fewer hands),
+// each with its own independently-seeded random number generator. The number of chunks does not depend
+// on the number of workers, so the results of a simulation depend only on its seed.
+const ParallelChunks = 64
+
+// The number of chunks RunParallel will divide a given number of hands into
+func ChunkCount(handsToPlay int) int {
+	return max(min(ParallelChunks, handsToPlay), 1)
+}
+
+// The range [start, end) of hands which make up the given chunk, when RunParallel splits up handsToPlay hands
+func ChunkRange(handsToPlay, chunk int) (start, end int) {
+	chunks := ChunkCount(handsToPlay)
+	start = chunk*(handsToPlay/chunks) + min(chunk, handsToPlay%chunks)
+	end = start + handsToPlay/chunks
+	if chunk < handsToPlay%chunks {
+		end++
+	}
+	return start, end
+}
+
+// Split a number of hands as evenly as possible into chunks (see ChunkCount), and run them concurrently
+// using a pool of worker goroutines. If workers is not positive, one worker per CPU is used.
+// Each chunk gets its own random number generator, seeded in turn from randGen, so they share no state
+// (or nil, if randGen is nil).
+// run is called once per chunk with the chunk's index, its share of the hands and its generator,
+// and RunParallel returns when all chunks have finished. If run panics, the first panic is raised again
+// from RunParallel once the other workers have stopped, rather than crashing the program from a worker.
+func RunParallel(handsToPlay, workers int, randGen *rand.Rand, run func(chunk, hands int, randGen *rand.Rand)) {
+	chunks := ChunkCount(handsToPlay)
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	workers = min(workers, chunks)
+
+	// Generate the seeds up-front, so that they do not depend on scheduling
+	var seeds []int64
+	if randGen != nil {
+		seeds = make([]int64, chunks)
+		for i := range seeds {
+			seeds[i] = randGen.Int63()
+		}
+	}
+
+	chunkQueue := make(chan int, chunks)
+	for i := 0; i < chunks; i++ {
+		chunkQueue <- i
+	}
+	close(chunkQueue)
+
+	var wg sync.WaitGroup
+	var panicOnce sync.Once
+	var panicked interface{}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					panicOnce.Do(func() { panicked = r })
+					// Skip the remaining chunks, as their results will be thrown away
+					for range chunkQueue {
+					}
+				}
+			}()
+			for chunk := range chunkQueue {
+				start, end := ChunkRange(handsToPlay, chunk)
+				var chunkRandGen *rand.Rand
+				if seeds != nil {
+					chunkRandGen = rand.New(rand.NewSource(seeds[chunk]))
+				}
+				run(chunk, end-start, chunkRandGen)
+			}
+		}()
+	}
+	wg.Wait()
+	if panicked != nil {
+		panic(panicked)
+	}
+}
+
+// Make an estimate from the simulation results, which is exact if the simulation was exhaustive
+func (s *Simulator) estimate(e Estimate) Estimate {
+	if s.Exhaustive {
+		e.StdErr = 0
+	}
+	return e
+}
+
+// The probability that we win at least part of the pot
+func (s *Simulator) WinRate() Estimate {
+	return s.estimate(ProportionEstimate(s.WinCount, s.HandCount))
+}
+
+// The probability that we split the pot with at least one opponent
+func (s *Simulator) TieRate() Estimate {
+	return s.estimate(ProportionEstimate(s.JointWinCount, s.HandCount))
+}
+
+// The average fraction of the pot we win
+func (s *Simulator) Equity() Estimate {
+	return s.estimate(MeanEstimate(s.PotsWon, s.PotsWonSquared, s.HandCount))
+}
+
+// The probability that we win at least part of the low half of the pot, in hi-lo games
+func (s *Simulator) LowWinRate() Estimate {
+	return s.estimate(ProportionEstimate(s.LowWinCount, s.HandCount))
+}
+
+// The probability that we have a qualifying low, in hi-lo games
+func (s *Simulator) LowQualifyRate() Estimate {
+	return s.estimate(ProportionEstimate(s.LowQualifyCount, s.HandCount))
+}
+
+// The probability that our best hand is of the given class
+func (s *Simulator) ClassFrequency(class HandClass) Estimate {
+	return s.estimate(ProportionEstimate(s.OurClassCounts[class], s.HandCount))
+}
+
+// Summary statistics for a simulation, with 95% confidence intervals
+type SimulatorStats struct {
+	WinRate, TieRate, Equity ConfidenceInterval
+	ClassFrequencies         []ConfidenceInterval
+}
+
+func (s *Simulator) Stats() SimulatorStats {
+	result := SimulatorStats{
+		WinRate:          s.WinRate().ConfidenceInterval(Z95).Clamped(),
+		TieRate:          s.TieRate().ConfidenceInterval(Z95).Clamped(),
+		Equity:           s.Equity().ConfidenceInterval(Z95).Clamped(),
+		ClassFrequencies: make([]ConfidenceInterval, MAX_HANDCLASS),
+	}
+	for class := range result.ClassFrequencies {
+		result.ClassFrequencies[class] = s.ClassFrequency(HandClass(class)).ConfidenceInterval(Z95).Clamped()
+	}
+	return result
+}
+
 func (s *Simulator) PotOddsBreakEven() float64 {
 	return PotOddsBreakEven(s.PotsWon, s.HandCount)
 }
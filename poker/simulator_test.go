@@ -20,7 +20,10 @@ along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
 package poker
 
 import (
+	"fmt"
 	"math"
+	"math/rand"
+	"reflect"
 	"testing"
 )
 
@@ -36,3 +39,59 @@ func TestPotOdds(t *testing.T) {
 		}
 	}
 }
+
+func TestMerge(t *testing.T) {
+	outcomes := []HandOutcome{
+		{Won: true, PotFractionWon: 1, OurLevel: hl("Flush", "A", "J", "9", "5", "3"), BestOpponentLevel: hl("Straight", "K"), RandomOpponentLevel: hl("OnePair", "2", "A", "K", "Q")},
+		{Won: true, OpponentWon: true, PotFractionWon: 0.5, BestOpponentPotFractionWon: 0.5, OurLevel: hl("Straight", "9"), BestOpponentLevel: hl("Straight", "9"), RandomOpponentLevel: hl("HighCard", "A", "J", "9", "5", "3")},
+		{OpponentWon: true, RandomOpponentWon: true, BestOpponentPotFractionWon: 1, RandomOpponentPotFractionWon: 1, OurLevel: hl("TwoPair", "Q", "J", "2"), BestOpponentLevel: hl("FullHouse", "3", "2"), RandomOpponentLevel: hl("FullHouse", "3", "2")},
+		{Won: true, PotFractionWon: 1, OurLevel: hl("Flush", "K", "J", "9", "5", "3"), BestOpponentLevel: hl("HighCard", "K", "J", "9", "5", "3"), RandomOpponentLevel: hl("HighCard", "K", "J", "9", "5", "3")},
+	}
+	all := Simulator{}
+	all.Reset(3, len(outcomes))
+	first, second := Simulator{}, Simulator{}
+	first.Reset(3, 1)
+	second.Reset(3, len(outcomes)-1)
+	for i := range outcomes {
+		all.ProcessHand(&outcomes[i])
+		if i == 0 {
+			first.ProcessHand(&outcomes[i])
+		} else {
+			second.ProcessHand(&outcomes[i])
+		}
+	}
+	first.Merge(&second)
+	if !reflect.DeepEqual(all, first) {
+		t.Errorf("Expected merged simulator %+v, found %+v", all, first)
+	}
+}
+
+func TestLowRankingBestHands(t *testing.T) {
+	sim := Simulator{Ranking: AceToFiveLowRanking}
+	sim.Reset(2, 2)
+	sim.ProcessHand(&HandOutcome{Won: true, PotFractionWon: 1, OurLevel: hl("HighCard", "7", "5", "4", "3", "2"), BestOpponentLevel: hl("OnePair", "2", "8", "7", "5"), RandomOpponentLevel: hl("OnePair", "2", "8", "7", "5")})
+	sim.ProcessHand(&HandOutcome{Won: true, PotFractionWon: 1, OurLevel: hl("HighCard", "8", "5", "4", "3", "2"), BestOpponentLevel: hl("HighCard", "K", "Q", "J", "9", "7"), RandomOpponentLevel: hl("HighCard", "K", "Q", "J", "9", "7")})
+	if expected := hl("HighCard", "7", "5", "4", "3", "2"); !reflect.DeepEqual(expected, sim.BestHand) {
+		t.Errorf("Expected best low hand %v, found %v", expected, sim.BestHand)
+	}
+	if expected := hl("HighCard", "K", "Q", "J", "9", "7"); !reflect.DeepEqual(expected, sim.BestOppHand) {
+		t.Errorf("Expected best opponent low hand %v, found %v", expected, sim.BestOppHand)
+	}
+	if sim.Ranking != AceToFiveLowRanking {
+		t.Errorf("Expected ranking to survive reset, found %v", sim.Ranking)
+	}
+}
+
+func TestRunParallelPanic(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "chunk 3 failed" {
+			t.Errorf("Expected worker panic to be raised again by RunParallel, found %v", r)
+		}
+	}()
+	RunParallel(100000, 4, nil, func(chunk, hands int, randGen *rand.Rand) {
+		if chunk == 3 {
+			panic(fmt.Sprintf("chunk %v failed", chunk))
+		}
+	})
+	t.Errorf("Expected RunParallel to panic")
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package poker
+
+import (
+	"math"
+)
+
+// The z-score for a two-sided 95% confidence interval under the normal approximation
+const Z95 = 1.959963984540054
+
+// A statistical estimate of a quantity (such as a win probability) based on simulation results
+type Estimate struct {
+	Mean   float64
+	StdErr float64 // Standard error of the mean
+}
+
+// Estimate a probability from the number of times an event happened in a number of independent trials
+func ProportionEstimate(count, trials int) Estimate {
+	if trials == 0 {
+		return Estimate{}
+	}
+	p := float64(count) / float64(trials)
+	return Estimate{p, math.Sqrt(p * (1 - p) / float64(trials))}
+}
+
+// Estimate the mean of a quantity from the sum of its values and the sum of their squares over a number of trials
+func MeanEstimate(sum, sumSquares float64, trials int) Estimate {
+	if trials == 0 {
+		return Estimate{}
+	}
+	n := float64(trials)
+	mean := sum / n
+	if trials == 1 {
+		return Estimate{mean, 0}
+	}
+	// Sample variance; clamp at zero to guard against rounding errors
+	variance := math.Max(0, (sumSquares-n*mean*mean)/(n-1))
+	return Estimate{mean, math.Sqrt(variance / n)}
+}
+
+// Half the width of the confidence interval with the given z-score (e.g. Z95)
+func (e Estimate) HalfWidth(z float64) float64 {
+	return z * e.StdErr
+}
+
+// A confidence interval for the estimate, using the normal approximation and the given z-score
+func (e Estimate) ConfidenceInterval(z float64) ConfidenceInterval {
+	hw := e.HalfWidth(z)
+	return ConfidenceInterval{e.Mean, e.StdErr, e.Mean - hw, e.Mean + hw}
+}
+
+// An estimate together with the bounds of a confidence interval around it
+type ConfidenceInterval struct {
+	Mean, StdErr, Low, High float64
+}
+
+// Clamp the interval to lie between 0 and 1, as is appropriate for probabilities and pot fractions
+func (ci ConfidenceInterval) Clamped() ConfidenceInterval {
+	ci.Low = math.Max(0, ci.Low)
+	ci.High = math.Min(1, ci.High)
+	return ci
+}
+
+// Adaptive simulations start with this many hands
+const AdaptiveInitialBatch = 10000
+
+// Adaptive simulations never play batches smaller than this, to avoid lots of tiny batches near the target
+const AdaptiveMinBatch = 1000
+
+// Decide how many more hands an adaptive simulation should play, to bring the half-width of the 95% confidence interval
+// for equity down to targetHalfWidth, given the hands played so far and the equity estimate from them.
+// Returns zero if the target has been met, or maxHands hands have already been played.
+func NextAdaptiveBatch(handsPlayed, maxHands int, equity Estimate, targetHalfWidth float64) int {
+	remaining := maxHands - handsPlayed
+	if remaining <= 0 {
+		return 0
+	}
+	if handsPlayed == 0 {
+		return min(AdaptiveInitialBatch, remaining)
+	}
+	halfWidth := equity.HalfWidth(Z95)
+	if halfWidth <= targetHalfWidth {
+		return 0
+	}
+	// The half-width shrinks in proportion to the square root of the number of hands.
+	// Aim a little beyond the point where we expect to hit the target, to avoid falling just short.
+	ratio := halfWidth / targetHalfWidth
+	needed := int(math.Ceil(1.1*float64(handsPlayed)*ratio*ratio)) - handsPlayed
+	return min(max(needed, AdaptiveMinBatch), remaining)
+}
+
+// Wrap a progress function for one batch of an adaptive simulation, so that it reports overall progress
+// given the progress before the batch started. Returns nil if progress is nil.
+func BatchProgress(progress ProgressFunc, before Progress) ProgressFunc {
+	if progress == nil {
+		return nil
+	}
+	return func(p Progress) {
+		progress(Progress{before.HandsPlayed + p.HandsPlayed, before.HandsToPlay, before.WinCount + p.WinCount, before.PotsWon + p.PotsWon})
+	}
+}
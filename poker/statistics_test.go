@@ -0,0 +1,160 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package poker
+
+import (
+	"math"
+	"testing"
+)
+
+func TestProportionEstimate(t *testing.T) {
+	e := ProportionEstimate(250, 1000)
+	if e.Mean != 0.25 {
+		t.Errorf("Expected mean 0.25, found %v", e.Mean)
+	}
+	expectedStdErr := math.Sqrt(0.25 * 0.75 / 1000)
+	if math.Abs(e.StdErr-expectedStdErr) > 1e-12 {
+		t.Errorf("Expected standard error %v, found %v", expectedStdErr, e.StdErr)
+	}
+	ci := e.ConfidenceInterval(Z95)
+	if math.Abs(ci.High-ci.Low-2*Z95*expectedStdErr) > 1e-12 || math.Abs((ci.High+ci.Low)/2-0.25) > 1e-12 {
+		t.Errorf("Unexpected confidence interval %+v", ci)
+	}
+	if (ProportionEstimate(0, 0) != Estimate{}) {
+		t.Errorf("Expected empty estimate with no trials, found %+v", ProportionEstimate(0, 0))
+	}
+	if e := ProportionEstimate(10, 10); e.StdErr != 0 {
+		t.Errorf("Expected zero standard error for certain event, found %v", e.StdErr)
+	}
+}
+
+func TestMeanEstimate(t *testing.T) {
+	values := []float64{0, 1, 0.5, 0, 1, 1, 0.25, 0}
+	sum, sumSquares := 0.0, 0.0
+	for _, v := range values {
+		sum += v
+		sumSquares += v * v
+	}
+	n := float64(len(values))
+	mean := sum / n
+	variance := 0.0
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	variance /= n - 1
+
+	e := MeanEstimate(sum, sumSquares, len(values))
+	if math.Abs(e.Mean-mean) > 1e-12 {
+		t.Errorf("Expected mean %v, found %v", mean, e.Mean)
+	}
+	if expected := math.Sqrt(variance / n); math.Abs(e.StdErr-expected) > 1e-12 {
+		t.Errorf("Expected standard error %v, found %v", expected, e.StdErr)
+	}
+	if e := MeanEstimate(5, 5, 5); e.Mean != 1 || e.StdErr != 0 {
+		t.Errorf("Expected exact estimate for constant values, found %+v", e)
+	}
+}
+
+func TestClamped(t *testing.T) {
+	ci := Estimate{0.01, 0.01}.ConfidenceInterval(Z95).Clamped()
+	if ci.Low != 0 || ci.Mean != 0.01 {
+		t.Errorf("Expected interval clamped at zero, found %+v", ci)
+	}
+	ci = Estimate{0.99, 0.01}.ConfidenceInterval(Z95).Clamped()
+	if ci.High != 1 || ci.Mean != 0.99 {
+		t.Errorf("Expected interval clamped at one, found %+v", ci)
+	}
+}
+
+func TestNextAdaptiveBatch(t *testing.T) {
+	if b := NextAdaptiveBatch(0, 1000000, Estimate{}, 0.01); b != AdaptiveInitialBatch {
+		t.Errorf("Expected initial batch of %v, found %v", AdaptiveInitialBatch, b)
+	}
+	if b := NextAdaptiveBatch(0, 500, Estimate{}, 0.01); b != 500 {
+		t.Errorf("Expected initial batch limited to 500, found %v", b)
+	}
+	// Already precise enough
+	if b := NextAdaptiveBatch(10000, 1000000, Estimate{0.5, 0.001}, 0.01); b != 0 {
+		t.Errorf("Expected to stop when target met, found batch %v", b)
+	}
+	// Half-width is about 0.02, twice the target, so we need roughly four times as many hands in total
+	b := NextAdaptiveBatch(10000, 1000000, Estimate{0.5, 0.02 / Z95}, 0.01)
+	if b < 30000 || b > 40000 {
+		t.Errorf("Expected a batch of a little over 30000, found %v", b)
+	}
+	if b := NextAdaptiveBatch(10000, 20000, Estimate{0.5, 0.02 / Z95}, 0.01); b != 10000 {
+		t.Errorf("Expected batch limited by maximum hand count, found %v", b)
+	}
+	if b := NextAdaptiveBatch(10000, 10000, Estimate{0.5, 0.02 / Z95}, 0.01); b != 0 {
+		t.Errorf("Expected to stop at maximum hand count, found batch %v", b)
+	}
+	if b := NextAdaptiveBatch(1000, 1000000, Estimate{0.5, 0.01001 / Z95}, 0.01); b != AdaptiveMinBatch {
+		t.Errorf("Expected minimum batch size when just short of target, found %v", b)
+	}
+}
+
+func TestBatchProgress(t *testing.T) {
+	if BatchProgress(nil, Progress{}) != nil {
+		t.Errorf("Expected nil progress function for nil input")
+	}
+	var reported Progress
+	progress := BatchProgress(func(p Progress) { reported = p }, Progress{HandsPlayed: 100, HandsToPlay: 1000, WinCount: 10, PotsWon: 7.5})
+	progress(Progress{HandsPlayed: 50, HandsToPlay: 200, WinCount: 5, PotsWon: 2.5})
+	expected := Progress{HandsPlayed: 150, HandsToPlay: 1000, WinCount: 15, PotsWon: 10}
+	if reported != expected {
+		t.Errorf("Expected %+v, found %+v", expected, reported)
+	}
+}
+
+func TestSimulatorStats(t *testing.T) {
+	s := Simulator{}
+	s.Reset(2, 0)
+	pair := TestMakeHandLevel("OnePair", "A", "4", "3", "2")
+	highCard := TestMakeHandLevel("HighCard", "A", "K", "4", "3", "2")
+	outcomes := []HandOutcome{
+		{Won: true, PotFractionWon: 1, OurLevel: pair, BestOpponentLevel: highCard, RandomOpponentLevel: highCard},
+		{Won: true, PotFractionWon: 0.5, OpponentWon: true, BestOpponentPotFractionWon: 0.5, OurLevel: pair, BestOpponentLevel: pair, RandomOpponentLevel: pair},
+		{OpponentWon: true, BestOpponentPotFractionWon: 1, OurLevel: highCard, BestOpponentLevel: pair, RandomOpponentLevel: pair},
+		{OpponentWon: true, BestOpponentPotFractionWon: 1, OurLevel: highCard, BestOpponentLevel: pair, RandomOpponentLevel: pair},
+	}
+	for i := range outcomes {
+		s.ProcessHand(&outcomes[i])
+		s.HandCount++
+	}
+	stats := s.Stats()
+	if stats.WinRate.Mean != 0.5 || stats.TieRate.Mean != 0.25 || stats.Equity.Mean != 0.375 {
+		t.Errorf("Unexpected means in %+v", stats)
+	}
+	if stats.ClassFrequencies[OnePair].Mean != 0.5 || stats.ClassFrequencies[HighCard].Mean != 0.5 {
+		t.Errorf("Unexpected class frequencies %+v", stats.ClassFrequencies)
+	}
+	if expected := MeanEstimate(1.5, 1.25, 4); s.Equity() != expected {
+		t.Errorf("Expected equity estimate %+v, found %+v", expected, s.Equity())
+	}
+	if stats.Equity.StdErr <= 0 || stats.Equity.High >= 1 {
+		t.Errorf("Expected non-trivial equity interval, found %+v", stats.Equity)
+	}
+
+	s.Exhaustive = true
+	stats = s.Stats()
+	if stats.Equity.StdErr != 0 || stats.Equity.Low != stats.Equity.Mean || stats.WinRate.High != stats.WinRate.Mean {
+		t.Errorf("Expected exact statistics for exhaustive simulation, found %+v", stats)
+	}
+}
@@ -42,6 +42,8 @@ func TestMakeHands(hands ...[]Card) [][]Card {
 
 func parseHandClass(handClassStr string) HandClass {
 	switch handClassStr {
+	case "FiveOfAKind":
+		return FiveOfAKind
 	case "StraightFlush":
 		return StraightFlush
 	case "FourOfAKind":
@@ -93,20 +95,18 @@ func CardsEqual(c1, c2 []Card) bool {
 
 // Assert that the pack contains exactly one of every card
 func TestPackPermutation(pack *Pack, t *testing.T) {
-	permCheck := make([][]int, 4)
-	for i := 0; i < 4; i++ {
-		permCheck[i] = make([]int, 13)
-	}
+	counts := make([]int, len(pack.Cards))
 	for _, c := range pack.Cards {
-		permCheck[c.Suit][c.Rank]++
+		counts[c.Index()]++
 	}
-	for s := range permCheck {
-		for r, count := range permCheck[s] {
-			if count != 1 {
-				t.Fatalf("Expected exactly one %v%v in pack after shuffle, found %v", Rank(r).String(), Suit(s).String(), count)
-			}
+	for i, count := range counts {
+		if count != 1 {
+			t.Fatalf("Expected exactly one %v in pack after shuffle, found %v", CardFromIndex(i), count)
 		}
 	}
+	if inPlay := NewCardSet(pack.Cards[:pack.Size()]...); inPlay != pack.cardSet() {
+		t.Fatalf("Expected %v in play after shuffle, found %v", pack.cardSet(), inPlay)
+	}
 
 }
 func TestAssertPotsWonSanity(winCount int, potsWon float64, description string, t *testing.T) {
@@ -181,12 +181,12 @@ func TestAssertSimSanity(sim *Simulator, players, simulations int, t *testing.T)
 	}
 
 	for c, l := range sim.ClassBestHands {
-		if Beats(l, sim.BestHand) {
+		if sim.Ranking.Beats(l, sim.BestHand) {
 			t.Errorf("Best hand %v of class %v better than overall best %v", l, c, sim.BestHand)
 		}
 	}
 	for c, l := range sim.ClassBestOppHands {
-		if Beats(l, sim.BestOppHand) {
+		if sim.Ranking.Beats(l, sim.BestOppHand) {
 			t.Errorf("Best opponent hand %v of class %v better than overall best %v", l, c, sim.BestOppHand)
 		}
 	}
@@ -0,0 +1,147 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package poker
+
+import "fmt"
+
+// Rules for wild cards. Any jokers in a hand are wild, as are all cards of the given ranks.
+type WildCards struct {
+	Ranks []Rank // Ranks which are wild in every suit, e.g. twos for deuces wild
+	Bug   bool   // Whether jokers are only a "bug", which can complete a straight or a flush but otherwise counts as an ace
+}
+
+// Whether this card can stand for any card under these rules. Wild cards can duplicate cards already in the hand
+// (so four aces and a wild card make five of a kind), but a flush is never allowed to contain the same card twice.
+func (w WildCards) IsWild(c Card) bool {
+	if c.IsJoker() {
+		return !w.Bug
+	}
+	for _, r := range w.Ranks {
+		if c.Rank == r {
+			return true
+		}
+	}
+	return false
+}
+
+func (w WildCards) isBug(c Card) bool {
+	return w.Bug && c.IsJoker()
+}
+
+// Classify a hand of five cards, with each wild card standing for whichever card gives the best hand.
+// Five cards of the same rank (e.g. four aces and a joker) make five of a kind, which beats a straight flush.
+func (w WildCards) ClassifyHand(cards []Card) HandLevel {
+	if len(cards) != 5 {
+		panic(fmt.Sprintf("Expected exactly five cards, found %v", len(cards)))
+	}
+	var hand [5]Card
+	naturals := hand[:0]
+	wilds, bugs := 0, 0
+	for _, c := range cards {
+		switch {
+		case w.isBug(c):
+			bugs++
+		case w.IsWild(c):
+			wilds++
+		default:
+			naturals = append(naturals, c)
+		}
+	}
+	if len(naturals) == 5 {
+		return Evaluate5(naturals).Level()
+	}
+
+	// Five of a kind beats everything else, and there is at most one rank it can be made in
+	fiveRank := Ace
+	if len(naturals) > 0 {
+		fiveRank = naturals[0].Rank
+	}
+	if bugs == 0 || fiveRank == Ace {
+		sameRank := true
+		for _, c := range naturals {
+			sameRank = sameRank && c.Rank == fiveRank
+		}
+		if sameRank {
+			return HandLevel{FiveOfAKind, []Rank{fiveRank}}
+		}
+	}
+
+	// Otherwise try every combination of ranks for the wild cards. They all take the suit of the first natural card:
+	// if the natural cards are all of one suit this makes a flush, which can only help, and otherwise suits don't matter.
+	suit := naturals[0].Suit
+	firstBug := len(naturals) + wilds
+	var bestLevel HandLevel
+	found := false
+	var assign func(pos int, minRank Rank)
+	assign = func(pos int, minRank Rank) {
+		if pos == firstBug {
+			minRank = Two
+		}
+		if pos == len(hand) {
+			var buf [5]Card
+			copy(buf[:], hand[:])
+			level := ClassifyHand(buf[:])
+			if level.Class == Flush {
+				for i := 1; i < len(level.Tiebreaks); i++ {
+					if level.Tiebreaks[i] == level.Tiebreaks[i-1] {
+						return // Not really a flush, as it would need the same card twice
+					}
+				}
+			}
+			if level.Class != Straight && level.Class != Flush && level.Class != StraightFlush {
+				for _, c := range hand[firstBug:] {
+					if c.Rank != Ace {
+						return // A bug can only be something other than an ace to make a straight or flush
+					}
+				}
+			}
+			if !found || Beats(level, bestLevel) {
+				bestLevel = level
+				found = true
+			}
+			return
+		}
+		for r := minRank; r <= Ace; r++ {
+			hand[pos] = Card{r, suit}
+			assign(pos+1, r)
+		}
+	}
+	assign(len(naturals), Two)
+	return bestLevel
+}
+
+// The best hand under these rules which can be made from five or more cards.
+// If best is not nil, the five cards making up the hand are copied into it, with wild cards as dealt.
+func (w WildCards) BestHand(cards []Card, best []Card) HandLevel {
+	var bestLevel HandLevel
+	first := true
+	ForEachCombination(cards, 5, func(combination []Card) bool {
+		level := w.ClassifyHand(combination)
+		if first || Beats(level, bestLevel) {
+			bestLevel = level
+			first = false
+			if best != nil {
+				copy(best, combination)
+			}
+		}
+		return true
+	})
+	return bestLevel
+}
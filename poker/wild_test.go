@@ -0,0 +1,84 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package poker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWildClassifyHand(t *testing.T) {
+	deuces := WildCards{Ranks: []Rank{Two}}
+	jokers := WildCards{}
+	bug := WildCards{Bug: true}
+	deucesAndBug := WildCards{Ranks: []Rank{Two}, Bug: true}
+	testCases := []struct {
+		wild     WildCards
+		cards    []Card
+		expected HandLevel
+	}{
+		{deuces, h("2H", "AS", "AH", "AD", "AC"), hl("FiveOfAKind", "A")},
+		{deuces, h("2H", "2D", "KS", "QS", "JS"), hl("StraightFlush", "A")},
+		{deuces, h("2H", "KS", "QS", "JS", "9S"), hl("StraightFlush", "K")},
+		{deuces, h("2H", "AS", "KS", "QS", "9S"), hl("Flush", "A", "K", "Q", "J", "9")},
+		{deuces, h("2H", "AS", "KD", "7C", "4H"), hl("OnePair", "A", "K", "7", "4")},
+		{deuces, h("2H", "2C", "AS", "KD", "7C"), hl("ThreeOfAKind", "A", "K", "7")},
+		{deuces, h("3H", "AS", "KD", "7C", "4H"), hl("HighCard", "A", "K", "7", "4", "3")},
+		{jokers, h("JK1", "KS", "KD", "KH", "KC"), hl("FiveOfAKind", "K")},
+		{jokers, h("JK1", "JK2", "AS", "KS", "3D"), hl("ThreeOfAKind", "A", "K", "3")},
+		{jokers, h("JK1", "KS", "KD", "QH", "QC"), hl("FullHouse", "K", "Q")},
+		{bug, h("JK1", "KS", "KD", "KH", "KC"), hl("FourOfAKind", "K", "A")},
+		{bug, h("JK1", "9S", "10D", "JH", "QC"), hl("Straight", "K")},
+		{bug, h("JK1", "AS", "AD", "7H", "3C"), hl("ThreeOfAKind", "A", "7", "3")},
+		{bug, h("JK1", "KS", "KD", "7H", "3C"), hl("OnePair", "K", "A", "7", "3")},
+		{bug, h("JK2", "2S", "7S", "9S", "KS"), hl("Flush", "A", "K", "9", "7", "2")},
+		{bug, h("JK2", "AS", "7S", "9S", "KS"), hl("Flush", "A", "K", "Q", "9", "7")},
+		{deucesAndBug, h("JK1", "2H", "2D", "2C", "2S"), hl("FiveOfAKind", "A")},
+		{deucesAndBug, h("JK1", "2H", "QD", "QC", "QS"), hl("FourOfAKind", "Q", "A")},
+	}
+	for _, tc := range testCases {
+		cards := make([]Card, len(tc.cards))
+		copy(cards, tc.cards)
+		if level := tc.wild.ClassifyHand(cards); !reflect.DeepEqual(tc.expected, level) {
+			t.Errorf("Expected %v for %v with %+v, found %v", tc.expected, tc.cards, tc.wild, level)
+		}
+		if !reflect.DeepEqual(tc.cards, cards) {
+			t.Errorf("Expected cards to be left in order, found %v", cards)
+		}
+	}
+}
+
+func TestWildBestHand(t *testing.T) {
+	deuces := WildCards{Ranks: []Rank{Two}}
+	best := make([]Card, 5)
+	level := deuces.BestHand(h("2H", "AS", "KS", "QS", "JS", "3D", "4C"), best)
+	if expected := hl("StraightFlush", "A"); !reflect.DeepEqual(expected, level) {
+		t.Errorf("Expected %v, found %v", expected, level)
+	}
+	if expected := h("2H", "AS", "KS", "QS", "JS"); !CardsEqual(expected, best) {
+		t.Errorf("Expected best hand %v, found %v", expected, best)
+	}
+	if level := (WildCards{}).BestHand(h("AS", "KS", "QS", "JS", "10S", "3D", "4C"), nil); !reflect.DeepEqual(hl("StraightFlush", "A"), level) {
+		t.Errorf("Expected royal flush without wild cards, found %v", level)
+	}
+	if !Beats(hl("FiveOfAKind", "2"), hl("StraightFlush", "A")) {
+		t.Errorf("Expected five of a kind to beat a straight flush")
+	}
+}
@@ -0,0 +1,223 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package poker_http
+
+import (
+	"errors"
+	"fmt"
+	"github.com/amdw/gopoker/history"
+	"html"
+	"io/ioutil"
+	"log"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const handsKey = "hands"
+const handsFileKey = "file"
+const playerKey = "player"
+
+// The largest hand history file which can be uploaded
+const maxHandsUpload = 32 << 20
+
+// The text of the hand histories in the uploaded file, or if there is none, pasted into the form
+func getHandsText(req *http.Request) (string, error) {
+	if req.MultipartForm != nil {
+		if files, ok := req.MultipartForm.File[handsFileKey]; ok && len(files) > 0 {
+			f, err := files[0].Open()
+			if err != nil {
+				return "", err
+			}
+			defer f.Close()
+			data, err := ioutil.ReadAll(f)
+			if err != nil {
+				return "", err
+			}
+			return string(data), nil
+		}
+	}
+	if handsStrs, ok := req.Form[handsKey]; ok && len(handsStrs) > 0 {
+		return handsStrs[0], nil
+	}
+	return "", nil
+}
+
+// The most deals which can be played out for each all-in, as a report may cover many all-ins
+const maxAllInDeals = 100000
+
+func getMaxDeals(req *http.Request) (int, error) {
+	maxDeals := 10000
+	if dealsStrs, ok := req.Form[simCountKey]; ok && len(dealsStrs) > 0 && len(dealsStrs[0]) > 0 {
+		deals, err := strconv.ParseInt(dealsStrs[0], 10, 32)
+		if err != nil {
+			return 0, errors.New(fmt.Sprintf("Could not parse simcount: %v", err))
+		}
+		maxDeals = int(deals)
+	}
+	if maxDeals < 1 || maxDeals > maxAllInDeals {
+		return 0, errors.New(fmt.Sprintf("Between 1 and %v deals allowed for each all-in, found %v", maxAllInDeals, maxDeals))
+	}
+	return maxDeals, nil
+}
+
+func printAllInEVForm(w http.ResponseWriter, player string, maxDeals int) {
+	fmt.Fprintln(w, `<form method="post" enctype="multipart/form-data">`)
+	fmt.Fprintf(w, `<div class="form-group"><label for="%v">Hand history file</label><input type="file" id="%v" name="%v"/></div>`, handsFileKey, handsFileKey, handsFileKey)
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, `<div class="form-group"><label for="%v">Or paste hands</label><textarea class="form-control" rows="8" id="%v" name="%v"></textarea></div>`, handsKey, handsKey, handsKey)
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, `<div class="form-group"><label for="%v">Player (blank for the hero of each hand)</label><input class="form-control" type="text" id="%v" name="%v" value="%v"/></div>`, playerKey, playerKey, playerKey, html.EscapeString(player))
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, `<div class="form-group"><label for="%v">Deals to play out for each all-in</label><input class="form-control" type="text" id="%v" name="%v" value="%v"/></div>`, simCountKey, simCountKey, simCountKey, maxDeals)
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `<button type="submit" class="btn btn-primary">Report</button></form>`)
+}
+
+func printSessions(w http.ResponseWriter, report *history.EVReport) {
+	fmt.Fprintln(w, `<div class="table-responsive"><table class="table table-bordered table-condensed">`)
+	fmt.Fprintln(w, `<tr><th>Start</th><th>Session</th><th>Hands</th><th>All-ins</th><th>Won</th><th>All-in luck</th><th>All-in adjusted</th></tr>`)
+	for _, s := range report.Sessions {
+		fmt.Fprintf(w, `<tr><td>%v</td><td>%v</td><td class="numcell">%v</td><td class="numcell">%v</td><td class="numcell">%v</td><td class="numcell">%v</td><td class="numcell">%v</td></tr>`,
+			s.Start.Format("2006-01-02 15:04"), html.EscapeString(s.Name()), s.Hands, s.AllIns, s.FormatAmount(float64(s.Won)), s.FormatAmount(s.Luck), s.FormatAmount(s.Adjusted()))
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintln(w, "</table></div>")
+}
+
+// A count of things, such as "1 hand" or "2 hands"
+func countOf(n int, thing string) string {
+	if n == 1 {
+		return "1 " + thing
+	}
+	return fmt.Sprintf("%v %vs", n, thing)
+}
+
+// Draw the cumulative luck after each all-in as a line, with a line across at zero
+func printLuckCurve(w http.ResponseWriter, curve []history.LuckPoint) {
+	if len(curve) == 0 {
+		fmt.Fprintln(w, "<p>No all-ins before the river were found.</p>")
+		return
+	}
+	const width, height = 800.0, 300.0
+	low, high := 0.0, 0.0
+	for _, p := range curve {
+		low, high = math.Min(low, p.Total), math.Max(high, p.Total)
+	}
+	if high == low {
+		high = low + 1
+	}
+	y := func(total float64) float64 {
+		return height - (total-low)/(high-low)*height
+	}
+	points := []string{fmt.Sprintf("0,%.1f", y(0))}
+	for i, p := range curve {
+		points = append(points, fmt.Sprintf("%.1f,%.1f", float64(i+1)*width/float64(len(curve)), y(p.Total)))
+	}
+	fmt.Fprintf(w, `<svg width="100%%" viewBox="0 0 %v %v" preserveAspectRatio="none" style="max-width: %vpx; height: %vpx; border: 1px solid #ddd">`, width, height, width, height)
+	fmt.Fprintf(w, `<line x1="0" y1="%.1f" x2="%v" y2="%.1f" stroke="#999" stroke-dasharray="4"/>`, y(0), width, y(0))
+	fmt.Fprintf(w, `<polyline fill="none" stroke="#337ab7" stroke-width="2" points="%v"/>`, strings.Join(points, " "))
+	fmt.Fprintln(w, "</svg>")
+	fmt.Fprintf(w, "<p>Cumulative luck from %.2f to %.2f big blinds over %v</p>\n", low, high, countOf(len(curve), "all-in"))
+
+	fmt.Fprintln(w, `<div class="table-responsive"><table class="table table-bordered table-condensed">`)
+	fmt.Fprintln(w, `<tr><th>Time</th><th>Hand</th><th>Street</th><th>Equity</th><th>Luck (big blinds)</th><th>Total</th></tr>`)
+	for _, p := range curve {
+		fmt.Fprintf(w, `<tr><td>%v</td><td>%v</td><td>%v</td><td class="numcell">%.1f%%</td><td class="numcell">%+.2f</td><td class="numcell">%+.2f</td></tr>`,
+			p.Hand.PlayedAt().Format("2006-01-02 15:04"), html.EscapeString(p.Hand.HandName()), p.Street, 100*p.Equity.Equity[p.Seat], p.Luck, p.Total)
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintln(w, "</table></div>")
+}
+
+// Report a player's all-in adjusted winnings over the hands in a PokerStars-style text hand history file, which is
+// uploaded as file or else pasted as hands. The player is chosen by name with player (by default the hero of each
+// hand), and simcount limits the number of ways of completing the board played out for each all-in.
+func AllInEV(w http.ResponseWriter, req *http.Request) {
+	if err := req.ParseMultipartForm(maxHandsUpload); err != nil && err != http.ErrNotMultipart {
+		http.Error(w, fmt.Sprintf("Could not parse form: %v", err), http.StatusBadRequest)
+		return
+	}
+	text, err := getHandsText(req)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Could not read hand histories: %v", err), http.StatusBadRequest)
+		return
+	}
+	player := strings.TrimSpace(req.FormValue(playerKey))
+	maxDeals, err := getMaxDeals(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	seed, err := getSeed(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var report *history.EVReport
+	var problems []history.ImportError
+	var hands []*history.HandHistory
+	if strings.TrimSpace(text) != "" {
+		hands, problems, err = history.ImportText(strings.NewReader(text))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Could not read hand histories: %v", err), http.StatusBadRequest)
+			return
+		}
+		report, err = history.NewEVReport(req.Context(), hands, player, maxDeals, 0, rand.New(rand.NewSource(seed)))
+		if err != nil && req.Context().Err() != nil {
+			log.Println("All-in EV report abandoned:", err)
+			return
+		}
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Could not produce report: %v", err), http.StatusBadRequest)
+			return
+		}
+	}
+
+	fmt.Fprintln(w, "<!DOCTYPE html>")
+	fmt.Fprintln(w, `<html lang="en"><head><title>All-in Adjusted Winnings</title>`)
+	fmt.Fprintln(w, `<meta name="viewport" content="width=device-width, initial-scale=1">`)
+	fmt.Fprintln(w, `<link rel="stylesheet" href="https://maxcdn.bootstrapcdn.com/bootstrap/3.3.7/css/bootstrap.min.css">`)
+	fmt.Fprintln(w, `</head><body><div class="container">`)
+	fmt.Fprintln(w, "<h1>All-in Adjusted Winnings</h1>")
+	if report == nil {
+		fmt.Fprintln(w, "<p>Upload or paste hand histories in PokerStars text format. For every all-in before the river, each player's equity is worked out and their winnings compared with what their equity entitled them to. Rake is ignored in working out the luck of each all-in.</p>")
+		printAllInEVForm(w, player, maxDeals)
+		fmt.Fprintln(w, "</div></body></html>")
+		return
+	}
+
+	fmt.Fprintf(w, "<p>Read %v. Random seed: %v</p>\n", countOf(len(hands), "hand"), seed)
+	if len(problems) > 0 {
+		fmt.Fprintf(w, "<p>Found %v reading the hand histories, and skipped the hands affected:</p><ul>\n", countOf(len(problems), "problem"))
+		for _, p := range problems {
+			fmt.Fprintf(w, "<li>%v</li>\n", html.EscapeString(p.Error()))
+		}
+		fmt.Fprintln(w, "</ul>")
+	}
+	fmt.Fprintln(w, "<h2>Sessions</h2>")
+	printSessions(w, report)
+	fmt.Fprintln(w, "<h2>Luck curve</h2>")
+	printLuckCurve(w, report.Curve)
+	fmt.Fprintln(w, "</div></body></html>")
+}
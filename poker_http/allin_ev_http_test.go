@@ -0,0 +1,133 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package poker_http
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// A hand in which Alice and Bob are all in before the flop, cut down to the lines the importer needs
+const allInHand = `PokerStars Hand #200000000004:  Hold'em No Limit ($0.01/$0.02 USD) - 2020/03/14 12:10:00 ET
+Table 'Alpha II' 6-max Seat #3 is the button
+Seat 1: Alice ($1.50 in chips)
+Seat 3: Bob ($1.50 in chips)
+Bob: posts small blind $0.01
+Alice: posts big blind $0.02
+Dealt to Alice [Ah Ad]
+Bob: raises $1.48 to $1.50 and is all-in
+Alice: calls $1.48 and is all-in
+*** RIVER *** [Kc Ks 2d 7h Ac]
+Bob: shows [Kh 9h]
+Alice collected $3 from pot
+*** SUMMARY ***
+`
+
+func TestAllInEVForm(t *testing.T) {
+	rec := httptest.NewRecorder()
+	req, err := http.NewRequest("GET", fmt.Sprintf("%v/history/allinev", baseUrl), nil)
+	if err != nil {
+		t.Fatalf("Could not generate HTTP request: %v", err)
+	}
+	AllInEV(rec, req)
+	assertOkHtml(rec, t)
+	if !strings.Contains(rec.Body.String(), `<form method="post" enctype="multipart/form-data">`) {
+		t.Errorf("Expected form in response: %v", rec.Body.String())
+	}
+}
+
+func TestAllInEVPasted(t *testing.T) {
+	form := url.Values{handsKey: {allInHand + "\nGarbage\n"}, seedKey: {"1234"}}
+	rec := httptest.NewRecorder()
+	req, err := http.NewRequest("POST", fmt.Sprintf("%v/history/allinev", baseUrl), strings.NewReader(form.Encode()))
+	if err != nil {
+		t.Fatalf("Could not generate HTTP request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	AllInEV(rec, req)
+	assertOkHtml(rec, t)
+	for _, expected := range []string{"Read 1 hand.", "Cash game (USD)", "<polyline", "<td>Preflop</td>", "+25.15"} {
+		if !strings.Contains(rec.Body.String(), expected) {
+			t.Errorf("Expected to find %q in response: %v", expected, rec.Body.String())
+		}
+	}
+}
+
+func TestAllInEVUpload(t *testing.T) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	fw, err := mw.CreateFormFile(handsFileKey, "hands.txt")
+	if err != nil {
+		t.Fatalf("Could not create form file: %v", err)
+	}
+	fw.Write([]byte("Not a hand\n" + allInHand))
+	mw.WriteField(playerKey, "Bob")
+	mw.WriteField(seedKey, "1234")
+	mw.Close()
+
+	rec := httptest.NewRecorder()
+	req, err := http.NewRequest("POST", fmt.Sprintf("%v/history/allinev", baseUrl), &body)
+	if err != nil {
+		t.Fatalf("Could not generate HTTP request: %v", err)
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	AllInEV(rec, req)
+	assertOkHtml(rec, t)
+	for _, expected := range []string{"Found 1 problem reading", "Line 1: Expected the start of a hand", "-25.15"} {
+		if !strings.Contains(rec.Body.String(), expected) {
+			t.Errorf("Expected to find %q in response: %v", expected, rec.Body.String())
+		}
+	}
+}
+
+func TestAllInEVCancel(t *testing.T) {
+	form := url.Values{handsKey: {allInHand}, seedKey: {"1234"}}
+	rec := httptest.NewRecorder()
+	req, err := http.NewRequest("POST", fmt.Sprintf("%v/history/allinev", baseUrl), strings.NewReader(form.Encode()))
+	if err != nil {
+		t.Fatalf("Could not generate HTTP request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	ctx, cancel := context.WithCancel(req.Context())
+	cancel()
+	AllInEV(rec, req.WithContext(ctx))
+	if rec.Body.Len() != 0 {
+		t.Errorf("Expected no response after client disconnected, found %v", rec.Body.String())
+	}
+}
+
+func TestAllInEVInputValidation(t *testing.T) {
+	for _, query := range []string{"simcount=wibble", "simcount=0", "simcount=100001", "seed=wibble"} {
+		rec := httptest.NewRecorder()
+		req, err := http.NewRequest("GET", fmt.Sprintf("%v/history/allinev?%v", baseUrl, query), nil)
+		if err != nil {
+			t.Fatalf("Could not generate HTTP request: %v", err)
+		}
+		AllInEV(rec, req)
+		assertBadRequest(rec, t)
+	}
+}
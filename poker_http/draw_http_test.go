@@ -0,0 +1,54 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package poker_http
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDrawSimulation(t *testing.T) {
+	rec := httptest.NewRecorder()
+	req, err := http.NewRequest("GET", fmt.Sprintf("%v/draw/simulate?yours=AS,AC,KD,7H,2S&players=3&simcount=200&seed=1234", baseUrl), nil)
+	if err != nil {
+		t.Fatalf("Could not generate HTTP request: %v", err)
+	}
+	SimulateDraw(rec, req)
+	assertOkHtml(rec, t)
+	body := rec.Body.String()
+	if !strings.Contains(body, "Stand pat") || !strings.Contains(body, "<td>32</td>") {
+		t.Errorf("Expected all 32 discard choices: %v", body)
+	}
+}
+
+func TestDrawSimulationInputValidation(t *testing.T) {
+	for _, query := range []string{"yours=AS,AC,KD,7H", "yours=AS,AC,KD,7H,2S&players=6", "yours=AS,AC,KD,7H,2S&table=3S", "yours=AS,AC,KD,7H,AS", "yours=wibble", "yours=AS,AC,KD,7H,JK1"} {
+		rec := httptest.NewRecorder()
+		req, err := http.NewRequest("GET", fmt.Sprintf("%v/draw/simulate?%v", baseUrl, query), nil)
+		if err != nil {
+			t.Fatalf("Could not generate HTTP request: %v", err)
+		}
+		SimulateDraw(rec, req)
+		assertBadRequest(rec, t)
+	}
+}
@@ -0,0 +1,262 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package poker_http
+
+import (
+	"errors"
+	"fmt"
+	"github.com/amdw/gopoker/history"
+	_ "github.com/amdw/gopoker/holdem" // Registers the Hold'em games
+	_ "github.com/amdw/gopoker/omaha"  // Registers the Omaha games
+	_ "github.com/amdw/gopoker/omaha8" // Registers the Omaha/8 games
+	"github.com/amdw/gopoker/poker"
+	"html"
+	"log"
+	"math"
+	"math/rand"
+	"net/http"
+	"net/url"
+)
+
+// Get the registered game named in the request, or nil if none is named
+func getGame(req *http.Request) (poker.Game, error) {
+	gameStrs, ok := req.Form[gameKey]
+	if !ok || len(gameStrs) == 0 || len(gameStrs[0]) == 0 {
+		return nil, nil
+	}
+	g, ok := poker.LookupGame(gameStrs[0])
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("Unknown game %q", gameStrs[0]))
+	}
+	return g, nil
+}
+
+func printGamePageHead(w http.ResponseWriter, title string) {
+	fmt.Fprintln(w, "<!DOCTYPE html>")
+	fmt.Fprintf(w, "<html lang=\"en\"><head><title>%v</title>\n", html.EscapeString(title))
+	fmt.Fprintln(w, `<meta name="viewport" content="width=device-width, initial-scale=1">`)
+	fmt.Fprintln(w, `<link rel="stylesheet" href="https://maxcdn.bootstrapcdn.com/bootstrap/3.3.7/css/bootstrap.min.css" integrity="sha384-BVYiiSIFeK1dGmJRAkycuHAHRg32OmUcww7on3RYdg4Va+PmSTsz/K68vbdEjh4u" crossorigin="anonymous">`)
+	fmt.Fprintln(w, "<style>")
+	fmt.Fprintln(w, "th { text-align: center }")
+	fmt.Fprintln(w, "td.numcell { text-align: right }")
+	fmt.Fprintln(w, "td.zero, .nothing { color: lightgrey }")
+	fmt.Fprintln(w, "td.tickcell { text-align: center }")
+	fmt.Fprintln(w, ".summary { font-weight: bold }")
+	fmt.Fprintln(w, "</style>")
+	fmt.Fprintln(w, `</head><body><div class="container-fluid">`)
+	fmt.Fprintf(w, "<h1>%v</h1>\n", html.EscapeString(title))
+}
+
+// List the registered games, each linking to the given path
+func printGameList(w http.ResponseWriter, path string) {
+	fmt.Fprintln(w, "<ul>")
+	for _, key := range poker.GameKeys() {
+		g, _ := poker.LookupGame(key)
+		fmt.Fprintf(w, `<li><a href="%v?%v=%v">%v</a></li>`, path, gameKey, url.QueryEscape(key), html.EscapeString(g.Name()))
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintln(w, "</ul>")
+}
+
+func printTickCell(w http.ResponseWriter, tick bool) {
+	if tick {
+		fmt.Fprintf(w, `<td class="tickcell">&#9989;</td>`)
+	} else {
+		fmt.Fprintf(w, "<td></td>")
+	}
+}
+
+// Deal a single hand of any registered game and show the outcome
+func PlayGame(w http.ResponseWriter, req *http.Request) {
+	req.ParseForm()
+	g, err := getGame(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if g == nil {
+		printGamePageHead(w, "Play a game")
+		printGameList(w, req.URL.Path)
+		fmt.Fprintln(w, "</div></body></html>")
+		return
+	}
+	players, err := getPlayers(req)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error getting player count: %v", err), http.StatusBadRequest)
+		return
+	}
+	if err = g.ValidateKnownCards(nil, nil, players); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	seed, err := getSeed(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	pack := g.NewPack()
+	randGen := rand.New(rand.NewSource(seed))
+	pack.Shuffle(randGen)
+	tableCards, playerCards := g.Layout().Deal(&pack, players)
+	outcomes := g.Outcomes(tableCards, playerCards)
+	hh, err := history.RecordDeal(req.Form.Get(gameKey), tableCards, playerCards)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if writeHistory(w, req, hh) {
+		return
+	}
+
+	printGamePageHead(w, fmt.Sprintf("Example %v game", g.Name()))
+	fmt.Fprintln(w, `<form method="get">`)
+	fmt.Fprintln(w, `<div class="form-group"><label for="playerCount">Players</label>`)
+	fmt.Fprintf(w, `<input type="text" id="playerCount" name="%v" value="%v" class="form-control"/>`, playersKey, players)
+	fmt.Fprintln(w, "</div>")
+	fmt.Fprintf(w, `<input type="hidden" name="%v" value="%v"/>`, gameKey, html.EscapeString(req.Form.Get(gameKey)))
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `<button type="submit" class="btn btn-default">Rerun</button></form>`)
+
+	if len(tableCards) > 0 {
+		fmt.Fprintf(w, "<h3>Table cards</h3><p>%v</p>\n", formatCards(tableCards))
+	}
+	fmt.Fprintln(w, "<h3>Player cards</h3><ul>")
+	for playerIdx := range playerCards {
+		fmt.Fprintf(w, "<li>Player %v: %v</li>\n", playerIdx+1, formatCards(playerCards[playerIdx]))
+	}
+	fmt.Fprintln(w, "</ul>")
+
+	fmt.Fprintln(w, "<h3>Results</h3>")
+	fmt.Fprintln(w, `<table class="table table-bordered">`)
+	hiLo := g.SettlementRules().HiLo
+	if hiLo {
+		fmt.Fprintln(w, `<tr><th rowspan="2">Player</th><th colspan="3">High</th><th colspan="3">Low</th><th rowspan="2">Winnings</th></tr>`)
+		fmt.Fprintln(w, `<tr><th>Hand</th><th>Cards</th><th>Win?</th><th>Hand</th><th>Cards</th><th>Win?</th></tr>`)
+	} else {
+		fmt.Fprintln(w, `<tr><th>Player</th><th>Hand</th><th>Cards</th><th>Win?</th><th>Winnings</th></tr>`)
+	}
+	for _, outcome := range outcomes {
+		fmt.Fprintln(w, "<tr>")
+		fmt.Fprintf(w, `<td>%v</td>`, outcome.Player)
+		fmt.Fprintf(w, `<td>%v</td><td>%v</td>`, outcome.Level.PrettyPrint(), formatCards(outcome.Cards))
+		printTickCell(w, outcome.PotFractionWon > outcome.LowPotFractionWon)
+		if hiLo {
+			if outcome.HasLow {
+				fmt.Fprintf(w, `<td>%v</td><td>%v</td>`, outcome.Low.PrettyPrint(), formatCards(outcome.LowCards))
+			} else {
+				fmt.Fprintf(w, `<td class="nothing">None</td><td class="nothing">None</td>`)
+			}
+			printTickCell(w, outcome.LowPotFractionWon > 0)
+		}
+		fracClass := ""
+		if outcome.PotFractionWon == 0 {
+			fracClass = " nothing"
+		}
+		fmt.Fprintf(w, `<td class="numcell%v">%.1f%%</td>`, fracClass, 100*outcome.PotFractionWon)
+		fmt.Fprintln(w, "</tr>")
+	}
+	fmt.Fprintln(w, "</table>")
+	printSeed(w, req, seed)
+	printHistoryLinks(w, req, seed)
+	fmt.Fprintln(w, "</div></body></html>")
+}
+
+// The statistics for the low half of the pot in a hi-lo game, with 95% confidence intervals
+func printLowStatsTable(w http.ResponseWriter, simulator *poker.Simulator) {
+	printRow := func(name string, e poker.Estimate) {
+		fmt.Fprintf(w, `<tr><td>%v</td><td class="numcell">%v</td></tr>`, name, formatInterval(e.ConfidenceInterval(poker.Z95).Clamped(), simulator.Exhaustive))
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintln(w, `<div class="table-responsive"><table class="table table-bordered table-condensed">`)
+	fmt.Fprintln(w, `<tr><th>Low half of the pot</th><th>Estimate</th></tr>`)
+	printRow("Qualifying low", simulator.LowQualifyRate())
+	printRow("Low win (sole or joint)", simulator.LowWinRate())
+	fmt.Fprintf(w, `<tr><td>Pots won with low</td><td class="numcell">%.1f%%</td></tr>`, 100*simulator.LowPotsWon/float64(simulator.HandCount))
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "</table></div>")
+}
+
+// Simulate any registered game, given the known table cards and your cards
+func SimulateGame(w http.ResponseWriter, req *http.Request) {
+	req.ParseForm()
+	g, err := getGame(req)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Could not get simulation parameters: %v", err), http.StatusBadRequest)
+		return
+	}
+	if g == nil {
+		printGamePageHead(w, "Simulate a game")
+		printGameList(w, req.URL.Path)
+		fmt.Fprintln(w, "</div></body></html>")
+		return
+	}
+	params, err := parseSimulationParams(req, g.Layout().HoleCards)
+	if err == nil {
+		err = g.ValidateKnownCards(params.tableCards, params.yourCards, params.players)
+	}
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Could not get simulation parameters: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	randGen := rand.New(rand.NewSource(params.seed))
+	var simulator *poker.Simulator
+	if params.targetHalfWidth > 0 {
+		simulator, err = poker.SimulateGameAdaptive(req.Context(), g, params.tableCards, params.yourCards, params.players, params.targetHalfWidth, params.handsToPlay, 0, randGen, nil)
+	} else {
+		simulator, err = poker.SimulateGameParallelContext(req.Context(), g, params.tableCards, params.yourCards, params.players, params.handsToPlay, 0, randGen, nil)
+	}
+	if err != nil {
+		log.Println("Simulation abandoned:", err)
+		return
+	}
+
+	printGamePageHead(w, fmt.Sprintf("%v Simulator", g.Name()))
+	if len(params.tableCards) > 0 {
+		fmt.Fprintf(w, "<p>Table cards: %v</p>\n", formatCards(params.tableCards))
+	}
+	if len(params.yourCards) > 0 {
+		fmt.Fprintf(w, "<p>Your cards: %v</p>\n", formatCards(params.yourCards))
+	}
+	fmt.Fprintf(w, "<p>Players: %v</p>\n", params.players)
+
+	fmt.Fprintln(w, "<h2>Results</h2>")
+	printSeed(w, req, params.seed)
+
+	breakEven := simulator.PotOddsBreakEven()
+	if math.IsInf(breakEven, 1) {
+		fmt.Fprintln(w, "<p><b>Any</b> bet has positive expected value! :)</p>")
+	} else {
+		fmt.Fprintf(w, "<p>A bet up to %.1f%% of the pot has positive expected value.</p>\n", 100.0*breakEven)
+	}
+
+	fmt.Fprintln(w, `<div class="row"><div class="col-md-6">`)
+	printStatsTable(w, simulator)
+	if g.SettlementRules().HiLo {
+		printLowStatsTable(w, simulator)
+	}
+	fmt.Fprintln(w, `</div></div>`)
+	fmt.Fprintln(w, `<div class="row"><div class="col-xs-12">`)
+	printResultTable(w, simulator)
+	fmt.Fprintln(w, `</div></div>`)
+
+	fmt.Fprintln(w, "</div></body></html>")
+}
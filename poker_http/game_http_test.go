@@ -0,0 +1,178 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package poker_http
+
+import (
+	"fmt"
+	"github.com/amdw/gopoker/history"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestPlayGame(t *testing.T) {
+	tests := map[string]string{
+		"":                          `<a href="/game/play?game=omaha8">Omaha/8</a>`,
+		"game=holdem&seed=1234":     "Example Texas Hold&#39;em game",
+		"game=bigo&players=3":       "Example Big O game",
+		"game=shortdeck&players=15": "Example Short-deck Hold&#39;em game",
+		"game=holdem-bug&seed=1234": "Example Texas Hold&#39;em (joker as bug wild) game",
+		"game=omaha8&seed=1234":     `<th colspan="3">Low</th>`,
+		"game=omaha&seed=1234":      "history=json",
+	}
+	for query, expected := range tests {
+		rec := httptest.NewRecorder()
+		req, err := http.NewRequest("GET", fmt.Sprintf("%v/game/play?%v", baseUrl, query), nil)
+		if err != nil {
+			t.Fatalf("Could not generate HTTP request: %v", err)
+		}
+		PlayGame(rec, req)
+		assertOkHtml(rec, t)
+		if !strings.Contains(rec.Body.String(), expected) {
+			t.Errorf("Expected to find %q in response to %v: %v", expected, query, rec.Body.String())
+		}
+	}
+
+	for _, query := range []string{"game=wibble", "game=holdem&players=wibble", "game=omaha6&players=8", "game=holdem&seed=wibble", "game=shortdeck&players=16", "game=holdem&history=wibble"} {
+		rec := httptest.NewRecorder()
+		req, err := http.NewRequest("GET", fmt.Sprintf("%v/game/play?%v", baseUrl, query), nil)
+		if err != nil {
+			t.Fatalf("Could not generate HTTP request: %v", err)
+		}
+		PlayGame(rec, req)
+		assertBadRequest(rec, t)
+	}
+}
+
+func TestPlayGameSeed(t *testing.T) {
+	play := func() string {
+		rec := httptest.NewRecorder()
+		req, err := http.NewRequest("GET", fmt.Sprintf("%v/game/play?game=holdem&players=4&seed=1234", baseUrl), nil)
+		if err != nil {
+			t.Fatalf("Could not generate HTTP request: %v", err)
+		}
+		PlayGame(rec, req)
+		assertOkHtml(rec, t)
+		return rec.Body.String()
+	}
+	body := play()
+	if body != play() {
+		t.Errorf("Expected identical games from identical seeds")
+	}
+	if !strings.Contains(body, "seed=1234") {
+		t.Errorf("Expected seed to be echoed in response: %v", body)
+	}
+}
+
+func TestPlayGameHistory(t *testing.T) {
+	play := func(query string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req, err := http.NewRequest("GET", fmt.Sprintf("%v/game/play?seed=1234&%v", baseUrl, query), nil)
+		if err != nil {
+			t.Fatalf("Could not generate HTTP request: %v", err)
+		}
+		PlayGame(rec, req)
+		return rec
+	}
+
+	tests := []struct {
+		query     string
+		game      string
+		seats     int
+		holeCards int
+	}{
+		{"game=holdem&players=4&history=json", "holdem", 4, 2},
+		{"game=omaha8-6&players=7&history=json", "omaha8-6", 7, 6},
+		{"game=shortdeck&players=3&history=json", "shortdeck", 3, 2},
+	}
+	for _, test := range tests {
+		rec := play(test.query)
+		assertOkJson(rec, t)
+		hh, err := history.ParseJSON(rec.Body.Bytes())
+		if err != nil {
+			t.Fatalf("Could not parse hand history for %v: %v", test.query, err)
+		}
+		if hh.Game != test.game || len(hh.Seats) != test.seats || len(hh.Seats[0].Cards) != test.holeCards || len(hh.Board) != 5 {
+			t.Errorf("Unexpected hand history for %v: %+v", test.query, hh)
+		}
+		if err := history.Verify(hh); err != nil {
+			t.Errorf("Could not verify hand history for %v: %v", test.query, err)
+		}
+	}
+
+	rec := play("game=bigo&history=text")
+	assertStatus(200, "text/plain; charset=utf-8", rec, t)
+	if !strings.Contains(rec.Body.String(), "*** SUMMARY ***") {
+		t.Errorf("Expected text hand history: %v", rec.Body.String())
+	}
+}
+
+func TestSimulateGame(t *testing.T) {
+	tests := map[string]string{
+		"": `<a href="/game/simulate?game=shortdeck-trips">Short-deck Hold&#39;em (trips beat a straight)</a>`,
+		"game=holdem&yours=" + url.QueryEscape("AS,AD") + "&simcount=1000":                    "Texas Hold&#39;em Simulator",
+		"game=omaha5&table=" + url.QueryEscape("2C,7D,9S") + "&precision=2":                   "Five-card Omaha Simulator",
+		"game=omaha8&yours=" + url.QueryEscape("AS,2D,3C,KH") + "&simcount=1000&players=3":    "Omaha/8 Simulator",
+		"game=shortdeck-trips&yours=" + url.QueryEscape("AS,KS") + "&simcount=1000&seed=1234": "Random seed:",
+		"game=holdem-jokers&yours=" + url.QueryEscape("JK1,JK2") + "&simcount=1000":           "Five of a Kind",
+		"game=bigo&yours=" + url.QueryEscape("AS,2C,3D,KH,KS") + "&simcount=1000":             "Qualifying low",
+	}
+	for query, expected := range tests {
+		rec := httptest.NewRecorder()
+		req, err := http.NewRequest("GET", fmt.Sprintf("%v/game/simulate?%v", baseUrl, query), nil)
+		if err != nil {
+			t.Fatalf("Could not generate HTTP request: %v", err)
+		}
+		SimulateGame(rec, req)
+		assertOkHtml(rec, t)
+		if !strings.Contains(rec.Body.String(), expected) {
+			t.Errorf("Expected to find %q in response to %v: %v", expected, query, rec.Body.String())
+		}
+	}
+}
+
+func TestSimulateGameInputValidation(t *testing.T) {
+	tests := map[string]string{
+		"game=wibble": "Unknown game \"wibble\"",
+		"game=holdem&yours=" + url.QueryEscape("AS,KS,QS"):        "Maximum of 2 player cards allowed",
+		"game=omaha6&players=8":                                   "Between 2 and 7 players can play Six-card Omaha",
+		"game=shortdeck&table=" + url.QueryEscape("5C"):           "Card 5C is not in the pack for Short-deck Hold'em",
+		"game=omaha&yours=" + url.QueryEscape("AS") + "&table=AS": "Found duplicate card AS",
+		"game=holdem&simcount=wibble":                             "Could not parse simcount",
+		"game=holdem&yours=" + url.QueryEscape("JK1,AS"):          "Card JK1 is not in the pack for Texas Hold'em",
+		"game=omaha8&yours=" + url.QueryEscape("AS,2C,3D,KH,KS"):  "Maximum of 4 player cards allowed",
+		"game=omaha8&table=JK2":                                   "Card JK2 is not in the pack for Omaha/8",
+	}
+	for query, expectedError := range tests {
+		rec := httptest.NewRecorder()
+		url := fmt.Sprintf("%v/game/simulate?%v", baseUrl, query)
+		req, err := http.NewRequest("GET", url, nil)
+		if err != nil {
+			t.Fatalf("Could not generate HTTP request: %v", err)
+		}
+		SimulateGame(rec, req)
+		assertBadRequest(rec, t)
+		if !strings.Contains(rec.Body.String(), expectedError) {
+			t.Errorf("Could not find expected error '%v' in response for %v: %v", expectedError, url, rec.Body.String())
+		}
+	}
+}
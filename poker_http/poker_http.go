@@ -22,10 +22,14 @@ package poker_http
 import (
 	"errors"
 	"fmt"
+	"github.com/amdw/gopoker/history"
 	"github.com/amdw/gopoker/poker"
+	"html"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
+	"time"
 )
 
 func Menu(w http.ResponseWriter, req *http.Request) {
@@ -33,13 +37,40 @@ func Menu(w http.ResponseWriter, req *http.Request) {
 	fmt.Fprintln(w, `<meta name="viewport" content="width=device-width, initial-scale=1">`)
 	fmt.Fprintln(w, "<title>Poker</title></head><body><h1>Poker</h1><ul>")
 	fmt.Fprintln(w, "<li>Texas Holdem<ul>")
-	fmt.Fprintln(w, `<li><a href="/holdem/play">Play</a></li>`)
+	fmt.Fprintln(w, `<li><a href="/game/play?game=holdem">Play</a></li>`)
 	fmt.Fprintln(w, `<li><a href="/holdem/simulate">Simulate</a></li>`)
 	fmt.Fprintln(w, `<li><a href="/holdem/startingcards">Starting cards</a></li>`)
+	fmt.Fprintln(w, `<li><a href="/holdem/rangeequity?range1=QQ%2B,AK&amp;range2=JJ-22">Range equity</a></li>`)
+	fmt.Fprintln(w, `<li><a href="/game/play?game=shortdeck">Play short-deck</a></li>`)
+	fmt.Fprintln(w, `<li><a href="/holdem/simulate?game=shortdeck">Simulate short-deck</a></li>`)
+	fmt.Fprintln(w, "</ul></li>")
+	fmt.Fprintln(w, "<li>Omaha<ul>")
+	fmt.Fprintln(w, `<li><a href="/game/play?game=omaha">Play</a></li>`)
+	fmt.Fprintln(w, `<li><a href="/game/simulate?game=omaha">Simulate</a></li>`)
+	fmt.Fprintln(w, `<li><a href="/game/play?game=omaha5">Play five-card Omaha</a></li>`)
+	fmt.Fprintln(w, `<li><a href="/game/simulate?game=omaha5">Simulate five-card Omaha</a></li>`)
+	fmt.Fprintln(w, `<li><a href="/game/play?game=omaha6">Play six-card Omaha</a></li>`)
+	fmt.Fprintln(w, `<li><a href="/game/simulate?game=omaha6">Simulate six-card Omaha</a></li>`)
 	fmt.Fprintln(w, "</ul></li>")
 	fmt.Fprintln(w, "<li>Omaha/8<ul>")
-	fmt.Fprintln(w, `<li><a href="/omaha8/play">Play</a></li>`)
-	fmt.Fprintln(w, `<li><a href="/omaha8/simulate">Simulate</a></li>`)
+	fmt.Fprintln(w, `<li><a href="/game/play?game=omaha8">Play</a></li>`)
+	fmt.Fprintln(w, `<li><a href="/game/simulate?game=omaha8">Simulate</a></li>`)
+	fmt.Fprintln(w, `<li><a href="/game/play?game=bigo">Play Big O</a></li>`)
+	fmt.Fprintln(w, `<li><a href="/game/simulate?game=bigo">Simulate Big O</a></li>`)
+	fmt.Fprintln(w, "</ul></li>")
+	fmt.Fprintln(w, "<li>Razz<ul>")
+	fmt.Fprintln(w, `<li><a href="/razz/play">Play</a></li>`)
+	fmt.Fprintln(w, `<li><a href="/razz/simulate">Simulate</a></li>`)
+	fmt.Fprintln(w, "</ul></li>")
+	fmt.Fprintln(w, "<li>Five-card draw<ul>")
+	fmt.Fprintln(w, `<li><a href="/draw/simulate?yours=AS,AC,KD,7H,2S&amp;players=3">Compare discards</a></li>`)
+	fmt.Fprintln(w, "</ul></li>")
+	fmt.Fprintln(w, "<li>Any flop game<ul>")
+	fmt.Fprintln(w, `<li><a href="/game/play">Play</a></li>`)
+	fmt.Fprintln(w, `<li><a href="/game/simulate">Simulate</a></li>`)
+	fmt.Fprintln(w, "</ul></li>")
+	fmt.Fprintln(w, "<li>Hand histories<ul>")
+	fmt.Fprintln(w, `<li><a href="/history/allinev">All-in adjusted winnings</a></li>`)
 	fmt.Fprintln(w, "</ul></li>")
 	fmt.Fprintln(w, "</ul></body></html>")
 }
@@ -68,3 +99,108 @@ func getPlayers(req *http.Request) (int, error) {
 	}
 	return players, nil
 }
+
+const gameKey = "game"
+const shortDeckGame = "shortdeck"
+const tripsBeatStraightKey = "tripsbeatstraight"
+
+// Get the rules for short-deck Hold'em if the request asks for it, or nil for the standard game
+func getShortDeckRules(req *http.Request) (*poker.ShortDeckRules, error) {
+	game := ""
+	if gameStrs, ok := req.Form[gameKey]; ok && len(gameStrs) > 0 {
+		game = gameStrs[0]
+	}
+	switch game {
+	case "", "holdem":
+		return nil, nil
+	case shortDeckGame:
+		rules := poker.ShortDeckRules{}
+		if tbsStrs, ok := req.Form[tripsBeatStraightKey]; ok && len(tbsStrs) == 1 && strings.EqualFold(tbsStrs[0], "true") {
+			rules.TripsBeatStraight = true
+		}
+		return &rules, nil
+	}
+	return nil, errors.New(fmt.Sprintf("Unknown game %q", game))
+}
+
+// The name of the Hold'em variant played under the given short-deck rules, if any
+func holdemVariantName(rules *poker.ShortDeckRules) string {
+	if rules == nil {
+		return "Texas Hold'em"
+	}
+	if rules.TripsBeatStraight {
+		return "Short-deck Hold'em (trips beat a straight)"
+	}
+	return "Short-deck Hold'em"
+}
+
+const seedKey = "seed"
+
+// Get the random seed supplied in the request, or generate a new one if there isn't one
+func getSeed(req *http.Request) (int64, error) {
+	if seedStrs, ok := req.Form[seedKey]; ok && len(seedStrs) > 0 && len(seedStrs[0]) > 0 {
+		seed, err := strconv.ParseInt(seedStrs[0], 10, 64)
+		if err != nil {
+			return 0, errors.New(fmt.Sprintf("Could not parse seed: %v", err))
+		}
+		return seed, nil
+	}
+	return time.Now().UnixNano(), nil
+}
+
+// Print the seed which was used, with a link which will reproduce this page exactly
+func printSeed(w http.ResponseWriter, req *http.Request, seed int64) {
+	query := url.Values{}
+	for key, values := range req.Form {
+		query[key] = values
+	}
+	query.Set(seedKey, strconv.FormatInt(seed, 10))
+	link := req.URL.Path + "?" + query.Encode()
+	fmt.Fprintf(w, `<p>Random seed: <a href="%v">%v</a></p>`, html.EscapeString(link), seed)
+	fmt.Fprintln(w)
+}
+
+const historyKey = "history"
+
+// If the request asks for the hand history ("json" or "text"), write it instead of the page and return true
+func writeHistory(w http.ResponseWriter, req *http.Request, hh *history.HandHistory) bool {
+	format := ""
+	if formatStrs, ok := req.Form[historyKey]; ok && len(formatStrs) > 0 {
+		format = strings.ToLower(formatStrs[0])
+	}
+	switch format {
+	case "":
+		return false
+	case "json":
+		data, err := hh.JSON()
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Could not write hand history: %v", err), http.StatusInternalServerError)
+			return true
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data)
+	case "text":
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		hh.WriteText(w)
+	default:
+		http.Error(w, fmt.Sprintf("Unknown hand history format %q", format), http.StatusBadRequest)
+	}
+	return true
+}
+
+// Print links to the hand history of the hand shown, which the seed reproduces
+func printHistoryLinks(w http.ResponseWriter, req *http.Request, seed int64) {
+	links := make([]string, 0, 2)
+	for _, format := range []string{"json", "text"} {
+		query := url.Values{}
+		for key, values := range req.Form {
+			query[key] = values
+		}
+		query.Set(seedKey, strconv.FormatInt(seed, 10))
+		query.Set(historyKey, format)
+		link := req.URL.Path + "?" + query.Encode()
+		links = append(links, fmt.Sprintf(`<a href="%v">%v</a>`, html.EscapeString(link), strings.ToUpper(format)))
+	}
+	fmt.Fprintf(w, "<p>Hand history: %v</p>", strings.Join(links, ", "))
+	fmt.Fprintln(w)
+}
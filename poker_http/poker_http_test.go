@@ -20,6 +20,7 @@ along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
 package poker_http
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/amdw/gopoker/poker"
@@ -59,26 +60,6 @@ func assertBadRequest(rec *httptest.ResponseRecorder, t *testing.T) {
 	assertStatus(http.StatusBadRequest, "text/plain; charset=utf-8", rec, t)
 }
 
-func TestPlayHoldem(t *testing.T) {
-	rec := httptest.NewRecorder()
-	req, err := http.NewRequest("GET", fmt.Sprintf("%v/holdem/play", baseUrl), nil)
-	if err != nil {
-		t.Fatalf("Could not generate HTTP request: %v", err)
-	}
-	PlayHoldem(rec, req)
-	assertOkHtml(rec, t)
-}
-
-func TestPlayHoldemBadPlayers(t *testing.T) {
-	rec := httptest.NewRecorder()
-	req, err := http.NewRequest("GET", fmt.Sprintf("%v/holdem/play?players=wibble", baseUrl), nil)
-	if err != nil {
-		t.Fatalf("Could not generate HTTP request: %v", err)
-	}
-	PlayHoldem(rec, req)
-	assertBadRequest(rec, t)
-}
-
 func setupSimStaticAssets(t *testing.T) string {
 	dir, err := ioutil.TempDir("", "gopokersimulatorstatic")
 	if err != nil {
@@ -105,6 +86,7 @@ func TestHoldemSim(t *testing.T) {
 	urls := []string{
 		fmt.Sprintf("%v/holdem/simulate?compute=false", baseUrl),
 		fmt.Sprintf("%v/holdem/simulate?compute=true", baseUrl),
+		fmt.Sprintf("%v/holdem/simulate?yours=AS,KS&precision=2", baseUrl),
 	}
 	for _, url := range urls {
 		rec := httptest.NewRecorder()
@@ -128,9 +110,16 @@ func TestHoldemSimInputValidation(t *testing.T) {
 		"yours=" + url.QueryEscape("AS,QZ"):       "Illegally formatted card \"QZ\"",
 		"table=" + url.QueryEscape("2D,3S,QZ,AD"): "Illegally formatted card \"QZ\"",
 		"yours=" + url.QueryEscape("AS,QD,3S"):    "Maximum of 2 player cards allowed, found 3",
+		"yours=" + url.QueryEscape("JK1,AS"):      "Card JK1 is not in a standard pack",
 		tooManyTableCards:                         "Maximum of 5 table cards allowed, found 6",
 		duplicateCard:                             "Found duplicate card QD",
 		"simcount=wibble":                         "Could not parse simcount",
+		"seed=wibble":                             "Could not parse seed",
+		"precision=wibble":                        "Could not parse precision",
+		"precision=-1":                            "Precision must be positive",
+		"players=3&range3=AA":                     "Unexpected range parameter \"range3\" for 3 players",
+		"range1=AKx":                              "Could not parse range for opponent 1",
+		"yours=" + url.QueryEscape("AS,AD") + "&range1=AsAd": "Range for opponent 1 has no combos left",
 	}
 
 	for query, expectedError := range tests {
@@ -148,6 +137,34 @@ func TestHoldemSimInputValidation(t *testing.T) {
 	}
 }
 
+func TestHoldemSimRanges(t *testing.T) {
+	dir := setupSimStaticAssets(t)
+	defer os.RemoveAll(dir)
+
+	tests := map[string][]string{
+		"yours=" + url.QueryEscape("AS,AD") + "&players=3&range2=" + url.QueryEscape("QQ+, AKs") + "&simcount=1000": {
+			"Opponent 2 range: QQ+, AKs (22 combos)", `var initRanges = ["","QQ+, AKs"];`},
+		"players=3&range1=AhKh&range2=AhKh&simcount=1000&compute=true": {"Simulation failed: Could not deal the opponents non-overlapping hands"},
+	}
+	for query, expected := range tests {
+		rec := httptest.NewRecorder()
+		req, err := http.NewRequest("GET", fmt.Sprintf("%v/holdem/simulate?%v", baseUrl, query), nil)
+		if err != nil {
+			t.Fatalf("Could not generate HTTP request: %v", err)
+		}
+		SimulateHoldem(dir)(rec, req)
+		assertOkHtml(rec, t)
+		for _, text := range expected {
+			if !strings.Contains(rec.Body.String(), text) {
+				t.Errorf("Expected to find %q in response to %v: %v", text, query, rec.Body.String())
+			}
+		}
+		if strings.Contains(rec.Body.String(), "Simulation failed") && strings.Contains(rec.Body.String(), `id="potsize"`) {
+			t.Errorf("Expected no results after a failed simulation for %v: %v", query, rec.Body.String())
+		}
+	}
+}
+
 func TestHoldemStartingCardsHome(t *testing.T) {
 	rec := httptest.NewRecorder()
 	req, err := http.NewRequest("GET", fmt.Sprintf("%v/holdem/startingcards", baseUrl), nil)
@@ -191,6 +208,42 @@ func TestHoldemStartingCardsExecute(t *testing.T) {
 	}
 }
 
+func TestHoldemStartingCardsSeed(t *testing.T) {
+	simulate := func() string {
+		rec := httptest.NewRecorder()
+		req, err := http.NewRequest("GET", fmt.Sprintf("%v/holdem/startingcards/sim?rank1=10&rank2=Q&samesuit=true&handstoplay=1000&players=4&seed=4321", baseUrl), nil)
+		if err != nil {
+			t.Fatalf("Could not generate HTTP request: %v", err)
+		}
+		SimulateStartingCards(rec, req)
+		assertOkJson(rec, t)
+		return rec.Body.String()
+	}
+	body := simulate()
+	if body != simulate() {
+		t.Errorf("Expected identical simulations from identical seeds")
+	}
+	result := struct{ Seed int64 }{}
+	json.Unmarshal([]byte(body), &result)
+	if result.Seed != 4321 {
+		t.Errorf("Expected seed 4321 to be echoed, found %v", result.Seed)
+	}
+}
+
+func TestHoldemStartingCardsCancelled(t *testing.T) {
+	rec := httptest.NewRecorder()
+	req, err := http.NewRequest("GET", fmt.Sprintf("%v/holdem/startingcards/sim?rank1=10&rank2=Q&samesuit=false&handstoplay=1000000", baseUrl), nil)
+	if err != nil {
+		t.Fatalf("Could not generate HTTP request: %v", err)
+	}
+	ctx, cancel := context.WithCancel(req.Context())
+	cancel()
+	SimulateStartingCards(rec, req.WithContext(ctx))
+	if rec.Body.Len() != 0 {
+		t.Errorf("Expected no response after client disconnected, found %v", rec.Body.String())
+	}
+}
+
 func TestHoldemBadStartingCards(t *testing.T) {
 	// Can't be both same rank and same suit
 	rec := httptest.NewRecorder()
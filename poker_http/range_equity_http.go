@@ -0,0 +1,160 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package poker_http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/amdw/gopoker/holdem"
+	"github.com/amdw/gopoker/poker"
+	"html"
+	"log"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const formatKey = "format"
+
+// The ranges given by parameters range1, range2 etc., of which there must be at least two.
+// An empty range, or one missing from the sequence, stands for any two cards.
+func getRanges(req *http.Request, tableCards []poker.Card) ([]holdem.Range, error) {
+	var ranges []holdem.Range
+	dead := poker.NewCardSet(tableCards...)
+	for key, values := range req.Form {
+		if !strings.HasPrefix(key, rangeKeyPrefix) {
+			continue
+		}
+		player, err := strconv.Atoi(key[len(rangeKeyPrefix):])
+		if err != nil || player < 1 || player > 10 {
+			return nil, errors.New(fmt.Sprintf("Unexpected range parameter %q", key))
+		}
+		for len(ranges) < player {
+			ranges = append(ranges, nil)
+		}
+		if len(values) == 0 || len(values[0]) == 0 {
+			continue
+		}
+		r, err := holdem.ParseRange(values[0])
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("Could not parse range %v: %v", player, err))
+		}
+		if r.RemoveBlocked(dead).ComboCount() == 0 {
+			return nil, errors.New(fmt.Sprintf("Range %v has no combos left once the table cards are removed", player))
+		}
+		ranges[player-1] = r
+	}
+	if len(ranges) < 2 {
+		return nil, errors.New(fmt.Sprintf("At least two ranges required, found %v", len(ranges)))
+	}
+	return ranges, nil
+}
+
+func rangeName(r holdem.Range) string {
+	if r == nil {
+		return "Any two cards"
+	}
+	return html.EscapeString(r.String())
+}
+
+func printRangeEquity(w http.ResponseWriter, ranges []holdem.Range, result *holdem.RangeEquityResult) {
+	if result.Partial {
+		fmt.Fprintf(w, `<div class="alert alert-warning">The calculation stopped early, after %v deals: these results are partial</div>`, result.Deals)
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintln(w, `<div class="table-responsive"><table class="table table-bordered table-condensed">`)
+	fmt.Fprintf(w, `<tr><th>Player</th><th>Range</th><th>Equity over %v deals</th></tr>`, result.Deals)
+	fmt.Fprintln(w)
+	for i, e := range result.Equity {
+		equity := formatInterval(e.ConfidenceInterval(poker.Z95).Clamped(), result.Exact)
+		if result.Partial {
+			// A partial enumeration has no meaningful error estimate
+			equity = fmt.Sprintf("%.2f%% (partial)", 100.0*e.Mean)
+		}
+		fmt.Fprintf(w, `<tr><td>%v</td><td>%v</td><td class="numcell">%v</td></tr>`, i+1, rangeName(ranges[i]), equity)
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintln(w, "</table></div>")
+
+	fmt.Fprintln(w, "<h2>Player 1 equity by combo</h2>")
+	fmt.Fprintln(w, `<div class="table-responsive"><table class="table table-bordered table-condensed">`)
+	fmt.Fprintln(w, `<tr><th>Combo</th><th>Frequency</th><th>Equity</th></tr>`)
+	for _, ce := range result.ComboEquity {
+		fmt.Fprintf(w, `<tr><td>%v</td><td class="numcell">%.2f%%</td><td class="numcell">%.2f%%</td></tr>`, ce.Combo, 100.0*ce.Frequency, 100.0*ce.Equity)
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintln(w, "</table></div>")
+}
+
+// Work out the equity of each of several ranges against the others. The table cards are given as for the simulator,
+// and simcount gives the maximum number of deals: if every possible deal can be visited within that, the results are exact.
+// With format=json, the results are returned as JSON instead of HTML.
+func RangeEquity(w http.ResponseWriter, req *http.Request) {
+	req.ParseForm()
+
+	params, err := getSimulationParams(req, 2)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Could not get simulation parameters: %v", err), http.StatusBadRequest)
+		return
+	}
+	if len(params.yourCards) > 0 {
+		http.Error(w, "Hole cards should be given as ranges", http.StatusBadRequest)
+		return
+	}
+	ranges, err := getRanges(req, params.tableCards)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Could not get ranges: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	randGen := rand.New(rand.NewSource(params.seed))
+	result, err := holdem.RangeEquity(req.Context(), params.tableCards, ranges, params.handsToPlay, 0, randGen)
+	if err != nil && req.Context().Err() != nil {
+		log.Println("Range equity calculation abandoned:", err)
+		return
+	}
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Could not calculate equity: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	if formatStrs, ok := req.Form[formatKey]; ok && len(formatStrs) > 0 && strings.EqualFold(formatStrs[0], "json") {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(result)
+		return
+	}
+
+	fmt.Fprintln(w, "<!DOCTYPE html>")
+	fmt.Fprintln(w, `<html lang="en"><head><title>Hold'em Range Equity</title>`)
+	fmt.Fprintln(w, `<meta name="viewport" content="width=device-width, initial-scale=1">`)
+	fmt.Fprintln(w, `<link rel="stylesheet" href="https://maxcdn.bootstrapcdn.com/bootstrap/3.3.7/css/bootstrap.min.css">`)
+	fmt.Fprintln(w, `</head><body><div class="container">`)
+	fmt.Fprintln(w, "<h1>Hold'em Range Equity</h1>")
+	if len(params.tableCards) > 0 {
+		fmt.Fprintf(w, "<p>Table cards: %v</p>\n", formatCards(params.tableCards))
+	}
+	if !result.Exact {
+		printSeed(w, req, params.seed)
+	}
+	printRangeEquity(w, ranges, result)
+	fmt.Fprintln(w, "</div></body></html>")
+}
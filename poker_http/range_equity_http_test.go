@@ -0,0 +1,124 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package poker_http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/amdw/gopoker/holdem"
+	"github.com/amdw/gopoker/poker"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// The JSON form of holdem.RangeEquityResult, with combos as strings
+type rangeEquityJson struct {
+	Deals       int
+	Exact       bool
+	Partial     bool
+	Equity      []poker.Estimate
+	ComboEquity []struct {
+		Combo     string
+		Frequency float64
+		Equity    float64
+	}
+}
+
+func rangeEquityRequest(query url.Values, t *testing.T) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	req, err := http.NewRequest("GET", fmt.Sprintf("%v/holdem/rangeequity?%v", baseUrl, query.Encode()), nil)
+	if err != nil {
+		t.Fatalf("Could not generate HTTP request: %v", err)
+	}
+	RangeEquity(rec, req)
+	return rec
+}
+
+func TestRangeEquity(t *testing.T) {
+	query := url.Values{"range1": {"AA,AKs"}, "range2": {"KK,QQ"}, "table": {"2C,7D,9H"}, "simcount": {"200000"}}
+	rec := rangeEquityRequest(query, t)
+	assertOkHtml(rec, t)
+	for _, expected := range []string{"(exact)", "AhAd", "KK"} {
+		if !strings.Contains(rec.Body.String(), expected) {
+			t.Errorf("Expected %q in output: %v", expected, rec.Body.String())
+		}
+	}
+
+	query.Set("format", "json")
+	rec = rangeEquityRequest(query, t)
+	assertOkJson(rec, t)
+	var result rangeEquityJson
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Fatalf("Could not parse JSON: %v", err)
+	}
+	if len(result.Equity) != 2 || !result.Exact || len(result.ComboEquity) != 10 || result.ComboEquity[0].Combo != "AhAd" {
+		t.Errorf("Unexpected results %v", rec.Body.String())
+	}
+
+	// Multiway with a missing range, simulated
+	query = url.Values{"range1": {"AA"}, "range3": {"77"}, "simcount": {"1000"}, "seed": {"1234"}, "format": {"json"}}
+	rec = rangeEquityRequest(query, t)
+	assertOkJson(rec, t)
+	result = rangeEquityJson{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Fatalf("Could not parse JSON: %v", err)
+	}
+	if result.Exact || result.Deals != 1000 || len(result.Equity) != 3 {
+		t.Errorf("Unexpected results %v", rec.Body.String())
+	}
+}
+
+func TestRangeEquityPartial(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	aces, _ := holdem.ParseRange("AA")
+	kings, _ := holdem.ParseRange("KK")
+	ranges := []holdem.Range{aces, kings}
+	result, _ := holdem.RangeEquity(ctx, nil, ranges, 1000000000, 0, nil)
+	rec := httptest.NewRecorder()
+	printRangeEquity(rec, ranges, result)
+	if !strings.Contains(rec.Body.String(), "(partial)") {
+		t.Errorf("Expected cancelled calculation to be marked as partial: %v", rec.Body.String())
+	}
+	for _, unexpected := range []string{"(exact)", "&plusmn;"} {
+		if strings.Contains(rec.Body.String(), unexpected) {
+			t.Errorf("Unexpected %q in partial output: %v", unexpected, rec.Body.String())
+		}
+	}
+}
+
+func TestRangeEquityInputValidation(t *testing.T) {
+	testCases := []url.Values{
+		{"range1": {"AA"}},
+		{"range1": {"AA"}, "range2": {"wibble"}},
+		{"range1": {"AA"}, "range2": {"KK"}, "table": {"KS,KC,KD"}},
+		{"range1": {"AA"}, "range2": {"KK"}, "rangex": {"QQ"}},
+		{"range1": {"AA"}, "range2": {"KK"}, "yours": {"2C,3C"}},
+		{"range1": {"AA"}, "range2": {"KK"}, "table": {"JK1"}},
+	}
+	for _, query := range testCases {
+		rec := rangeEquityRequest(query, t)
+		assertBadRequest(rec, t)
+	}
+}
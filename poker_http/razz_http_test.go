@@ -0,0 +1,79 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package poker_http
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPlayRazz(t *testing.T) {
+	rec := httptest.NewRecorder()
+	req, err := http.NewRequest("GET", fmt.Sprintf("%v/razz/play?seed=1234&players=7", baseUrl), nil)
+	if err != nil {
+		t.Fatalf("Could not generate HTTP request: %v", err)
+	}
+	PlayRazz(rec, req)
+	assertOkHtml(rec, t)
+}
+
+func TestPlayRazzErrorHandling(t *testing.T) {
+	for _, query := range []string{"players=wibble", "players=1", "players=8"} {
+		rec := httptest.NewRecorder()
+		req, err := http.NewRequest("GET", fmt.Sprintf("%v/razz/play?%v", baseUrl, query), nil)
+		if err != nil {
+			t.Fatalf("Could not create HTTP request: %v", err)
+		}
+		PlayRazz(rec, req)
+		assertBadRequest(rec, t)
+	}
+}
+
+func TestRazzSimulation(t *testing.T) {
+	rec := httptest.NewRecorder()
+	req, err := http.NewRequest("GET", fmt.Sprintf("%v/razz/simulate?yours=AS,2C,3D&up1=KH&up3=4C,5C&dead=6D&players=4&simcount=1000&seed=1234", baseUrl), nil)
+	if err != nil {
+		t.Fatalf("Could not generate HTTP request: %v", err)
+	}
+	SimulateRazz(rec, req)
+	assertOkHtml(rec, t)
+	body := rec.Body.String()
+	if !strings.Contains(body, "Based on 1000 hands") {
+		t.Errorf("Expected results of 1000 hands: %v", body)
+	}
+	if !strings.Contains(body, "Opponent 3 up cards") || strings.Contains(body, "Opponent 2 up cards") {
+		t.Errorf("Expected up cards for opponents 1 and 3 only: %v", body)
+	}
+}
+
+func TestRazzSimulationInputValidation(t *testing.T) {
+	for _, query := range []string{"players=8", "table=AS", "yours=AS,2S,3S,4S,5S,6S,7S,8S", "up1=2C,3C,4C,5C,6C", "yours=AS&up1=AS", "players=3&up3=2C", "dead=wibble", "up1=wibble", "yours=JK1", "up1=JK2", "dead=JK1"} {
+		rec := httptest.NewRecorder()
+		req, err := http.NewRequest("GET", fmt.Sprintf("%v/razz/simulate?%v", baseUrl, query), nil)
+		if err != nil {
+			t.Fatalf("Could not generate HTTP request: %v", err)
+		}
+		SimulateRazz(rec, req)
+		assertBadRequest(rec, t)
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package poker_http
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestSimulateShortDeck(t *testing.T) {
+	dir := setupSimStaticAssets(t)
+	defer os.RemoveAll(dir)
+
+	tests := map[string][]string{
+		"game=shortdeck&yours=" + url.QueryEscape("AS,KS") + "&simcount=1000": {
+			"Game: Short-deck Hold'em</p>", `var initGame = "shortdeck";`, "var initTripsBeatStraight = false;"},
+		"game=shortdeck&tripsbeatstraight=true&table=" + url.QueryEscape("6C,7C,8C") + "&precision=2": {
+			"Game: Short-deck Hold'em (trips beat a straight)</p>", "var initTripsBeatStraight = true;"},
+		"game=shortdeck&players=3":                             {`var initGame = "shortdeck";`},
+		"yours=" + url.QueryEscape("2S,2D") + "&simcount=1000": {`var initGame = "holdem";`},
+	}
+	for query, expected := range tests {
+		rec := httptest.NewRecorder()
+		req, err := http.NewRequest("GET", fmt.Sprintf("%v/holdem/simulate?%v", baseUrl, query), nil)
+		if err != nil {
+			t.Fatalf("Could not generate HTTP request: %v", err)
+		}
+		SimulateHoldem(dir)(rec, req)
+		assertOkHtml(rec, t)
+		for _, text := range expected {
+			if !strings.Contains(rec.Body.String(), text) {
+				t.Errorf("Expected to find %q in response to %v: %v", text, query, rec.Body.String())
+			}
+		}
+	}
+}
+
+func TestSimulateShortDeckInputValidation(t *testing.T) {
+	dir := setupSimStaticAssets(t)
+	defer os.RemoveAll(dir)
+
+	tests := map[string]string{
+		"game=wibble": "Unknown game \"wibble\"",
+		"game=shortdeck&yours=" + url.QueryEscape("AS,5D"): "Card 5D is not in a short pack",
+		"game=shortdeck&table=" + url.QueryEscape("2C"):    "Card 2C is not in a short pack",
+		"game=shortdeck&players=16":                        "At most 15 players can be dealt in from a short pack",
+		"game=shortdeck&range1=AA":                         "Opponent ranges are not supported for short-deck Hold'em",
+	}
+	for query, expectedError := range tests {
+		rec := httptest.NewRecorder()
+		url := fmt.Sprintf("%v/holdem/simulate?%v", baseUrl, query)
+		req, err := http.NewRequest("GET", url, nil)
+		if err != nil {
+			t.Fatalf("Could not generate HTTP request: %v", err)
+		}
+		SimulateHoldem(dir)(rec, req)
+		assertBadRequest(rec, t)
+		if !strings.Contains(rec.Body.String(), expectedError) {
+			t.Errorf("Could not find expected error '%v' in response for %v: %v", expectedError, url, rec.Body.String())
+		}
+	}
+}
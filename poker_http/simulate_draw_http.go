@@ -0,0 +1,83 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package poker_http
+
+import (
+	"fmt"
+	"github.com/amdw/gopoker/draw"
+	"github.com/amdw/gopoker/poker"
+	"log"
+	"math/rand"
+	"net/http"
+)
+
+func SimulateDraw(w http.ResponseWriter, req *http.Request) {
+	req.ParseForm()
+
+	params, err := getSimulationParams(req, draw.HandSize)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Could not get simulation parameters: %v", err), http.StatusBadRequest)
+		return
+	}
+	if len(params.tableCards) > 0 {
+		http.Error(w, "No table cards are dealt in draw games", http.StatusBadRequest)
+		return
+	}
+	if err = draw.ValidateDiscards(params.yourCards, nil, params.players); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	randGen := rand.New(rand.NewSource(params.seed))
+	outcomes, err := draw.AnalyseDiscards(req.Context(), params.yourCards, params.players, params.handsToPlay, 0, randGen)
+	if err != nil {
+		log.Println("Draw simulation abandoned:", err)
+		return
+	}
+
+	fmt.Fprintln(w, "<!DOCTYPE html>")
+	fmt.Fprintln(w, `<html lang="en"><head><title>Five-card Draw Simulator</title>`)
+	fmt.Fprintln(w, `<meta name="viewport" content="width=device-width, initial-scale=1">`)
+	fmt.Fprintln(w, `<link rel="stylesheet" href="https://maxcdn.bootstrapcdn.com/bootstrap/3.3.7/css/bootstrap.min.css" integrity="sha384-BVYiiSIFeK1dGmJRAkycuHAHRg32OmUcww7on3RYdg4Va+PmSTsz/K68vbdEjh4u" crossorigin="anonymous">`)
+	fmt.Fprintln(w, "<style>td.numcell { text-align: right }</style>")
+	fmt.Fprintln(w, `</head><body><div class="container-fluid">`)
+	fmt.Fprintln(w, "<h1>Five-card Draw Simulator</h1>")
+	fmt.Fprintf(w, "<p>Your cards: %v</p>\n", formatCards(params.yourCards))
+	fmt.Fprintf(w, "<p>Each discard choice is played out over the same %v deals against %v opponents, who keep any pairs or better and otherwise draw four to their highest card.</p>\n", params.handsToPlay, params.players-1)
+
+	fmt.Fprintln(w, "<h2>Results</h2>")
+	printSeed(w, req, params.seed)
+
+	fmt.Fprintln(w, `<div class="table-responsive"><table class="table table-bordered table-condensed">`)
+	fmt.Fprintln(w, `<tr><th>Rank</th><th>Discard</th><th>Win (sole or joint)</th><th>Equity</th></tr>`)
+	for i, outcome := range outcomes {
+		discards := "Stand pat"
+		if len(outcome.Discards) > 0 {
+			discards = formatCards(outcome.Discards)
+		}
+		winRate := outcome.Simulator.WinRate().ConfidenceInterval(poker.Z95).Clamped()
+		equity := outcome.Simulator.Equity().ConfidenceInterval(poker.Z95).Clamped()
+		fmt.Fprintf(w, `<tr><td>%v</td><td>%v</td><td class="numcell">%v</td><td class="numcell">%v</td></tr>`, i+1, discards, formatInterval(winRate, false), formatInterval(equity, false))
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintln(w, "</table></div>")
+
+	fmt.Fprintln(w, "</div></body></html>")
+}
@@ -25,11 +25,15 @@ import (
 	"fmt"
 	"github.com/amdw/gopoker/holdem"
 	"github.com/amdw/gopoker/poker"
+	"html"
 	"io"
+	"log"
 	"math"
+	"math/rand"
 	"net/http"
 	"os"
 	"path"
+	"strconv"
 	"strings"
 )
 
@@ -37,6 +41,65 @@ const yourCardsKey = "yours"
 const tableCardsKey = "table"
 const simCountKey = "simcount"
 const forceComputeKey = "compute"
+const precisionKey = "precision"
+const rangeKeyPrefix = "range"
+
+// The range for each opponent, given by parameters range1, range2 etc., with nil for opponents without one
+func getOpponentRanges(req *http.Request, params simulationParams) ([]holdem.Range, error) {
+	ranges := make([]holdem.Range, params.players-1)
+	dead := poker.NewCardSet(params.tableCards...).Union(poker.NewCardSet(params.yourCards...))
+	for key, values := range req.Form {
+		if !strings.HasPrefix(key, rangeKeyPrefix) || len(values) == 0 || len(values[0]) == 0 {
+			continue
+		}
+		opponent, err := strconv.Atoi(key[len(rangeKeyPrefix):])
+		if err != nil || opponent < 1 || opponent >= params.players {
+			return nil, errors.New(fmt.Sprintf("Unexpected range parameter %q for %v players", key, params.players))
+		}
+		r, err := holdem.ParseRange(values[0])
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("Could not parse range for opponent %v: %v", opponent, err))
+		}
+		if r.RemoveBlocked(dead).ComboCount() == 0 {
+			return nil, errors.New(fmt.Sprintf("Range for opponent %v has no combos left once the known cards are removed", opponent))
+		}
+		ranges[opponent-1] = r
+	}
+	return ranges, nil
+}
+
+// Check that the simulation parameters make sense for short-deck Hold'em
+func validateShortDeckParams(params simulationParams, ranges []holdem.Range) error {
+	for _, r := range ranges {
+		if r != nil {
+			return errors.New("Opponent ranges are not supported for short-deck Hold'em")
+		}
+	}
+	return holdem.ValidateShortDeck(params.tableCards, params.yourCards, params.players)
+}
+
+// The ranges as strings for the page's script, with empty strings for opponents without one
+func rangesJson(ranges []holdem.Range) string {
+	rangeStrings := make([]string, len(ranges))
+	for i, r := range ranges {
+		if r != nil {
+			rangeStrings[i] = r.String()
+		}
+	}
+	jsonBytes, err := json.Marshal(rangeStrings)
+	if err != nil {
+		panic(fmt.Sprintf("Unable to marshal ranges %v: %v", rangeStrings, err))
+	}
+	return string(jsonBytes)
+}
+
+func printRanges(w http.ResponseWriter, ranges []holdem.Range) {
+	for i, r := range ranges {
+		if r != nil {
+			fmt.Fprintf(w, "<p>Opponent %v range: %v (%v combos)</p>\n", i+1, html.EscapeString(r.String()), r.ComboCount())
+		}
+	}
+}
 
 func printResultGraph(w http.ResponseWriter, title string, handNames []string, series []map[string]interface{}, id string) {
 	graphDef := map[string]interface{}{
@@ -218,6 +281,24 @@ func printResultTable(w http.ResponseWriter, simulator *poker.Simulator) {
 	fmt.Fprintf(w, "</table></div>")
 }
 
+func printStatsTable(w http.ResponseWriter, simulator *poker.Simulator) {
+	stats := simulator.Stats()
+	printRow := func(name string, ci poker.ConfidenceInterval) {
+		fmt.Fprintf(w, `<tr><td>%v</td><td class="numcell">%v</td></tr>`, name, formatInterval(ci, simulator.Exhaustive))
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintln(w, `<div class="table-responsive"><table class="table table-bordered table-condensed">`)
+	fmt.Fprintf(w, `<tr><th>Based on %v hands</th><th>Estimate</th></tr>`, simulator.HandCount)
+	fmt.Fprintln(w)
+	printRow("Win (sole or joint)", stats.WinRate)
+	printRow("Joint win", stats.TieRate)
+	printRow("Equity", stats.Equity)
+	for class, ci := range stats.ClassFrequencies {
+		printRow(poker.HandClass(class).String(), ci)
+	}
+	fmt.Fprintln(w, "</table></div>")
+}
+
 func loadStaticFiles(staticBaseDir string) (*os.File, *os.File, *os.File, error) {
 	filenames := []string{"simulation_head.html", "simulation_foot.html", "simulation.js"}
 	files := make([]*os.File, len(filenames))
@@ -266,7 +347,20 @@ func SimulateHoldem(staticBaseDir string) func(http.ResponseWriter, *http.Reques
 		defer footFile.Close()
 		defer jsFile.Close()
 
-		params, err := getSimulationParams(req)
+		params, err := getSimulationParams(req, 2)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Could not get simulation parameters: %v", err), http.StatusBadRequest)
+			return
+		}
+		ranges, err := getOpponentRanges(req, params)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Could not get simulation parameters: %v", err), http.StatusBadRequest)
+			return
+		}
+		rules, err := getShortDeckRules(req)
+		if err == nil && rules != nil {
+			err = validateShortDeckParams(params, ranges)
+		}
 		if err != nil {
 			http.Error(w, fmt.Sprintf("Could not get simulation parameters: %v", err), http.StatusBadRequest)
 			return
@@ -281,27 +375,56 @@ func SimulateHoldem(staticBaseDir string) func(http.ResponseWriter, *http.Reques
 		breakEvenStr := "undefined"
 
 		if len(params.tableCards) > 0 || len(params.yourCards) > 0 || params.forceComputation {
-			simulator := holdem.SimulateHoldem(params.tableCards, params.yourCards, params.players, params.handsToPlay)
-
-			fmt.Fprintf(w, "<h2>Results</h2>")
-
-			breakEven := simulator.PotOddsBreakEven()
-			if math.IsInf(breakEven, 1) {
-				breakEvenStr = "Infinity"
+			randGen := rand.New(rand.NewSource(params.seed))
+			var simulator *poker.Simulator
+			if rules != nil && params.targetHalfWidth > 0 {
+				simulator, err = holdem.SimulateShortDeckAdaptive(req.Context(), *rules, params.tableCards, params.yourCards, params.players, params.targetHalfWidth, params.handsToPlay, 0, randGen, nil)
+			} else if rules != nil {
+				simulator, err = holdem.SimulateShortDeckParallelContext(req.Context(), *rules, params.tableCards, params.yourCards, params.players, params.handsToPlay, 0, randGen, nil)
+			} else if params.targetHalfWidth > 0 {
+				simulator, err = holdem.SimulateHoldemRangesAdaptive(req.Context(), params.tableCards, params.yourCards, ranges, params.targetHalfWidth, params.handsToPlay, 0, randGen, nil)
 			} else {
-				breakEvenStr = fmt.Sprintf("%v", breakEven)
+				simulator, err = holdem.SimulateHoldemRanges(req.Context(), params.tableCards, params.yourCards, ranges, params.handsToPlay, 0, randGen, nil)
+			}
+			if err != nil && req.Context().Err() != nil {
+				log.Println("Hold'em simulation abandoned:", err)
+				return
 			}
-			fmt.Fprintln(w, `<div class="row"><div class="col-xs-12"><div class="form-group"><form>`)
-			fmt.Fprintln(w, `<label for="potsize">Pot size</label>`)
-			fmt.Fprintln(w, `<input id="potsize" type="text" name="potsize" ng-model="potSize" class="form-control"/>`)
-			fmt.Fprintln(w, `<span ng-bind-html="potOddsMessage()"></span>`)
-			fmt.Fprintln(w, `</form></div></div></div>`)
-
-			printResultGraphs(w, simulator)
 
-			fmt.Fprintln(w, `<div class="row"><div class="col-xs-12">`)
-			printResultTable(w, simulator)
-			fmt.Fprintln(w, `</div></div>`)
+			fmt.Fprintf(w, "<h2>Results</h2>")
+			if err != nil {
+				fmt.Fprintf(w, `<div class="alert alert-danger">Simulation failed: %v</div>`, html.EscapeString(err.Error()))
+				fmt.Fprintln(w)
+			}
+			if rules != nil {
+				fmt.Fprintf(w, "<p>Game: %v</p>\n", holdemVariantName(rules))
+			}
+			printRanges(w, ranges)
+			printSeed(w, req, params.seed)
+
+			if err == nil {
+				breakEven := simulator.PotOddsBreakEven()
+				if math.IsInf(breakEven, 1) {
+					breakEvenStr = "Infinity"
+				} else {
+					breakEvenStr = fmt.Sprintf("%v", breakEven)
+				}
+				fmt.Fprintln(w, `<div class="row"><div class="col-xs-12"><div class="form-group"><form>`)
+				fmt.Fprintln(w, `<label for="potsize">Pot size</label>`)
+				fmt.Fprintln(w, `<input id="potsize" type="text" name="potsize" ng-model="potSize" class="form-control"/>`)
+				fmt.Fprintln(w, `<span ng-bind-html="potOddsMessage()"></span>`)
+				fmt.Fprintln(w, `</form></div></div></div>`)
+
+				printResultGraphs(w, simulator)
+
+				fmt.Fprintln(w, `<div class="row"><div class="col-md-6">`)
+				printStatsTable(w, simulator)
+				fmt.Fprintln(w, `</div></div>`)
+
+				fmt.Fprintln(w, `<div class="row"><div class="col-xs-12">`)
+				printResultTable(w, simulator)
+				fmt.Fprintln(w, `</div></div>`)
+			}
 		}
 
 		fmt.Fprintln(w, "<script>")
@@ -309,6 +432,15 @@ func SimulateHoldem(staticBaseDir string) func(http.ResponseWriter, *http.Reques
 		fmt.Fprintf(w, "var initYourCards = %v;\n", cardsJson(params.yourCards))
 		fmt.Fprintf(w, "var initTableCards = %v;\n", cardsJson(params.tableCards))
 		fmt.Fprintf(w, "var initSimCount = %v;\n", params.handsToPlay)
+		fmt.Fprintf(w, "var initPrecision = %q;\n", params.precisionString())
+		fmt.Fprintf(w, "var initRanges = %v;\n", rangesJson(ranges))
+		if rules != nil {
+			fmt.Fprintf(w, "var initGame = %q;\n", shortDeckGame)
+			fmt.Fprintf(w, "var initTripsBeatStraight = %v;\n", rules.TripsBeatStraight)
+		} else {
+			fmt.Fprintln(w, `var initGame = "holdem";`)
+			fmt.Fprintln(w, "var initTripsBeatStraight = false;")
+		}
 		fmt.Fprintf(w, "var potOddsBreakEven = %v;\n", breakEvenStr)
 
 		if !writeStaticFile(jsFile, w) {
@@ -23,20 +23,20 @@ import (
 	"errors"
 	"fmt"
 	"github.com/amdw/gopoker/poker"
+	"github.com/amdw/gopoker/stud"
 	"net/http"
 	"strconv"
 	"strings"
 )
 
 func duplicateCheck(tableCards, yourCards []poker.Card) (ok bool, dupeCard poker.Card) {
-	allCards := make([]poker.Card, len(yourCards)+len(tableCards))
-	copy(allCards, yourCards)
-	copy(allCards[len(yourCards):], tableCards)
-	cardDupeCheck := make(map[string]int)
-	for _, c := range allCards {
-		cardDupeCheck[c.String()]++
-		if cardDupeCheck[c.String()] > 1 {
-			return false, c
+	seen := poker.CardSet(0)
+	for _, cards := range [][]poker.Card{yourCards, tableCards} {
+		for _, c := range cards {
+			if seen.Contains(c) {
+				return false, c
+			}
+			seen = seen.Add(c)
 		}
 	}
 	return true, poker.Card{}
@@ -47,43 +47,79 @@ type simulationParams struct {
 	tableCards, yourCards []poker.Card
 	handsToPlay           int
 	forceComputation      bool
+	seed                  int64
+	targetHalfWidth       float64 // If positive, simulate until equity is known to within this, playing at most handsToPlay hands
+}
+
+// The precision as a percentage, for display, or the empty string if none was requested
+func (params simulationParams) precisionString() string {
+	if params.targetHalfWidth <= 0 {
+		return ""
+	}
+	return strconv.FormatFloat(100.0*params.targetHalfWidth, 'g', -1, 64)
 }
 
-func getSimulationParams(req *http.Request) (params simulationParams, err error) {
+// Format a confidence interval for a probability as percentages
+func formatInterval(ci poker.ConfidenceInterval, exact bool) string {
+	if exact {
+		return fmt.Sprintf("%.2f%% (exact)", 100.0*ci.Mean)
+	}
+	return fmt.Sprintf("%.2f%% &plusmn; %.2f%% (95%% CI %.2f%%&ndash;%.2f%%)", 100.0*ci.Mean, 100.0*(ci.High-ci.Low)/2, 100.0*ci.Low, 100.0*ci.High)
+}
+
+// Get a comma-separated list of cards from the request, or an empty list if there is none
+func getCards(req *http.Request, key string) ([]poker.Card, error) {
+	cards := []poker.Card{}
+	if cardsStrs, ok := req.Form[key]; ok && len(cardsStrs) > 0 && len(cardsStrs[0]) > 0 {
+		cardsSplit := strings.Split(strings.Replace(cardsStrs[0], " ", "", -1), ",")
+		cards = make([]poker.Card, len(cardsSplit))
+		for i, cstr := range cardsSplit {
+			card, err := poker.MakeCard(cstr)
+			if err != nil {
+				return cards, errors.New(fmt.Sprintf("Illegally formatted card %q", cstr))
+			}
+			cards[i] = card
+		}
+	}
+	return cards, nil
+}
+
+// Get the simulation parameters from the request for a game played with a standard pack, allowing up to maxHoleCards
+// of your own cards
+func getSimulationParams(req *http.Request, maxHoleCards int) (params simulationParams, err error) {
+	params, err = parseSimulationParams(req, maxHoleCards)
+	if err != nil {
+		return params, err
+	}
+	return params, poker.ValidateStandardCards(params.tableCards, params.yourCards)
+}
+
+// As getSimulationParams, but allowing cards from outside a standard pack, such as jokers, for the caller to check
+func parseSimulationParams(req *http.Request, maxHoleCards int) (params simulationParams, err error) {
 	players, err := getPlayers(req)
 	if err != nil {
 		return simulationParams{}, errors.New(fmt.Sprintf("Could not get player count: %v", err))
 	}
 
-	params = simulationParams{players, []poker.Card{}, []poker.Card{}, 10000, false}
+	seed, err := getSeed(req)
+	if err != nil {
+		return simulationParams{}, err
+	}
+
+	params = simulationParams{players, []poker.Card{}, []poker.Card{}, 10000, false, seed, 0}
 
 	if forceStrs, ok := req.Form[forceComputeKey]; ok && len(forceStrs) == 1 && strings.EqualFold(forceStrs[0], "true") {
 		params.forceComputation = true
 	}
 
-	extractCards := func(key string) ([]poker.Card, error) {
-		cards := []poker.Card{}
-		if cardsStrs, ok := req.Form[key]; ok && len(cardsStrs) > 0 && len(cardsStrs[0]) > 0 {
-			cardsSplit := strings.Split(strings.Replace(cardsStrs[0], " ", "", -1), ",")
-			cards = make([]poker.Card, len(cardsSplit))
-			for i, cstr := range cardsSplit {
-				card, err := poker.MakeCard(cstr)
-				if err != nil {
-					return cards, errors.New(fmt.Sprintf("Illegally formatted card %q", cstr))
-				}
-				cards[i] = card
-			}
-		}
-		return cards, nil
-	}
-	params.yourCards, err = extractCards(yourCardsKey)
+	params.yourCards, err = getCards(req, yourCardsKey)
 	if err != nil {
 		return params, err
 	}
-	if len(params.yourCards) > 2 {
-		return params, errors.New(fmt.Sprintf("Maximum of 2 player cards allowed, found %v", len(params.yourCards)))
+	if len(params.yourCards) > maxHoleCards {
+		return params, errors.New(fmt.Sprintf("Maximum of %v player cards allowed, found %v", maxHoleCards, len(params.yourCards)))
 	}
-	params.tableCards, err = extractCards(tableCardsKey)
+	params.tableCards, err = getCards(req, tableCardsKey)
 	if err != nil {
 		return params, err
 	}
@@ -103,5 +139,51 @@ func getSimulationParams(req *http.Request) (params simulationParams, err error)
 		params.handsToPlay = int(handsToPlayParsed)
 	}
 
+	if precisionStrs, ok := req.Form[precisionKey]; ok && len(precisionStrs) > 0 && len(precisionStrs[0]) > 0 {
+		precision, err := strconv.ParseFloat(precisionStrs[0], 64)
+		if err != nil {
+			return params, errors.New(fmt.Sprintf("Could not parse precision: %v", err.Error()))
+		}
+		if precision <= 0 {
+			return params, errors.New(fmt.Sprintf("Precision must be positive, found %v", precision))
+		}
+		params.targetHalfWidth = precision / 100.0
+	}
+
 	return params, nil
 }
+
+const upCardsKeyPrefix = "up"
+const deadCardsKey = "dead"
+
+// Get the simulation parameters for a stud variant, along with the up cards of each opponent (given as up1, up2 etc.)
+// and any dead cards. Your cards are given in the order they were dealt, and there are no table cards.
+func getStudSimulationParams(req *http.Request) (params simulationParams, opponentUpCards [][]poker.Card, deadCards []poker.Card, err error) {
+	params, err = getSimulationParams(req, stud.CardsPerPlayer)
+	if err != nil {
+		return params, nil, nil, err
+	}
+	if len(params.tableCards) > 0 {
+		return params, nil, nil, errors.New("No table cards are dealt in stud games")
+	}
+	for i := 1; i < stud.MaxPlayers; i++ {
+		cards, err := getCards(req, fmt.Sprintf("%v%v", upCardsKeyPrefix, i))
+		if err != nil {
+			return params, nil, nil, err
+		}
+		if len(cards) > 0 {
+			for len(opponentUpCards) < i-1 {
+				opponentUpCards = append(opponentUpCards, nil)
+			}
+			opponentUpCards = append(opponentUpCards, cards)
+		}
+	}
+	deadCards, err = getCards(req, deadCardsKey)
+	if err != nil {
+		return params, nil, nil, err
+	}
+	if err = stud.ValidateKnownCards(params.yourCards, opponentUpCards, deadCards, params.players); err != nil {
+		return params, nil, nil, err
+	}
+	return params, opponentUpCards, deadCards, nil
+}
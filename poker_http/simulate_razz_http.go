@@ -0,0 +1,89 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package poker_http
+
+import (
+	"fmt"
+	"github.com/amdw/gopoker/poker"
+	"github.com/amdw/gopoker/razz"
+	"log"
+	"math"
+	"math/rand"
+	"net/http"
+)
+
+func SimulateRazz(w http.ResponseWriter, req *http.Request) {
+	req.ParseForm()
+
+	params, opponentUpCards, deadCards, err := getStudSimulationParams(req)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Could not get simulation parameters: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	randGen := rand.New(rand.NewSource(params.seed))
+	var simulator *poker.Simulator
+	if params.targetHalfWidth > 0 {
+		simulator, err = razz.SimulateRazzAdaptive(req.Context(), params.yourCards, opponentUpCards, deadCards, params.players, params.targetHalfWidth, params.handsToPlay, 0, randGen, nil)
+	} else {
+		simulator, err = razz.SimulateRazzParallelContext(req.Context(), params.yourCards, opponentUpCards, deadCards, params.players, params.handsToPlay, 0, randGen, nil)
+	}
+	if err != nil {
+		log.Println("Razz simulation abandoned:", err)
+		return
+	}
+
+	fmt.Fprintln(w, "<!DOCTYPE html>")
+	fmt.Fprintln(w, `<html lang="en"><head><title>Razz Simulator</title>`)
+	fmt.Fprintln(w, `<meta name="viewport" content="width=device-width, initial-scale=1">`)
+	fmt.Fprintln(w, `<link rel="stylesheet" href="https://maxcdn.bootstrapcdn.com/bootstrap/3.3.7/css/bootstrap.min.css" integrity="sha384-BVYiiSIFeK1dGmJRAkycuHAHRg32OmUcww7on3RYdg4Va+PmSTsz/K68vbdEjh4u" crossorigin="anonymous">`)
+	fmt.Fprintln(w, `</head><body><div class="container-fluid">`)
+	fmt.Fprintln(w, "<h1>Razz Simulator</h1>")
+	if len(params.yourCards) > 0 {
+		fmt.Fprintf(w, "<p>Your cards: %v</p>\n", formatCards(params.yourCards))
+	}
+	for i, cards := range opponentUpCards {
+		if len(cards) > 0 {
+			fmt.Fprintf(w, "<p>Opponent %v up cards: %v</p>\n", i+1, formatCards(cards))
+		}
+	}
+	if len(deadCards) > 0 {
+		fmt.Fprintf(w, "<p>Dead cards: %v</p>\n", formatCards(deadCards))
+	}
+
+	fmt.Fprintln(w, "<h2>Results</h2>")
+	printSeed(w, req, params.seed)
+
+	breakEven := simulator.PotOddsBreakEven()
+	if math.IsInf(breakEven, 1) {
+		fmt.Fprintln(w, "<p><b>Any</b> bet has positive expected value! :)</p>")
+	} else {
+		fmt.Fprintf(w, "<p>A bet up to %.1f%% of the pot has positive expected value.</p>", 100.0*breakEven)
+	}
+
+	fmt.Fprintln(w, `<div class="row"><div class="col-md-6">`)
+	printStatsTable(w, simulator)
+	fmt.Fprintln(w, `</div></div>`)
+	fmt.Fprintln(w, `<div class="row"><div class="col-xs-12">`)
+	printResultTable(w, simulator)
+	fmt.Fprintln(w, `</div></div>`)
+
+	fmt.Fprintln(w, "</div></body></html>")
+}
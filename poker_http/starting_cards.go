@@ -20,12 +20,14 @@ along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
 package poker_http
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/amdw/gopoker/holdem"
 	"github.com/amdw/gopoker/poker"
 	"io"
 	"log"
+	"math/rand"
 	"net/http"
 	"os"
 	"path"
@@ -77,10 +79,21 @@ type SimParams struct {
 	StartingPair holdem.StartingPair
 	Players      int
 	HandsToPlay  int
+	Seed         int64
 }
 
-func (params SimParams) RunSimulation() *poker.Simulator {
-	return params.StartingPair.RunSimulation(params.Players, params.HandsToPlay)
+// Run the simulation, stopping early if ctx is cancelled
+func (params SimParams) RunSimulation(ctx context.Context) (*poker.Simulator, error) {
+	randGen := rand.New(rand.NewSource(params.Seed))
+	card1, card2 := params.StartingPair.SampleCards()
+	return holdem.SimulateHoldemContext(ctx, []poker.Card{}, []poker.Card{card1, card2}, params.Players, params.HandsToPlay, randGen, nil)
+}
+
+// The simulation results, together with the seed needed to reproduce them and confidence intervals
+type startingCardsResult struct {
+	*poker.Simulator
+	Seed  int64
+	Stats poker.SimulatorStats
 }
 
 func getStartingPair(req *http.Request, w http.ResponseWriter) (SimParams, bool) {
@@ -122,13 +135,18 @@ func getStartingPair(req *http.Request, w http.ResponseWriter) (SimParams, bool)
 		}
 		handsToPlay = int(handsToPlay64)
 	}
-	startingPair := holdem.StartingPair{rank1, rank2, sameSuit}
+	startingPair := holdem.StartingPair{Rank1: rank1, Rank2: rank2, SameSuit: sameSuit}
 	err = startingPair.Validate()
 	if err != nil {
 		http.Error(w, fmt.Sprintf("Bad pair: %v", err), http.StatusBadRequest)
 		return SimParams{}, false
 	}
-	return SimParams{startingPair, players, handsToPlay}, true
+	seed, err := getSeed(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return SimParams{}, false
+	}
+	return SimParams{startingPair, players, handsToPlay, seed}, true
 }
 
 func SimulateStartingCards(w http.ResponseWriter, req *http.Request) {
@@ -137,8 +155,12 @@ func SimulateStartingCards(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 	log.Println("Simulating", simParams)
-	simulator := simParams.RunSimulation()
+	simulator, err := simParams.RunSimulation(req.Context())
+	if err != nil {
+		log.Println("Simulation", simParams, "abandoned:", err)
+		return
+	}
 	log.Println("Simulation", simParams, "complete")
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(simulator)
+	json.NewEncoder(w).Encode(startingCardsResult{simulator, simParams.Seed, simulator.Stats()})
 }
@@ -0,0 +1,67 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package razz
+
+import (
+	"github.com/amdw/gopoker/poker"
+	"github.com/amdw/gopoker/stud"
+)
+
+// Razz is dealt exactly as seven-card stud: two down, four up and one down for each player
+func Deal(pack *poker.Pack, players int) [][]poker.Card {
+	return stud.Deal(pack, players)
+}
+
+type PlayerOutcome struct {
+	Player         int
+	Level          poker.HandLevel
+	Cards          []poker.Card
+	Won            bool
+	PotFractionWon float64
+}
+
+// Find the best ace-to-five low each player can make from their seven cards, and work out how the pot is divided.
+// There is no qualifier: the lowest hand always wins. Outcomes are in the same order as playerCards, with player
+// numbers starting from 1.
+func PlayerOutcomes(playerCards [][]poker.Card) []PlayerOutcome {
+	result := make([]PlayerOutcome, len(playerCards))
+	bestLevel := poker.AceToFiveLowRanking.MinLevel()
+	for i, hand := range playerCards {
+		level, cards := poker.BestAceToFiveLow(hand)
+		result[i] = PlayerOutcome{i + 1, level, cards, false, 0}
+		if poker.BeatsAceToFiveLow(level, bestLevel) {
+			bestLevel = level
+		}
+	}
+
+	winners := 0
+	for _, outcome := range result {
+		if !poker.BeatsAceToFiveLow(bestLevel, outcome.Level) {
+			winners++
+		}
+	}
+	for i, outcome := range result {
+		if !poker.BeatsAceToFiveLow(bestLevel, outcome.Level) {
+			result[i].Won = true
+			result[i].PotFractionWon = 1.0 / float64(winners)
+		}
+	}
+	return result
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package razz
+
+import (
+	"github.com/amdw/gopoker/poker"
+	"reflect"
+	"testing"
+)
+
+var h = poker.TestMakeHand
+var hl = poker.TestMakeHandLevel
+
+func TestPlayerOutcomes(t *testing.T) {
+	playerCards := [][]poker.Card{
+		h("6C", "KS", "2C", "3C", "4C", "5C", "QS"),  // A straight flush at high, but a six-low here
+		h("AH", "AD", "2C", "2H", "KD", "KH", "QD"),  // Forced to play a pair
+		h("7H", "8H", "9H", "10D", "JD", "2D", "3S"), // Nine-low
+	}
+	outcomes := PlayerOutcomes(playerCards)
+	expected := []poker.HandLevel{hl("HighCard", "6", "5", "4", "3", "2"), hl("OnePair", "A", "K", "Q", "2"), hl("HighCard", "9", "8", "7", "3", "2")}
+	for i, outcome := range outcomes {
+		if outcome.Player != i+1 || !reflect.DeepEqual(expected[i], outcome.Level) || outcome.Won != (i == 0) {
+			t.Errorf("Unexpected outcome for player %v: %v", i+1, outcome)
+		}
+		if len(outcome.Cards) != 5 || !poker.NewCardSet(playerCards[i]...).ContainsAll(poker.NewCardSet(outcome.Cards...)) {
+			t.Errorf("Expected five of %v for player %v, found %v", playerCards[i], i+1, outcome.Cards)
+		}
+	}
+
+	// Split pot: suits and the unused cards don't matter
+	outcomes = PlayerOutcomes([][]poker.Card{
+		h("AS", "2S", "3S", "4S", "6S", "KS", "KC"),
+		h("AH", "2H", "3H", "4H", "6D", "QD", "QC"),
+	})
+	for _, outcome := range outcomes {
+		if !outcome.Won || outcome.PotFractionWon != 0.5 {
+			t.Errorf("Expected split pot, found %v", outcome)
+		}
+	}
+
+	// There is no qualifier, however bad the best hand is
+	outcomes = PlayerOutcomes([][]poker.Card{
+		h("KS", "KC", "KD", "QS", "QC", "QD", "JC"),
+		h("KH", "QH", "JH", "JS", "JD", "10C", "10D"),
+	})
+	if !outcomes[1].Won || outcomes[1].PotFractionWon != 1 || outcomes[0].Won {
+		t.Errorf("Expected player 2 to win with %v, found %v", outcomes[1].Level, outcomes)
+	}
+}
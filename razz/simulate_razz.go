@@ -0,0 +1,71 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package razz
+
+import (
+	"context"
+	"github.com/amdw/gopoker/poker"
+	"github.com/amdw/gopoker/stud"
+	"math/rand"
+)
+
+// The outcomes of a hand of Razz, whose levels are ace-to-five lows
+func gameOutcomes(playerCards [][]poker.Card) []poker.GameOutcome {
+	outcomes := PlayerOutcomes(playerCards)
+	result := make([]poker.GameOutcome, len(outcomes))
+	for i, o := range outcomes {
+		result[i] = poker.GameOutcome{Player: o.Player, Level: o.Level, Cards: o.Cards, PotFractionWon: o.PotFractionWon}
+	}
+	return result
+}
+
+// Simulate hands of Razz, given the cards known so far (see stud.ValidateKnownCards, which they must pass).
+// The best hands recorded by the simulator are the lowest ones.
+func SimulateRazz(yourCards []poker.Card, opponentUpCards [][]poker.Card, deadCards []poker.Card, players, handsToPlay int, randGen *rand.Rand) *poker.Simulator {
+	sim, _ := SimulateRazzContext(context.Background(), yourCards, opponentUpCards, deadCards, players, handsToPlay, randGen, nil)
+	return sim
+}
+
+// Like SimulateRazz, but abandoned with ctx.Err() once ctx is cancelled, keeping the hands dealt by then, and
+// reporting progress along the way if progress is non-nil
+func SimulateRazzContext(ctx context.Context, yourCards []poker.Card, opponentUpCards [][]poker.Card, deadCards []poker.Card, players, handsToPlay int, randGen *rand.Rand, progress poker.ProgressFunc) (*poker.Simulator, error) {
+	deal := stud.NewDealer(yourCards, opponentUpCards, deadCards, players, gameOutcomes)()
+	return poker.Simulate(ctx, poker.AceToFiveLowRanking, players, handsToPlay, deal, randGen, progress)
+}
+
+// Like SimulateRazz, but with the hands dealt by several goroutines (one per CPU unless workers is positive).
+// However many there are, the same seed gives the same results.
+func SimulateRazzParallel(yourCards []poker.Card, opponentUpCards [][]poker.Card, deadCards []poker.Card, players, handsToPlay, workers int, randGen *rand.Rand) *poker.Simulator {
+	sim, _ := SimulateRazzParallelContext(context.Background(), yourCards, opponentUpCards, deadCards, players, handsToPlay, workers, randGen, nil)
+	return sim
+}
+
+// Like SimulateRazzParallel, but with cancellation and progress as for SimulateRazzContext
+func SimulateRazzParallelContext(ctx context.Context, yourCards []poker.Card, opponentUpCards [][]poker.Card, deadCards []poker.Card, players, handsToPlay, workers int, randGen *rand.Rand, progress poker.ProgressFunc) (*poker.Simulator, error) {
+	newDealer := stud.NewDealer(yourCards, opponentUpCards, deadCards, players, gameOutcomes)
+	return poker.SimulateParallel(ctx, poker.AceToFiveLowRanking, players, handsToPlay, workers, newDealer, randGen, progress)
+}
+
+// Keep dealing Razz hands in batches until our equity is known to within targetHalfWidth either way (at 95%
+// confidence) or maxHands have been dealt
+func SimulateRazzAdaptive(ctx context.Context, yourCards []poker.Card, opponentUpCards [][]poker.Card, deadCards []poker.Card, players int, targetHalfWidth float64, maxHands, workers int, randGen *rand.Rand, progress poker.ProgressFunc) (*poker.Simulator, error) {
+	newDealer := stud.NewDealer(yourCards, opponentUpCards, deadCards, players, gameOutcomes)
+	return poker.SimulateAdaptive(ctx, poker.AceToFiveLowRanking, players, targetHalfWidth, maxHands, workers, newDealer, randGen, progress)
+}
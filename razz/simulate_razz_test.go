@@ -0,0 +1,77 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package razz
+
+import (
+	"context"
+	"github.com/amdw/gopoker/poker"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestSimulate(t *testing.T) {
+	sim := SimulateRazz(h("AS", "2C", "3D"), nil, nil, 4, 10000, rand.New(rand.NewSource(1234)))
+	poker.TestAssertSimSanity(sim, 4, 10000, t)
+	if expected := hl("HighCard", "5", "4", "3", "2", "A"); !reflect.DeepEqual(expected, sim.BestHand) {
+		t.Errorf("Expected best hand %v, found %v", expected, sim.BestHand)
+	}
+	// Three wheel cards against a king showing is a big favourite; against three lower up cards it is not
+	good := SimulateRazz(h("AS", "2C", "3D"), [][]poker.Card{h("KH")}, nil, 2, 5000, rand.New(rand.NewSource(1234)))
+	poker.TestAssertSimSanity(good, 2, 5000, t)
+	bad := SimulateRazz(h("AS", "2C", "QD"), [][]poker.Card{h("3H", "4H", "5H")}, nil, 2, 5000, rand.New(rand.NewSource(1234)))
+	poker.TestAssertSimSanity(bad, 2, 5000, t)
+	if good.Equity().Mean < 0.7 || bad.Equity().Mean > 0.3 {
+		t.Errorf("Unexpected equities %v (against a king) and %v (against three wheel cards)", good.Equity(), bad.Equity())
+	}
+}
+
+func TestSimulateParallel(t *testing.T) {
+	yourCards := h("AS", "4C", "7D", "8H")
+	opponentUpCards := [][]poker.Card{h("2D", "KH"), h("3C", "6S")}
+	sim := SimulateRazzParallel(yourCards, opponentUpCards, h("5C"), 3, 5000, 4, rand.New(rand.NewSource(1234)))
+	poker.TestAssertSimSanity(sim, 3, 5000, t)
+	other := SimulateRazzParallel(yourCards, opponentUpCards, h("5C"), 3, 5000, 1, rand.New(rand.NewSource(1234)))
+	if !reflect.DeepEqual(sim, other) {
+		t.Errorf("Expected identical results with different numbers of workers")
+	}
+}
+
+func TestSimulateAdaptive(t *testing.T) {
+	sim, err := SimulateRazzAdaptive(context.Background(), h("AS", "2C", "3D"), nil, nil, 2, 0.02, 100000, 0, rand.New(rand.NewSource(1234)), nil)
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+	if halfWidth := sim.Equity().HalfWidth(poker.Z95); halfWidth > 0.02 || sim.HandCount >= 100000 {
+		t.Errorf("Expected precision 0.02 well within 100000 hands, found %v after %v", halfWidth, sim.HandCount)
+	}
+	if sim.Ranking != poker.AceToFiveLowRanking {
+		t.Errorf("Expected low ranking to be kept, found %v", sim.Ranking)
+	}
+}
+
+func TestSimulateCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	sim, err := SimulateRazzContext(ctx, nil, nil, nil, 3, 1000, rand.New(rand.NewSource(1234)), nil)
+	if err != context.Canceled || sim.HandCount >= 1000 {
+		t.Errorf("Expected cancellation, found %v after %v hands", err, sim.HandCount)
+	}
+}
@@ -73,12 +73,16 @@ func main() {
 	log.Printf("Listening on port %v...\n", port)
 
 	http.HandleFunc("/", poker_http.Menu)
-	http.HandleFunc("/holdem/play", poker_http.PlayHoldem)
 	http.HandleFunc("/holdem/simulate", poker_http.SimulateHoldem(staticBaseDir))
 	http.HandleFunc("/holdem/startingcards", poker_http.StartingCards(staticBaseDir))
 	http.HandleFunc("/holdem/startingcards/sim", poker_http.SimulateStartingCards)
-	http.HandleFunc("/omaha8/play", poker_http.PlayOmaha8)
-	http.HandleFunc("/omaha8/simulate", poker_http.SimulateOmaha8)
+	http.HandleFunc("/holdem/rangeequity", poker_http.RangeEquity)
+	http.HandleFunc("/razz/play", poker_http.PlayRazz)
+	http.HandleFunc("/razz/simulate", poker_http.SimulateRazz)
+	http.HandleFunc("/draw/simulate", poker_http.SimulateDraw)
+	http.HandleFunc("/game/play", poker_http.PlayGame)
+	http.HandleFunc("/game/simulate", poker_http.SimulateGame)
+	http.HandleFunc("/history/allinev", poker_http.AllInEV)
 	err = http.ListenAndServe(fmt.Sprintf(":%v", port), nil)
 	if err != nil {
 		log.Fatal("ListenAndServe: ", err)
@@ -5,9 +5,15 @@ app.controller('simulatorController', function($scope, $window, $sce) {
     $scope.yourCards = initYourCards;
     $scope.tableCards = initTableCards;
     $scope.simulationCount = initSimCount;
+    $scope.precision = initPrecision;
+    $scope.ranges = initRanges;
+    $scope.game = initGame;
+    $scope.tripsBeatStraight = initTripsBeatStraight;
     $scope.potSize = 1000;
 
-    $scope.legalRanks = ["2", "3", "4", "5", "6", "7", "8", "9", "10", "J", "Q", "K", "A"];
+    var allRanks = ["2", "3", "4", "5", "6", "7", "8", "9", "10", "J", "Q", "K", "A"];
+    var shortDeckRanks = allRanks.slice(4);
+    $scope.legalRanks = allRanks;
     $scope.legalSuits = ["C", "D", "H", "S"];
 
     $scope.yourPendingSuit = "";
@@ -22,6 +28,14 @@ app.controller('simulatorController', function($scope, $window, $sce) {
         $scope.playerCount += 1;
     };
 
+    $scope.opponentNumbers = function() {
+        var result = [];
+        for (var i = 1; i < parseInt($scope.playerCount); i++) {
+            result.push(i);
+        }
+        return result;
+    };
+
     $scope.displaySuit = function(suit) {
         switch (suit) {
             case "C":
@@ -173,6 +187,19 @@ app.controller('simulatorController', function($scope, $window, $sce) {
         return {'active': rank == $scope.tablePendingRank && !$scope.tableCardsFull()};
     };
 
+    $scope.isShortDeck = function() {
+        return $scope.game == "shortdeck";
+    };
+    $scope.gameChanged = function() {
+        $scope.legalRanks = $scope.isShortDeck() ? shortDeckRanks : allRanks;
+        var isLegal = function(card) {
+            return $scope.legalRanks.indexOf(card.toUpperCase().slice(0, -1)) >= 0;
+        };
+        $scope.yourCards = $scope.yourCards.filter(isLegal);
+        $scope.tableCards = $scope.tableCards.filter(isLegal);
+    };
+    $scope.gameChanged();
+
     $scope.potOddsMessage = function() {
         if (potOddsBreakEven == Infinity) {
             return $sce.trustAsHtml("<b>Any</b> bet size has positive expected value! :)");
@@ -186,13 +213,27 @@ app.controller('simulatorController', function($scope, $window, $sce) {
 
     $scope.compute = function() {
         var parts = ["players=" + $scope.playerCount];
+        if ($scope.isShortDeck()) {
+            parts.push("game=shortdeck");
+            if ($scope.tripsBeatStraight) {
+                parts.push("tripsbeatstraight=true");
+            }
+        }
         if ($scope.yourCards.length > 0) {
             parts.push("yours=" + $scope.yourCardsUri());
         }
         if ($scope.tableCards.length > 0) {
             parts.push("table=" + $scope.tableCardsUri());
         }
+        for (var i = 0; i < parseInt($scope.playerCount) - 1; i++) {
+            if ($scope.ranges[i] && !$scope.isShortDeck()) {
+                parts.push("range" + (i + 1) + "=" + encodeURIComponent($scope.ranges[i]));
+            }
+        }
         parts.push("simcount=" + $scope.simulationCount);
+        if ($scope.precision) {
+            parts.push("precision=" + encodeURIComponent($scope.precision));
+        }
         parts.push("compute=true");
         $window.location.href = "/holdem/simulate?" + parts.join("&");
     };
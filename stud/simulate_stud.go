@@ -0,0 +1,74 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package stud
+
+import (
+	"context"
+	"github.com/amdw/gopoker/poker"
+	"math/rand"
+)
+
+// Dealers for simulating any seven-card stud game. Each hand, the known cards (see ValidateKnownCards) are fixed
+// where Deal gives them out and the rest of the pack is shuffled; outcomes then works out how the hand ends.
+func NewDealer(yourCards []poker.Card, opponentUpCards [][]poker.Card, deadCards []poker.Card, players int, outcomes func(playerCards [][]poker.Card) []poker.GameOutcome) func() poker.Dealer {
+	fixing := Fixing(yourCards, opponentUpCards, deadCards)
+	return func() poker.Dealer {
+		p := poker.NewPack()
+		return func(randGen *rand.Rand) ([]poker.GameOutcome, error) {
+			p.ShuffleFixed(randGen, &fixing)
+			return outcomes(Deal(&p, players)), nil
+		}
+	}
+}
+
+// The outcomes of a hand of seven-card stud, for a simulation
+func gameOutcomes(playerCards [][]poker.Card) []poker.GameOutcome {
+	outcomes := PlayerOutcomes(playerCards)
+	result := make([]poker.GameOutcome, len(outcomes))
+	for i, o := range outcomes {
+		result[i] = poker.GameOutcome{Player: o.Player, Level: o.Level, Cards: o.Cards, PotFractionWon: o.PotFractionWon}
+	}
+	return result
+}
+
+// Simulate hands of seven-card stud, given the cards known so far (see ValidateKnownCards, which they must pass)
+func SimulateStud(yourCards []poker.Card, opponentUpCards [][]poker.Card, deadCards []poker.Card, players, handsToPlay int, randGen *rand.Rand) *poker.Simulator {
+	sim, _ := SimulateStudContext(context.Background(), yourCards, opponentUpCards, deadCards, players, handsToPlay, randGen, nil)
+	return sim
+}
+
+// SimulateStud with cancellation and progress reports, as for poker.Simulate
+func SimulateStudContext(ctx context.Context, yourCards []poker.Card, opponentUpCards [][]poker.Card, deadCards []poker.Card, players, handsToPlay int, randGen *rand.Rand, progress poker.ProgressFunc) (*poker.Simulator, error) {
+	deal := NewDealer(yourCards, opponentUpCards, deadCards, players, gameOutcomes)()
+	return poker.Simulate(ctx, poker.HighRanking, players, handsToPlay, deal, randGen, progress)
+}
+
+// SimulateStud spread over several workers (one per CPU if workers is not positive), with the same results
+// whatever their number
+func SimulateStudParallel(yourCards []poker.Card, opponentUpCards [][]poker.Card, deadCards []poker.Card, players, handsToPlay, workers int, randGen *rand.Rand) *poker.Simulator {
+	sim, _ := SimulateStudParallelContext(context.Background(), yourCards, opponentUpCards, deadCards, players, handsToPlay, workers, randGen, nil)
+	return sim
+}
+
+// SimulateStudParallel with cancellation and progress reports, as for poker.SimulateParallel
+func SimulateStudParallelContext(ctx context.Context, yourCards []poker.Card, opponentUpCards [][]poker.Card, deadCards []poker.Card, players, handsToPlay, workers int, randGen *rand.Rand, progress poker.ProgressFunc) (*poker.Simulator, error) {
+	newDealer := NewDealer(yourCards, opponentUpCards, deadCards, players, gameOutcomes)
+	return poker.SimulateParallel(ctx, poker.HighRanking, players, handsToPlay, workers, newDealer, randGen, progress)
+}
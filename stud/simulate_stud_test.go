@@ -0,0 +1,89 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package stud
+
+import (
+	"context"
+	"github.com/amdw/gopoker/poker"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestShuffleFixing(t *testing.T) {
+	pack := poker.NewPack()
+	randGen := rand.New(rand.NewSource(1234))
+	yourCards := h("AS", "KS", "QS", "JS")
+	opponentUpCards := [][]poker.Card{h("2C", "3C"), nil, h("4D")}
+	deadCards := h("5H", "6H")
+	for i := 0; i < 100; i++ {
+		ShuffleFixing(&pack, yourCards, opponentUpCards, deadCards, randGen)
+		poker.TestPackPermutation(&pack, t)
+		playerCards := Deal(&pack, 4)
+		if !poker.CardsEqual(yourCards, playerCards[0][:4]) {
+			t.Errorf("Expected our cards %v, found %v", yourCards, playerCards[0])
+		}
+		for opponent, upCards := range opponentUpCards {
+			if up := UpCards(playerCards[opponent+1]); len(upCards) > 0 && !poker.CardsEqual(upCards, up[:len(upCards)]) {
+				t.Errorf("Expected opponent %v up cards to start %v, found %v", opponent+1, upCards, up)
+			}
+		}
+		dealt := poker.CardSet(0)
+		for _, hand := range playerCards {
+			dealt = dealt.Union(poker.NewCardSet(hand...))
+		}
+		if dealt.Intersects(poker.NewCardSet(deadCards...)) {
+			t.Errorf("Dead cards %v were dealt", deadCards)
+		}
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	sim := SimulateStud(h("AS", "AC"), nil, nil, 4, 10000, rand.New(rand.NewSource(1234)))
+	poker.TestAssertSimSanity(sim, 4, 10000, t)
+	// Rolled-up aces are a big favourite, but the open aces of an opponent leave us drawing almost dead to a set
+	rolledUp := SimulateStud(h("AS", "AC", "AD"), nil, nil, 2, 5000, rand.New(rand.NewSource(1234)))
+	poker.TestAssertSimSanity(rolledUp, 2, 5000, t)
+	blocked := SimulateStud(h("AS", "AC", "2D"), [][]poker.Card{h("AD")}, h("AH"), 2, 5000, rand.New(rand.NewSource(1234)))
+	poker.TestAssertSimSanity(blocked, 2, 5000, t)
+	if rolledUp.Equity().Mean < 0.75 || blocked.Equity().Mean > rolledUp.Equity().Mean-0.1 {
+		t.Errorf("Unexpected equities %v (rolled up) and %v (aces blocked)", rolledUp.Equity(), blocked.Equity())
+	}
+}
+
+func TestSimulateParallel(t *testing.T) {
+	yourCards := h("KS", "KC", "2D", "9H")
+	opponentUpCards := [][]poker.Card{h("QD", "QH"), h("3C", "7S")}
+	sim := SimulateStudParallel(yourCards, opponentUpCards, nil, 3, 5000, 4, rand.New(rand.NewSource(1234)))
+	poker.TestAssertSimSanity(sim, 3, 5000, t)
+	other := SimulateStudParallel(yourCards, opponentUpCards, nil, 3, 5000, 1, rand.New(rand.NewSource(1234)))
+	if !reflect.DeepEqual(sim, other) {
+		t.Errorf("Expected identical results with different numbers of workers")
+	}
+}
+
+func TestSimulateCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	sim, err := SimulateStudContext(ctx, nil, nil, nil, 3, 1000, rand.New(rand.NewSource(1234)), nil)
+	if err != context.Canceled || sim.HandCount >= 1000 {
+		t.Errorf("Expected cancellation, found %v after %v hands", err, sim.HandCount)
+	}
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package stud
+
+import (
+	"errors"
+	"fmt"
+	"github.com/amdw/gopoker/poker"
+	"math/rand"
+)
+
+// Each player gets seven cards: two down, four up (third to sixth streets) and a final one down on seventh street
+const CardsPerPlayer = 7
+
+// With seven cards each, no more than seven players can be dealt in from one pack
+const MaxPlayers = 52 / CardsPerPlayer
+
+// Which of a player's cards are dealt face up, in the order their cards are dealt
+var faceUp = [CardsPerPlayer]bool{false, false, true, true, true, true, false}
+
+// The cards in a player's hand which are visible to the other players
+func UpCards(hand []poker.Card) []poker.Card {
+	result := []poker.Card{}
+	for i, c := range hand {
+		if faceUp[i] {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// The cards in a player's hand which only they can see
+func DownCards(hand []poker.Card) []poker.Card {
+	result := []poker.Card{}
+	for i, c := range hand {
+		if !faceUp[i] {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// Deal seven cards to each player, in the order they are dealt (two down, four up, one down)
+func Deal(pack *poker.Pack, players int) [][]poker.Card {
+	if players < 1 || players > MaxPlayers {
+		panic(fmt.Sprintf("Between 1 and %v players supported, found %v", MaxPlayers, players))
+	}
+	playerCards := make([][]poker.Card, players)
+	for i := 0; i < players; i++ {
+		playerCards[i] = pack.Cards[i*CardsPerPlayer : (i+1)*CardsPerPlayer]
+	}
+	return playerCards
+}
+
+type PlayerOutcome struct {
+	Player         int
+	Level          poker.HandLevel
+	Cards          []poker.Card
+	Won            bool
+	PotFractionWon float64
+}
+
+// Assess the best five-card hand each player can make from their seven cards, and work out how the pot is divided.
+// Outcomes are in the same order as playerCards, with player numbers starting from 1.
+func PlayerOutcomes(playerCards [][]poker.Card) []PlayerOutcome {
+	result := make([]PlayerOutcome, len(playerCards))
+	strengths := make([]poker.HandStrength, len(playerCards))
+	var bestStrength poker.HandStrength
+	for i, hand := range playerCards {
+		cards := make([]poker.Card, 5)
+		strength := poker.Evaluate7Best(hand, cards)
+		result[i] = PlayerOutcome{i + 1, strength.Level(), cards, false, 0}
+		strengths[i] = strength
+		if strength > bestStrength {
+			bestStrength = strength
+		}
+	}
+
+	winners := 0
+	for _, strength := range strengths {
+		if strength == bestStrength {
+			winners++
+		}
+	}
+	for i, strength := range strengths {
+		if strength == bestStrength {
+			result[i].Won = true
+			result[i].PotFractionWon = 1.0 / float64(winners)
+		}
+	}
+	return result
+}
+
+// Check the cards known part-way through a hand, for use in simulations. yourCards are your own cards in the order
+// they were dealt, opponentUpCards are the up cards showing for each opponent in turn (nil for those not yet known)
+// and deadCards are any other cards seen, such as the up cards of players who have folded.
+func ValidateKnownCards(yourCards []poker.Card, opponentUpCards [][]poker.Card, deadCards []poker.Card, players int) error {
+	if players < 2 || players > MaxPlayers {
+		return errors.New(fmt.Sprintf("Between 2 and %v players required, found %v", MaxPlayers, players))
+	}
+	if len(yourCards) > CardsPerPlayer {
+		return errors.New(fmt.Sprintf("Maximum of %v player cards allowed, found %v", CardsPerPlayer, len(yourCards)))
+	}
+	if len(opponentUpCards) > players-1 {
+		return errors.New(fmt.Sprintf("Up cards given for %v opponents, but there are only %v", len(opponentUpCards), players-1))
+	}
+	upCount := len(UpCards(make([]poker.Card, CardsPerPlayer)))
+	seen := poker.NewCardSet(yourCards...)
+	count := len(yourCards)
+	for i, cards := range opponentUpCards {
+		if len(cards) > upCount {
+			return errors.New(fmt.Sprintf("Maximum of %v up cards allowed for opponent %v, found %v", upCount, i+1, len(cards)))
+		}
+		seen = seen.Union(poker.NewCardSet(cards...))
+		count += len(cards)
+	}
+	if maxDead := 52 - players*CardsPerPlayer; len(deadCards) > maxDead {
+		return errors.New(fmt.Sprintf("Maximum of %v dead cards allowed with %v players, found %v", maxDead, players, len(deadCards)))
+	}
+	seen = seen.Union(poker.NewCardSet(deadCards...))
+	count += len(deadCards)
+	if seen.Count() != count {
+		return errors.New("Duplicate cards found in specification")
+	}
+	return poker.ValidateStandardCards(append([][]poker.Card{yourCards, deadCards}, opponentUpCards...)...)
+}
+
+// Where the known cards (see ValidateKnownCards) will be dealt from: your cards and each opponent's up cards where
+// Deal gives them out, and the dead cards at the bottom of the pack, after all the players' cards. The known cards
+// must be valid.
+func Fixing(yourCards []poker.Card, opponentUpCards [][]poker.Card, deadCards []poker.Card) poker.Fixing {
+	result := poker.Fixing{}
+	result.Place(0, yourCards...)
+	for opponent, cards := range opponentUpCards {
+		j := 0
+		for i := 0; i < CardsPerPlayer && j < len(cards); i++ {
+			if faceUp[i] {
+				result.Place((opponent+1)*CardsPerPlayer+i, cards[j])
+				j++
+			}
+		}
+	}
+	result.PlaceAtBottom(52, deadCards...)
+	return result
+}
+
+// Shuffle the pack, but fix the known cards where Fixing puts them
+func ShuffleFixing(pack *poker.Pack, yourCards []poker.Card, opponentUpCards [][]poker.Card, deadCards []poker.Card, randGen *rand.Rand) {
+	f := Fixing(yourCards, opponentUpCards, deadCards)
+	pack.ShuffleFixed(randGen, &f)
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package stud
+
+import (
+	"github.com/amdw/gopoker/poker"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var h = poker.TestMakeHand
+var hl = poker.TestMakeHandLevel
+
+func TestDeal(t *testing.T) {
+	pack := poker.NewPack()
+	pack.Shuffle(rand.New(rand.NewSource(1234)))
+	playerCards := Deal(&pack, MaxPlayers)
+	seen := poker.CardSet(0)
+	for i, hand := range playerCards {
+		if len(hand) != 7 {
+			t.Errorf("Expected seven cards for player %v, found %v", i+1, len(hand))
+		}
+		seen = seen.Union(poker.NewCardSet(hand...))
+	}
+	if seen.Count() != 7*MaxPlayers {
+		t.Errorf("Expected %v distinct cards, found %v", 7*MaxPlayers, seen.Count())
+	}
+}
+
+func TestUpAndDownCards(t *testing.T) {
+	hand := h("AS", "KS", "2C", "3C", "4C", "5C", "QS")
+	if up := UpCards(hand); !reflect.DeepEqual(h("2C", "3C", "4C", "5C"), up) {
+		t.Errorf("Unexpected up cards %v", up)
+	}
+	if down := DownCards(hand); !reflect.DeepEqual(h("AS", "KS", "QS"), down) {
+		t.Errorf("Unexpected down cards %v", down)
+	}
+	// Part-way through the hand
+	if up := UpCards(hand[:4]); !reflect.DeepEqual(h("2C", "3C"), up) {
+		t.Errorf("Unexpected up cards on fourth street %v", up)
+	}
+}
+
+func TestPlayerOutcomes(t *testing.T) {
+	playerCards := [][]poker.Card{
+		h("6C", "KS", "2C", "3C", "4C", "5C", "QS"),  // Straight flush
+		h("AH", "AD", "AC", "KH", "KD", "2H", "3D"),  // Full house
+		h("7H", "8H", "9H", "10D", "JD", "2D", "2S"), // Straight
+	}
+	outcomes := PlayerOutcomes(playerCards)
+	expected := []poker.HandLevel{hl("StraightFlush", "6"), hl("FullHouse", "A", "K"), hl("Straight", "J")}
+	for i, outcome := range outcomes {
+		if outcome.Player != i+1 || !reflect.DeepEqual(expected[i], outcome.Level) || outcome.Won != (i == 0) {
+			t.Errorf("Unexpected outcome for player %v: %v", i+1, outcome)
+		}
+		if !reflect.DeepEqual(expected[i], poker.ClassifyHand(outcome.Cards)) {
+			t.Errorf("Expected best cards %v to classify as %v", outcome.Cards, expected[i])
+		}
+	}
+
+	// Split pot
+	outcomes = PlayerOutcomes([][]poker.Card{
+		h("AS", "KS", "QS", "JD", "10C", "2C", "3C"),
+		h("AD", "KD", "QC", "JH", "10S", "2D", "3D"),
+	})
+	for _, outcome := range outcomes {
+		if !outcome.Won || outcome.PotFractionWon != 0.5 {
+			t.Errorf("Expected split pot, found %v", outcome)
+		}
+	}
+}
+
+func TestValidateKnownCards(t *testing.T) {
+	if err := ValidateKnownCards(h("AS", "KS", "QS"), [][]poker.Card{h("2C"), nil, h("3C")}, h("4C", "5C"), 4); err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+	testCases := []struct {
+		yourCards       []poker.Card
+		opponentUpCards [][]poker.Card
+		deadCards       []poker.Card
+		players         int
+		expected        string
+	}{
+		{nil, nil, nil, 8, "Between 2 and 7 players"},
+		{h("AS", "KS", "QS", "JS", "10S", "9S", "8S", "7S"), nil, nil, 2, "Maximum of 7 player cards"},
+		{nil, [][]poker.Card{h("2C"), h("3C")}, nil, 2, "only 1"},
+		{nil, [][]poker.Card{h("2C", "3C", "4C", "5C", "6C")}, nil, 2, "Maximum of 4 up cards"},
+		{nil, nil, h("2C", "3C", "4C", "5C"), 7, "Maximum of 3 dead cards"},
+		{h("AS"), [][]poker.Card{h("AS")}, nil, 2, "Duplicate"},
+		{nil, [][]poker.Card{nil, h("JK1")}, nil, 3, "not in a standard pack"},
+	}
+	for _, tc := range testCases {
+		err := ValidateKnownCards(tc.yourCards, tc.opponentUpCards, tc.deadCards, tc.players)
+		if err == nil || !strings.Contains(err.Error(), tc.expected) {
+			t.Errorf("Expected error containing %q, found %v", tc.expected, err)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package stud8
+
+import (
+	"context"
+	"github.com/amdw/gopoker/poker"
+	"github.com/amdw/gopoker/stud"
+	"math/rand"
+)
+
+// The outcomes of a hand of stud hi-lo, ranked by their high hands. The low hands are reported alongside, so that
+// the simulator can keep track of the low half of the pot.
+func gameOutcomes(playerCards [][]poker.Card) []poker.GameOutcome {
+	outcomes := PlayerOutcomes(playerCards)
+	result := make([]poker.GameOutcome, len(outcomes))
+	for i, o := range outcomes {
+		result[i] = poker.GameOutcome{Player: o.Player, Level: o.Level.HighLevel, Cards: o.Level.HighHand, PotFractionWon: o.PotFractionWon(),
+			Low: o.Level.LowLevel, LowCards: o.Level.LowHand, HasLow: o.Level.LowLevelQualifies, LowPotFractionWon: o.LowPotFractionWon}
+	}
+	return result
+}
+
+// Simulate hands of seven-card stud hi-lo, given the cards known so far (see stud.ValidateKnownCards, which they
+// must pass). Besides the usual results for the whole pot, the simulator counts how often we make an eight-or-better
+// low and how much of the low half we take.
+func SimulateStud8(yourCards []poker.Card, opponentUpCards [][]poker.Card, deadCards []poker.Card, players, handsToPlay int, randGen *rand.Rand) *poker.Simulator {
+	sim, _ := SimulateStud8Context(context.Background(), yourCards, opponentUpCards, deadCards, players, handsToPlay, randGen, nil)
+	return sim
+}
+
+// SimulateStud8, giving up with ctx.Err() and the hands played so far if ctx is cancelled, and reporting progress
+// to progress if it is non-nil
+func SimulateStud8Context(ctx context.Context, yourCards []poker.Card, opponentUpCards [][]poker.Card, deadCards []poker.Card, players, handsToPlay int, randGen *rand.Rand, progress poker.ProgressFunc) (*poker.Simulator, error) {
+	deal := stud.NewDealer(yourCards, opponentUpCards, deadCards, players, gameOutcomes)()
+	return poker.Simulate(ctx, poker.HighRanking, players, handsToPlay, deal, randGen, progress)
+}
+
+// SimulateStud8 shared between workers goroutines, or one per CPU if workers is not positive. The seed of randGen
+// alone determines the results.
+func SimulateStud8Parallel(yourCards []poker.Card, opponentUpCards [][]poker.Card, deadCards []poker.Card, players, handsToPlay, workers int, randGen *rand.Rand) *poker.Simulator {
+	sim, _ := SimulateStud8ParallelContext(context.Background(), yourCards, opponentUpCards, deadCards, players, handsToPlay, workers, randGen, nil)
+	return sim
+}
+
+// SimulateStud8Parallel, with cancellation and progress reporting as for SimulateStud8Context
+func SimulateStud8ParallelContext(ctx context.Context, yourCards []poker.Card, opponentUpCards [][]poker.Card, deadCards []poker.Card, players, handsToPlay, workers int, randGen *rand.Rand, progress poker.ProgressFunc) (*poker.Simulator, error) {
+	newDealer := stud.NewDealer(yourCards, opponentUpCards, deadCards, players, gameOutcomes)
+	return poker.SimulateParallel(ctx, poker.HighRanking, players, handsToPlay, workers, newDealer, randGen, progress)
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package stud8
+
+import (
+	"context"
+	"github.com/amdw/gopoker/poker"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func assertSimSanity(sim *poker.Simulator, players, simCount int, t *testing.T) {
+	poker.TestAssertSimSanity(sim, players, simCount, t)
+	poker.TestAssertPotsWonSanity(sim.LowWinCount, 2*sim.LowPotsWon, "us (low)", t)
+	if sim.LowWinCount > sim.LowQualifyCount || sim.LowWinCount > sim.WinCount || sim.LowPotsWon > sim.PotsWon {
+		t.Errorf("Inconsistent low results %v qualified, %v won, %v pots for %v hands", sim.LowQualifyCount, sim.LowWinCount, sim.LowPotsWon, simCount)
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	// Three low cards against an opponent showing a pair of kings
+	yourCards := h("AS", "2C", "3D")
+	opponentUpCards := [][]poker.Card{h("KS", "KH")}
+	sim := SimulateStud8(yourCards, opponentUpCards, nil, 2, 5000, rand.New(rand.NewSource(1234)))
+	assertSimSanity(sim, 2, 5000, t)
+	if sim.LowQualifyCount == 0 || sim.LowWinCount == 0 {
+		t.Errorf("Expected some qualifying and winning lows, found %v and %v", sim.LowQualifyCount, sim.LowWinCount)
+	}
+}
+
+func TestSimulateParallel(t *testing.T) {
+	yourCards := h("AS", "2C", "3D", "KH")
+	sim := SimulateStud8Parallel(yourCards, nil, h("4S", "5S"), 3, 2000, 4, rand.New(rand.NewSource(1234)))
+	assertSimSanity(sim, 3, 2000, t)
+	other := SimulateStud8Parallel(yourCards, nil, h("4S", "5S"), 3, 2000, 1, rand.New(rand.NewSource(1234)))
+	if !reflect.DeepEqual(sim, other) {
+		t.Errorf("Expected identical results with different numbers of workers")
+	}
+}
+
+func TestSimulateCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	sim, err := SimulateStud8Context(ctx, nil, nil, nil, 3, 1000, rand.New(rand.NewSource(1234)), nil)
+	if err != context.Canceled || sim.HandCount >= 1000 {
+		t.Errorf("Expected cancellation, found %v after %v hands", err, sim.HandCount)
+	}
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package stud8
+
+import (
+	"github.com/amdw/gopoker/poker"
+	"github.com/amdw/gopoker/stud"
+)
+
+// Deal seven cards to each player, as for seven-card stud
+func Deal(pack *poker.Pack, players int) [][]poker.Card {
+	return stud.Deal(pack, players)
+}
+
+type Stud8Level struct {
+	HighLevel, LowLevel poker.HandLevel
+	HighHand, LowHand   []poker.Card
+	LowLevelQualifies   bool
+}
+
+// Identify the best high and low hands which can be made from a player's seven cards
+func classify(hand []poker.Card) Stud8Level {
+	highHand := make([]poker.Card, 5)
+	highStrength := poker.Evaluate7Best(hand, highHand)
+	lowLevel, lowHand := poker.BestAceToFiveLow(hand)
+	return Stud8Level{highStrength.Level(), lowLevel, highHand, lowHand, poker.IsEightOrBetter(lowLevel)}
+}
+
+type PlayerOutcome struct {
+	Player                                int
+	Level                                 Stud8Level
+	IsHighWinner, IsLowWinner             bool
+	HighPotFractionWon, LowPotFractionWon float64
+}
+
+func (o *PlayerOutcome) PotFractionWon() float64 {
+	return o.HighPotFractionWon + o.LowPotFractionWon
+}
+
+// Work out how the pot is divided between the high and low hands. If nobody has an eight-or-better low,
+// the best high hand takes the whole pot. Outcomes are in the same order as playerCards, with player numbers starting from 1.
+func PlayerOutcomes(playerCards [][]poker.Card) []PlayerOutcome {
+	levels := make([]Stud8Level, len(playerCards))
+	for i, hand := range playerCards {
+		levels[i] = classify(hand)
+	}
+
+	bestHighLevel := levels[0].HighLevel
+	bestLowLevel := levels[0].LowLevel
+	for i := 1; i < len(levels); i++ {
+		if poker.Beats(levels[i].HighLevel, bestHighLevel) {
+			bestHighLevel = levels[i].HighLevel
+		}
+		if poker.BeatsAceToFiveLow(levels[i].LowLevel, bestLowLevel) {
+			bestLowLevel = levels[i].LowLevel
+		}
+	}
+
+	highWinners := make([]int, 0, len(levels))
+	lowWinners := make([]int, 0, len(levels))
+	lowQualified := poker.IsEightOrBetter(bestLowLevel)
+	result := make([]PlayerOutcome, len(levels))
+	for i, level := range levels {
+		result[i] = PlayerOutcome{i + 1, level, false, false, 0, 0}
+		if !poker.Beats(bestHighLevel, level.HighLevel) {
+			highWinners = append(highWinners, i)
+		}
+		if lowQualified && !poker.BeatsAceToFiveLow(bestLowLevel, level.LowLevel) {
+			lowWinners = append(lowWinners, i)
+		}
+	}
+
+	highMultiple := 0.5
+	if len(lowWinners) == 0 {
+		highMultiple = 1.0
+	}
+	for _, i := range highWinners {
+		result[i].IsHighWinner = true
+		result[i].HighPotFractionWon += highMultiple / float64(len(highWinners))
+	}
+	for _, i := range lowWinners {
+		result[i].IsLowWinner = true
+		result[i].LowPotFractionWon += 0.5 / float64(len(lowWinners))
+	}
+
+	return result
+}
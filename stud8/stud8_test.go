@@ -0,0 +1,77 @@
+/*
+Copyright 2026 Andrew Medworth
+
+This file is part of Gopoker, a set of miscellaneous poker-related functions
+written in the Go programming language (http://golang.org).
+
+Gopoker is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Gopoker is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with Gopoker.  If not, see <http://www.gnu.org/licenses/>.
+*/
+package stud8
+
+import (
+	"github.com/amdw/gopoker/poker"
+	"reflect"
+	"testing"
+)
+
+var h = poker.TestMakeHand
+var hl = poker.TestMakeHandLevel
+
+func TestClassify(t *testing.T) {
+	testCases := []struct {
+		hand                 []poker.Card
+		expectedHigh         poker.HandLevel
+		expectedLow          poker.HandLevel
+		expectedLowQualifies bool
+	}{
+		{h("AS", "2C", "3D", "4H", "5S", "KC", "KD"), hl("Straight", "5"), hl("HighCard", "5", "4", "3", "2", "A"), true},
+		{h("AS", "2C", "3D", "9H", "KS", "KC", "KD"), hl("ThreeOfAKind", "K", "A", "9"), hl("HighCard", "K", "9", "3", "2", "A"), false},
+		{h("2H", "4H", "6H", "8H", "10H", "7C", "7D"), hl("Flush", "10", "8", "6", "4", "2"), hl("HighCard", "8", "7", "6", "4", "2"), true},
+	}
+	for _, tc := range testCases {
+		level := classify(tc.hand)
+		if !reflect.DeepEqual(tc.expectedHigh, level.HighLevel) || !reflect.DeepEqual(tc.expectedLow, level.LowLevel) || tc.expectedLowQualifies != level.LowLevelQualifies {
+			t.Errorf("Expected %v, %v (qualifies %v) for %v, found %v", tc.expectedHigh, tc.expectedLow, tc.expectedLowQualifies, tc.hand, level)
+		}
+	}
+}
+
+func TestPlayerOutcomes(t *testing.T) {
+	// The wheel scoops both halves
+	outcomes := PlayerOutcomes([][]poker.Card{
+		h("AS", "2C", "3D", "4H", "5S", "KC", "KD"),
+		h("QS", "QC", "QD", "6H", "7S", "8C", "JD"),
+	})
+	if outcomes[0].PotFractionWon() != 1 || outcomes[1].PotFractionWon() != 0 {
+		t.Errorf("Expected player 1 to scoop, found %v", outcomes)
+	}
+
+	// High and low split
+	outcomes = PlayerOutcomes([][]poker.Card{
+		h("AS", "2C", "3D", "4H", "7S", "KC", "QD"),
+		h("QS", "QC", "QH", "6H", "9S", "9C", "JD"),
+	})
+	if !outcomes[0].IsLowWinner || !outcomes[1].IsHighWinner || outcomes[0].PotFractionWon() != 0.5 || outcomes[1].PotFractionWon() != 0.5 {
+		t.Errorf("Expected a split between low and high, found %v", outcomes)
+	}
+
+	// No qualifying low, so the high hand takes everything
+	outcomes = PlayerOutcomes([][]poker.Card{
+		h("AS", "2C", "9D", "4H", "10S", "KC", "QD"),
+		h("QS", "QC", "QH", "6H", "9S", "9C", "JD"),
+	})
+	if outcomes[0].PotFractionWon() != 0 || outcomes[1].HighPotFractionWon != 1 {
+		t.Errorf("Expected high hand to scoop, found %v", outcomes)
+	}
+}